@@ -2,7 +2,11 @@ package main
 
 import (
 	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/pkg/cli"
+	"github.com/richardwooding/gameoflife/pkg/dev"
 	"github.com/richardwooding/gameoflife/pkg/game"
+	"github.com/richardwooding/gameoflife/pkg/preview"
+	"github.com/richardwooding/gameoflife/pkg/pwa"
 	"github.com/richardwooding/gameoflife/webmode"
 	"log"
 	"net/http"
@@ -18,6 +22,14 @@ func main() {
 	app.RouteWithRegexp("/(.*)", func() app.Composer { return &game.Game{} })
 	app.RunWhenOnBrowser()
 
+	// Command line tools:
+	if len(os.Args) > 1 {
+		if err := cli.Run(os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	webMode := webmode.Live
 	webModeEnv, ok := os.LookupEnv("CONWAYS_GAME_OF_LIFE_WEB_MODE")
 	if ok {
@@ -30,18 +42,24 @@ func main() {
 
 	handler := &app.Handler{
 		Name:        "Conway's Game of Life",
+		Title:       "Conway's Game of Life",
 		Description: "A live demo of Conway's Game of Life.",
-		Icon: app.Icon{
-			Default: "/web/logo.png",
-		},
 		Styles: []string{
 			"/web/gameoflife.css",
 		},
 	}
+	// Previews of shared links need the absolute URLs of their thumbnails, so the live server needs to know the
+	// domain it is reached on.
+	if domain, ok := os.LookupEnv("CONWAYS_GAME_OF_LIFE_DOMAIN"); ok {
+		handler.Domain = domain
+	}
 
 	switch webMode {
 	case webmode.Live:
+		pwa.Configure(handler)
 		// HTTP routing:
+		http.Handle("/manifest.webmanifest", pwa.ManifestHandler(handler))
+		http.Handle(preview.Path, preview.NewHandler(game.DecodeState))
 		http.Handle("/{path...}", handler)
 
 		if err := http.ListenAndServe(":8000", nil); err != nil {
@@ -49,9 +67,23 @@ func main() {
 		}
 	case webmode.Static:
 		handler.Resources = app.GitHubPages("gameoflife")
+		pwa.Configure(handler)
 		if err := app.GenerateStaticWebsite("dist", handler); err != nil {
 			log.Fatal(err)
 		}
+		if err := pwa.WriteManifest("dist", handler); err != nil {
+			log.Fatal(err)
+		}
+	case webmode.Dev:
+		pwa.Configure(handler)
+		dev.Configure(handler)
+		http.Handle("/manifest.webmanifest", pwa.ManifestHandler(handler))
+		http.Handle(preview.Path, preview.NewHandler(game.DecodeState))
+		http.Handle("/{path...}", handler)
+
+		if err := dev.NewServer(dev.DefaultOptions()).ListenAndServe(":8000", http.DefaultServeMux); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 }
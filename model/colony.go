@@ -1,12 +1,19 @@
 package model
 
-import "math/rand"
+import (
+	"errors"
+	"math/rand/v2"
+)
 
 type Colony struct {
 	generation int64
 	dx         int
 	dy         int
 	cells      *[][]bool
+	states     *[][]uint8 // Full cell states, only kept for rules with more than two states
+	rule       Rule
+	random     *RandomOptions // Options of the last Randomize, so the soup can be recreated
+	tracker    *tracker       // Age and activity of each cell, only kept while the colony is tracked
 }
 
 func NewColony(dx, dy int) *Colony {
@@ -22,22 +29,189 @@ func NewColony(dx, dy int) *Colony {
 	}
 }
 
+// SetCells replaces the cells of the colony. Under a multi-state rule, live cells are set to state 1.
 func (c *Colony) SetCells(cells [][]bool) {
 	c.dy = len(cells)
 	c.dx = len(cells[0])
 	c.cells = &cells
+	if c.states != nil {
+		c.states = nil
+		c.initStates()
+	}
 }
 
+// Cells returns the grid of occupied cells, indexed [y][x]. A cell is occupied when it is in any state but 0.
+// Under a multi-state rule, setting a cell through the grid puts it in state 1 and clearing it puts it in state 0.
 func (c *Colony) Cells() *[][]bool {
 	return c.cells
 }
 
+// Rule returns the rule the colony runs under, Conway's Life unless another has been set.
+func (c *Colony) Rule() Rule {
+	if c.rule == nil {
+		return Life
+	}
+	return c.rule
+}
+
+// SetRule changes the rule the colony runs under. Cell states the new rule doesn't have are cleared.
+func (c *Colony) SetRule(r Rule) {
+	c.sync()
+	c.rule = r
+	if r.States() <= 2 {
+		if c.states != nil {
+			for y := 0; y < c.dy; y++ {
+				for x := 0; x < c.dx; x++ {
+					(*c.cells)[y][x] = (*c.states)[y][x] == 1
+				}
+			}
+		}
+		c.states = nil
+		return
+	}
+	c.initStates()
+	for y := 0; y < c.dy; y++ {
+		for x := 0; x < c.dx; x++ {
+			if int((*c.states)[y][x]) >= r.States() {
+				(*c.states)[y][x] = 0
+				(*c.cells)[y][x] = false
+			}
+		}
+	}
+}
+
+// initStates creates the state grid from the occupied cells, if it doesn't exist yet.
+func (c *Colony) initStates() {
+	if c.states != nil {
+		return
+	}
+	states := make([][]uint8, c.dy)
+	for y := range states {
+		states[y] = make([]uint8, c.dx)
+		for x := range states[y] {
+			if (*c.cells)[y][x] {
+				states[y][x] = 1
+			}
+		}
+	}
+	c.states = &states
+}
+
+// sync brings the state grid up to date with cells set or cleared through Cells.
+func (c *Colony) sync() {
+	if c.states == nil {
+		return
+	}
+	for y := 0; y < c.dy; y++ {
+		for x := 0; x < c.dx; x++ {
+			occupied, state := (*c.cells)[y][x], (*c.states)[y][x]
+			if occupied && state == 0 {
+				(*c.states)[y][x] = 1
+			} else if !occupied && state != 0 {
+				(*c.states)[y][x] = 0
+			}
+		}
+	}
+}
+
+// State returns the state of the cell at (x, y): 0 for dead, 1 for alive and higher for other states of the rule.
+func (c *Colony) State(x, y int) uint8 {
+	if x < 0 || y < 0 || x >= c.dx || y >= c.dy {
+		return 0
+	}
+	if c.states == nil {
+		if (*c.cells)[y][x] {
+			return 1
+		}
+		return 0
+	}
+	occupied, state := (*c.cells)[y][x], (*c.states)[y][x]
+	switch {
+	case occupied && state == 0:
+		return 1
+	case !occupied:
+		return 0
+	}
+	return state
+}
+
+// SetState sets the state of the cell at (x, y). States the rule doesn't have are ignored.
+func (c *Colony) SetState(x, y int, state uint8) {
+	if x < 0 || y < 0 || x >= c.dx || y >= c.dy || int(state) >= c.Rule().States() {
+		return
+	}
+	(*c.cells)[y][x] = state != 0
+	if c.states != nil {
+		(*c.states)[y][x] = state
+	}
+}
+
+// StateGrid returns a copy of the states of all cells, indexed [y][x].
+func (c *Colony) StateGrid() [][]uint8 {
+	grid := make([][]uint8, c.dy)
+	for y := range grid {
+		grid[y] = make([]uint8, c.dx)
+		for x := range grid[y] {
+			grid[y][x] = c.State(x, y)
+		}
+	}
+	return grid
+}
+
+// SetStateGrid sets the states of all cells from a grid indexed [y][x] the size of the colony.
+func (c *Colony) SetStateGrid(grid [][]uint8) {
+	for y := 0; y < c.dy && y < len(grid); y++ {
+		for x := 0; x < c.dx && x < len(grid[y]); x++ {
+			c.SetState(x, y, grid[y][x])
+		}
+	}
+}
+
+// Clone returns a deep copy of the colony, including its generation count and rule.
+func (c *Colony) Clone() *Colony {
+	cells := make([][]bool, c.dy)
+	for y := range cells {
+		cells[y] = make([]bool, c.dx)
+		copy(cells[y], (*c.cells)[y])
+	}
+	clone := &Colony{
+		generation: c.generation,
+		dx:         c.dx,
+		dy:         c.dy,
+		cells:      &cells,
+		rule:       c.rule,
+		random:     c.random,
+	}
+	if c.tracker != nil {
+		clone.tracker = c.tracker.clone()
+	}
+	if c.states != nil {
+		states := make([][]uint8, c.dy)
+		for y := range states {
+			states[y] = make([]uint8, c.dx)
+			copy(states[y], (*c.states)[y])
+		}
+		clone.states = &states
+	}
+	return clone
+}
+
+// Width returns the number of columns in the colony.
+func (c *Colony) Width() int {
+	return c.dx
+}
+
+// Height returns the number of rows in the colony.
+func (c *Colony) Height() int {
+	return c.dy
+}
+
 // count returns 1 if the cell at (x, y) is alive, 0 otherwise.
 func (c *Colony) count(x int, y int) uint {
 	if x < 0 || y < 0 || x >= c.dx || y >= c.dy {
 		return 0
 	}
-	if (*c.cells)[y][x] {
+	if (*c.cells)[y][x] && (c.states == nil || (*c.states)[y][x] <= 1) {
 		return 1
 	}
 	return 0
@@ -50,6 +224,18 @@ func (c *Colony) countNeighbours(x int, y int) uint {
 		c.count(x-1, y+1) + c.count(x, y+1) + c.count(x+1, y+1)
 }
 
+// neighbourhood returns the live cells of the 3×3 block centred on (x, y) as a mask, one bit per cell row by
+// row from the top left, so bit 4 is the cell itself.
+func (c *Colony) neighbourhood(x, y int) uint16 {
+	var mask uint16
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			mask |= uint16(c.count(x+dx, y+dy)) << ((dy+1)*3 + dx + 1)
+		}
+	}
+	return mask
+}
+
 func (c *Colony) GetGeneration() int64 {
 	if c == nil {
 		return 0
@@ -57,52 +243,138 @@ func (c *Colony) GetGeneration() int64 {
 	return c.generation
 }
 
+// SetGeneration sets the generation count, as when restoring a saved colony.
+func (c *Colony) SetGeneration(generation int64) {
+	c.generation = generation
+}
+
 func (c *Colony) Generate() {
+	c.sync()
+	rule := c.Rule()
+	if s, ok := rule.(Stepper); ok {
+		step := s.Step(c)
+		c.apply(func(x, y int) uint8 { return step[y][x] })
+	} else {
+		c.apply(func(x, y int) uint8 { return rule.Next(c, x, y) })
+	}
+	c.generation++
+}
+
+var Irreversible = errors.New("rule is not reversible")
+
+// GenerateBackwards steps the colony back to its previous generation, for rules that are reversible.
+func (c *Colony) GenerateBackwards() error {
+	rule, ok := c.Rule().(Reverser)
+	if !ok || !rule.Reversible() {
+		return Irreversible
+	}
+	c.sync()
+	c.generation--
+	c.apply(func(x, y int) uint8 { return rule.Previous(c, x, y) })
+	return nil
+}
+
+// apply replaces every cell with the state returned by next, which sees the colony as it was before.
+func (c *Colony) apply(next func(x, y int) uint8) {
 	ng := make([][]bool, c.dy)
 	for i := range ng {
 		ng[i] = make([]bool, c.dx)
 	}
+	var ns [][]uint8
+	if c.states != nil {
+		ns = make([][]uint8, c.dy)
+		for i := range ns {
+			ns[i] = make([]uint8, c.dx)
+		}
+	}
 	for x := 0; x < c.dx; x++ {
 		for y := 0; y < c.dy; y++ {
-			alive := (*c.cells)[y][x]
-			neighbours := c.countNeighbours(x, y)
-			ng[y][x] = (alive && (neighbours == 2 || neighbours == 3)) || (!alive && neighbours == 3)
+			state := next(x, y)
+			ng[y][x] = state != 0
+			if ns != nil {
+				ns[y][x] = state
+			}
 		}
 	}
 	c.cells = &ng
-	c.generation++
+	if ns != nil {
+		c.states = &ns
+	}
+	c.trackGeneration()
 }
 
+// Toggle advances the cell at (x, y) to its next state, wrapping round to dead after the last state of the rule.
 func (c *Colony) Toggle(x, y int) {
 	if x < 0 || y < 0 || x >= c.dx || y >= c.dy {
 		return
 	}
-	(*c.cells)[y][x] = !(*c.cells)[y][x]
+	if c.states == nil {
+		(*c.cells)[y][x] = !(*c.cells)[y][x]
+		return
+	}
+	c.SetState(x, y, uint8((int(c.State(x, y))+1)%c.Rule().States()))
 }
 
+// IsAlive reports whether the cell at (x, y) is in state 1.
 func (c *Colony) IsAlive(x, y int) bool {
 	if x < 0 || y < 0 || x >= c.dx || y >= c.dy {
 		return false
 	}
+	if c.states != nil {
+		return c.State(x, y) == 1
+	}
 	return (*c.cells)[y][x]
 }
 
 func (c *Colony) Reset() {
 	c.generation = 0
+	c.random = nil
 	for y := 0; y < c.dy; y++ {
 		for x := 0; x < c.dx; x++ {
 			(*c.cells)[y][x] = false
+			if c.states != nil {
+				(*c.states)[y][x] = 0
+			}
 		}
 	}
+	c.retrack()
 }
 
-func (c *Colony) Randomize() {
-	for y := 0; y < c.dy; y++ {
-		for x := 0; x < c.dx; x++ {
-			(*c.cells)[y][x] = rand.Intn(2) == 1
+// Randomize fills a region of the colony with a random soup, leaving the cells outside it alone, and restarts
+// the generation count. The soup depends only on the options, so sharing them shares the soup.
+func (c *Colony) Randomize(o RandomOptions) error {
+	x0, y0, w, h, err := o.region(c)
+	if err != nil {
+		return err
+	}
+	soup := Soup(rand.New(rand.NewPCG(o.Seed, 0)), w, h, o.Density, o.Symmetry)
+	c.sync()
+	for y, row := range soup {
+		for x, alive := range row {
+			(*c.cells)[y0+y][x0+x] = alive
+			if c.states != nil {
+				(*c.states)[y0+y][x0+x] = 0
+			}
 		}
 	}
+	c.sync()
 	c.generation = 0
+	c.random = &o
+	c.retrack()
+	return nil
+}
+
+// RandomOptions returns the options the colony was last randomised with, if it has been since it was cleared.
+func (c *Colony) RandomOptions() (RandomOptions, bool) {
+	if c.random == nil {
+		return RandomOptions{}, false
+	}
+	return *c.random, true
+}
+
+// SetRandomOptions records the options a colony was randomised with, as when restoring a saved colony.
+func (c *Colony) SetRandomOptions(o RandomOptions) {
+	c.random = &o
 }
 
 func (c *Colony) CentreAlive() {
@@ -136,19 +408,36 @@ func (c *Colony) CentreAlive() {
 	if dx == 0 && dy == 0 {
 		return
 	}
+	c.sync()
 	newCells := make([][]bool, c.dy)
 	for y := range newCells {
 		newCells[y] = make([]bool, c.dx)
 	}
+	var newStates [][]uint8
+	if c.states != nil {
+		newStates = make([][]uint8, c.dy)
+		for y := range newStates {
+			newStates[y] = make([]uint8, c.dx)
+		}
+	}
 	for y := minY; y <= maxY; y++ {
 		for x := minX; x <= maxX; x++ {
 			if (*c.cells)[y][x] {
 				nx, ny := x+dx, y+dy
 				if nx >= 0 && nx < c.dx && ny >= 0 && ny < c.dy {
 					newCells[ny][nx] = true
+					if newStates != nil {
+						newStates[ny][nx] = (*c.states)[y][x]
+					}
 				}
 			}
 		}
 	}
 	c.cells = &newCells
+	if newStates != nil {
+		c.states = &newStates
+	}
+	if c.tracker != nil {
+		c.tracker.shift(dx, dy)
+	}
 }
@@ -0,0 +1,63 @@
+Feature: Margolus block rules
+
+  Scenario Outline: Margolus rulestrings are parsed into canonical form
+    When I parse the rule "<rulestring>"
+    Then the rule should be "<canonical>" with 2 states
+
+    Examples:
+      | rulestring                                      | canonical                                       |
+      | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15       | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15       |
+      | ms,d15;14;13;3;11;5;6;1;7;9;10;2;12;4;8;0       | MS,D15;14;13;3;11;5;6;1;7;9;10;2;12;4;8;0       |
+
+  Scenario Outline: Invalid Margolus rulestrings are rejected
+    When I parse the rule "<rulestring>"
+    Then the rule should be invalid
+
+    Examples:
+      | rulestring                                      |
+      | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14          |
+      | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;16       |
+      | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;x        |
+
+  Scenario Outline: Rules whose tables are permutations are reversible
+    When I parse the rule "<rulestring>"
+    Then the rule should <reversible> reversible
+
+    Examples:
+      | rulestring                                      | reversible |
+      | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15       | be         |
+      | MS,D15;14;13;3;11;5;6;1;7;9;10;2;12;4;8;0       | be         |
+      | MS,D0;0;0;0;0;0;0;0;0;0;0;0;0;0;0;15            | not be     |
+
+  Scenario: A Billiard Ball Machine ball moves diagonally through alternating blocks
+    Given a 8x8 colony under the rule "MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15"
+    And the cell at (2,2) is in state 1
+    When 3 generations are computed
+    Then the cell at (5,5) should be in state 1
+    And the colony should have 1 cells in state 1
+
+  Scenario: Blocks wrap round the edges of even sized colonies
+    Given a 8x8 colony under the rule "MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15"
+    And the cell at (6,6) is in state 1
+    When 2 generations are computed
+    Then the cell at (0,0) should be in state 1
+
+  Scenario: Critters inverts empty blocks
+    Given a 4x4 colony under the rule "MS,D15;14;13;3;11;5;6;1;7;9;10;2;12;4;8;0"
+    When the next generation is computed
+    Then the colony should have 16 cells in state 1
+    When the next generation is computed
+    Then the colony should have 0 cells in state 1
+
+  Scenario Outline: Reversible rules run backwards to where they started
+    Given a random 16x16 colony under the rule "<rulestring>"
+    Then running 25 generations forwards and back should restore the colony
+
+    Examples:
+      | rulestring                                      |
+      | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15       |
+      | MS,D15;14;13;3;11;5;6;1;7;9;10;2;12;4;8;0       |
+
+  Scenario: Irreversible rules can't run backwards
+    Given a 8x8 colony under the rule "B3/S23"
+    Then stepping back should fail
@@ -0,0 +1,190 @@
+Feature: Regions of a colony
+
+  Scenario: Taking a region copies its cells
+    Given the colony
+      """
+      .O..
+      ..O.
+      OOO.
+      """
+    When I take the 2x3 region at (1,0)
+    Then the colony should be
+      """
+      O.
+      .O
+      OO
+      """
+
+  Scenario: A region past the edge is dead beyond it
+    Given the colony
+      """
+      .O
+      OO
+      """
+    When I take the 3x2 region at (1,1)
+    Then the colony should be
+      """
+      O..
+      ...
+      """
+
+  Scenario: Regions keep the rule and states of the colony
+    Given the colony under the rule "B2/S/C3"
+      """
+      O2.
+      .2O
+      """
+    When I take the 2x2 region at (1,0)
+    Then the colony should be
+      """
+      2.
+      2O
+      """
+    And the rule should be "B2/S/C3"
+
+  Scenario: Rotating turns the colony clockwise
+    Given the colony
+      """
+      .O.
+      ..O
+      OOO
+      OO.
+      """
+    When I rotate it
+    Then the colony should be
+      """
+      OO..
+      OO.O
+      .OO.
+      """
+
+  Scenario: Four rotations bring the colony back
+    Given the colony
+      """
+      .O.
+      ..O
+      OOO
+      OO.
+      """
+    When I rotate it
+    And I rotate it
+    And I rotate it
+    And I rotate it
+    Then the colony should be
+      """
+      .O.
+      ..O
+      OOO
+      OO.
+      """
+
+  Scenario: Mirroring reflects left to right
+    Given the colony
+      """
+      .O.
+      ..O
+      OOO
+      """
+    When I mirror it
+    Then the colony should be
+      """
+      .O.
+      O..
+      OOO
+      """
+
+  Scenario: Flipping reflects top to bottom
+    Given the colony
+      """
+      .O.
+      ..O
+      OOO
+      """
+    When I flip it
+    Then the colony should be
+      """
+      OOO
+      ..O
+      .O.
+      """
+
+  Scenario: Setting a region overwrites cells and drops what doesn't fit
+    Given the colony
+      """
+      OOOO
+      OOOO
+      OOOO
+      """
+    When I set the region at (2,1) to
+      """
+      .O.
+      O.O
+      """
+    Then the colony should be
+      """
+      OOOO
+      OO.O
+      OOO.
+      """
+
+  Scenario: Clearing inside a rectangle
+    Given the colony
+      """
+      OOOO
+      OOOO
+      OOOO
+      """
+    When I clear the 2x2 rectangle at (1,1)
+    Then the colony should be
+      """
+      OOOO
+      O..O
+      O..O
+      """
+
+  Scenario: Clearing outside a rectangle
+    Given the colony under the rule "B2/S/C3"
+      """
+      O2OO
+      OO2O
+      OOOO
+      """
+    When I clear outside the 2x2 rectangle at (1,0)
+    Then the colony should be
+      """
+      .2O.
+      .O2.
+      ....
+      """
+
+  Scenario: Shifting moves every cell
+    Given the colony under the rule "B2/S/C3"
+      """
+      O2..
+      .O..
+      ....
+      """
+    When I shift it by (2,1)
+    Then it should have moved
+    And the colony should be
+      """
+      ....
+      ..O2
+      ...O
+      """
+
+  Scenario: Shifting cells off the edge is refused
+    Given the colony
+      """
+      .O..
+      ..O.
+      OOO.
+      """
+    When I shift it by (0,-1)
+    Then it should not have moved
+    And the colony should be
+      """
+      .O..
+      ..O.
+      OOO.
+      """
@@ -0,0 +1,285 @@
+Feature: Rules
+
+  Scenario Outline: Rulestrings are parsed into canonical form
+    When I parse the rule "<rulestring>"
+    Then the rule should be "<canonical>" with <states> states
+
+    Examples:
+      | rulestring | canonical     | states |
+      | B3/S23     | B3/S23        | 2      |
+      | b3s23      | B3/S23        | 2      |
+      | S23B3      | B3/S23        | 2      |
+      | 23/3       | B3/S23        | 2      |
+      | B36/S23    | B36/S23       | 2      |
+      | B2/S       | B2/S          | 2      |
+      | B2/S/C3    | B2/S/C3       | 3      |
+      | B2/S/3     | B2/S/C3       | 3      |
+      | /2/3       | B2/S/C3       | 3      |
+      | 345/2/4    | B2/S345/C4    | 4      |
+      | B3/S23/C2  | B3/S23        | 2      |
+
+  Scenario Outline: Invalid rulestrings are rejected
+    When I parse the rule "<rulestring>"
+    Then the rule should be invalid
+
+    Examples:
+      | rulestring |
+      |            |
+      | B9/S23     |
+      | B3/S23/C1  |
+      | B3/S23/C256|
+      | B3/X23     |
+      | 3          |
+
+  Scenario: A Brian's Brain cell that fires becomes refractory, then dies
+    Given a 3x3 colony under the rule "B2/S/C3"
+    And the cell at (1,1) is in state 1
+    When the next generation is computed
+    Then the cell at (1,1) should be in state 2
+    When the next generation is computed
+    Then the cell at (1,1) should be in state 0
+
+  Scenario: Refractory cells are not counted as live neighbours
+    Given a 3x3 colony under the rule "B2/S/C3"
+    And the cell at (0,1) is in state 1
+    And the cell at (2,1) is in state 2
+    When the next generation is computed
+    Then the cell at (1,1) should be in state 0
+
+  Scenario: A dead cell with enough live neighbours fires
+    Given a 3x3 colony under the rule "B2/S/C3"
+    And the cell at (0,1) is in state 1
+    And the cell at (2,1) is in state 1
+    When the next generation is computed
+    Then the cell at (1,1) should be in state 1
+
+  Scenario: Toggling a cell cycles through every state
+    Given a 3x3 colony under the rule "B2/S345/C4"
+    When I toggle the cell at (1,1)
+    Then the cell at (1,1) should be in state 1
+    When I toggle the cell at (1,1)
+    Then the cell at (1,1) should be in state 2
+    When I toggle the cell at (1,1)
+    Then the cell at (1,1) should be in state 3
+    When I toggle the cell at (1,1)
+    Then the cell at (1,1) should be in state 0
+
+  Scenario: Switching to a rule with fewer states clears the extra states
+    Given a 3x3 colony under the rule "B2/S345/C4"
+    And the cell at (0,0) is in state 1
+    And the cell at (1,1) is in state 3
+    When the rule is changed to "B3/S23"
+    Then the cell at (0,0) should be in state 1
+    And the cell at (1,1) should be in state 0
+
+  Scenario Outline: Isotropic non-totalistic rulestrings are parsed into canonical form
+    When I parse the rule "<rulestring>"
+    Then the rule should be "<canonical>" with <states> states
+
+    Examples:
+      | rulestring                         | canonical       | states |
+      | B2-a/S12                           | B2-a/S12        | 2      |
+      | b2ceikn/s12                        | B2-a/S12        | 2      |
+      | B3/S2-i34q                         | B3/S2-i34q      | 2      |
+      | B3ceaiknjqry/S2ceaikn3ceaiknjqry   | B3/S23          | 2      |
+      | B2a2e/S                            | B2ea/S          | 2      |
+      | B4-cetw/S4kn                       | B4-cetw/S4kn    | 2      |
+      | B2-A/S12                           | B2-a/S12        | 2      |
+      | B2-a/S12/C3                        | B2-a/S12/C3     | 3      |
+      | 12/2-a                             | B2-a/S12        | 2      |
+
+  Scenario Outline: Invalid Hensel notation is rejected
+    When I parse the rule "<rulestring>"
+    Then the rule should be invalid
+
+    Examples:
+      | rulestring |
+      | B2z/S23    |
+      | B1a/S      |
+      | B0c/S      |
+      | B8e/S      |
+      | B2-/S      |
+      | B2-a-/S    |
+      | B-a/S      |
+
+  Scenario Outline: Neighbourhood configurations are named by their Hensel letter
+    Then the configuration "<neighbours>" should be "<name>"
+
+    Examples:
+      | neighbours           | name |
+      | NW                   | 1c   |
+      | W                    | 1e   |
+      | N,NE                 | 2a   |
+      | N,E                  | 2e   |
+      | N,SE                 | 2k   |
+      | N,S                  | 2i   |
+      | NE,SE                | 2c   |
+      | NE,SW                | 2n   |
+      | N,NE,E               | 3a   |
+      | N,NE,SE              | 3n   |
+      | N,NE,S               | 3r   |
+      | N,NE,SW              | 3q   |
+      | N,NE,W               | 3j   |
+      | NE,E,SE              | 3i   |
+      | N,E,W                | 3e   |
+      | N,E,SW               | 3k   |
+      | N,SE,SW              | 3y   |
+      | NE,SE,SW             | 3c   |
+      | N,NE,E,SE            | 4a   |
+      | N,NE,E,S             | 4r   |
+      | N,NE,E,SW            | 4q   |
+      | N,NE,SE,S            | 4i   |
+      | N,NE,SE,SW           | 4y   |
+      | N,NE,SE,W            | 4k   |
+      | N,NE,SE,NW           | 4n   |
+      | N,NE,S,SW            | 4z   |
+      | N,NE,S,W             | 4j   |
+      | NW,N,NE,S            | 4t   |
+      | N,NE,SW,W            | 4w   |
+      | N,E,S,W              | 4e   |
+      | NE,SE,SW,NW          | 4c   |
+      | SE,S,SW,W,NW         | 5a   |
+      | NE,E,SE,SW,W,NW      | 6i   |
+      | NE,E,SE,S,SW,W,NW    | 7e   |
+
+  Scenario: Every configuration belongs to exactly one letter
+    Then the letters of every neighbour count should partition its configurations
+
+  Scenario Outline: Births depend on the arrangement of the neighbours
+    Given a 3x3 colony under the rule "B2a/S"
+    And the cell at (<x1>,<y1>) is in state 1
+    And the cell at (<x2>,<y2>) is in state 1
+    When the next generation is computed
+    Then the cell at (1,1) should be in state <state>
+
+    Examples:
+      | x1 | y1 | x2 | y2 | state |
+      | 1  | 0  | 2  | 0  | 1     |
+      | 2  | 1  | 2  | 2  | 1     |
+      | 0  | 2  | 0  | 1  | 1     |
+      | 1  | 0  | 1  | 2  | 0     |
+      | 1  | 0  | 2  | 1  | 0     |
+      | 0  | 0  | 2  | 2  | 0     |
+
+  Scenario: Life written in Hensel notation behaves like Life
+    Then the rule "B3ceaiknjqry/S2-a2a3" should run a random 16x16 colony like "B3/S23" for 50 generations
+
+  Scenario Outline: Larger than Life rulestrings are parsed into canonical form
+    When I parse the rule "<rulestring>"
+    Then the rule should be "<canonical>" with <states> states
+
+    Examples:
+      | rulestring                   | canonical                    | states |
+      | R5,C0,M1,S34..58,B34..45,NM  | R5,C0,M1,S34..58,B34..45,NM  | 2      |
+      | r5,c0,m1,s34..58,b34..45,nm  | R5,C0,M1,S34..58,B34..45,NM  | 2      |
+      | R1,C2,M0,S2..3,B3,NM         | R1,C0,M0,S2..3,B3..3,NM      | 2      |
+      | R3,C4,M1,S2..10,B5..6,NN     | R3,C4,M1,S2..10,B5..6,NN     | 4      |
+      | R2,M0,S1..4,B3..3,NC         | R2,C0,M0,S1..4,B3..3,NC      | 2      |
+      | R4,B41..81,S41..81,M1        | R4,C0,M1,S41..81,B41..81,NM  | 2      |
+
+  Scenario Outline: Invalid Larger than Life rulestrings are rejected
+    When I parse the rule "<rulestring>"
+    Then the rule should be invalid
+
+    Examples:
+      | rulestring                    |
+      | R0,C0,M1,S1..2,B1..2,NM       |
+      | R501,C0,M1,S1..2,B1..2,NM     |
+      | R5,C1,M1,S1..2,B1..2,NM       |
+      | R5,C0,M2,S1..2,B1..2,NM       |
+      | R5,C0,M1,S5..3,B1..2,NM       |
+      | R5,C0,M1,S1..2,NM             |
+      | R5,C0,M1,S1..2,B1..2,NX       |
+      | R5,C0,M1,S1..2,B1..2,NM,R3    |
+      | R5,,S1..2,B1..2               |
+
+  Scenario Outline: Range 1 Larger than Life rules match their Life-like equivalents
+    Then the rule "<ltl>" should run a random 16x16 colony like "<rule>" for 50 generations
+
+    Examples:
+      | ltl                          | rule       |
+      | R1,C0,M0,S2..3,B3..3,NM      | B3/S23     |
+      | R1,C0,M1,S3..4,B3..3,NM      | B3/S23     |
+      | R1,C4,M0,S3..5,B2..2,NM      | B2/S345/C4 |
+
+  Scenario Outline: Neighbourhood shapes cover the expected cells
+    Then the rule "<rule>" should count <count> live cells around the centre of a full 21x21 colony
+
+    Examples:
+      | rule                         | count |
+      | R5,C0,M1,S1..2,B1..2,NM      | 121   |
+      | R5,C0,M0,S1..2,B1..2,NM      | 120   |
+      | R5,C0,M1,S1..2,B1..2,NN      | 61    |
+      | R5,C0,M1,S1..2,B1..2,NC      | 97    |
+
+  Scenario Outline: Summed-area counting agrees with counting each neighbourhood directly
+    Then the rule "<rule>" should step a random 24x24 colony like counting cell by cell
+
+    Examples:
+      | rule                         |
+      | R5,C0,M1,S34..58,B34..45,NM  |
+      | R3,C0,M0,S5..12,B6..9,NN     |
+      | R4,C3,M1,S10..30,B12..20,NC  |
+
+  Scenario Outline: Hexagonal and von Neumann rulestrings are parsed into canonical form
+    When I parse the rule "<rulestring>"
+    Then the rule should be "<canonical>" with <states> states
+
+    Examples:
+      | rulestring | canonical   | states |
+      | B2/S34H    | B2/S34H     | 2      |
+      | b2s34h     | B2/S34H     | 2      |
+      | 34/2H      | B2/S34H     | 2      |
+      | B1/S12V    | B1/S12V     | 2      |
+      | B2/S/C3H   | B2/S/C3H    | 3      |
+      | 34/2/3H    | B2/S34/C3H  | 3      |
+
+  Scenario Outline: Counts beyond the neighbourhood size are rejected
+    When I parse the rule "<rulestring>"
+    Then the rule should be invalid
+
+    Examples:
+      | rulestring |
+      | B7/S23H    |
+      | B5/S1V     |
+      | B2a/S12H   |
+
+  Scenario Outline: Only the cells of the neighbourhood are counted
+    Given a 3x3 colony under the rule "<rule>"
+    And the cell at (<x1>,<y1>) is in state 1
+    And the cell at (<x2>,<y2>) is in state 1
+    When the next generation is computed
+    Then the cell at (1,1) should be in state <state>
+
+    Examples:
+      | rule  | x1 | y1 | x2 | y2 | state |
+      | B2/SH | 0  | 0  | 2  | 2  | 1     |
+      | B2/SH | 2  | 0  | 0  | 2  | 0     |
+      | B2/SH | 2  | 0  | 1  | 0  | 0     |
+      | B2/SH | 0  | 1  | 1  | 2  | 1     |
+      | B2/SV | 1  | 0  | 1  | 2  | 1     |
+      | B2/SV | 0  | 0  | 2  | 2  | 0     |
+      | B2/SV | 0  | 1  | 2  | 0  | 0     |
+
+  Scenario Outline: Two-state rules on adjacent cells have a lookup table that steps like the rule
+    Given a random 16x16 colony under the rule "<rule>"
+    Then the lookup table of the rule should step the colony like the rule
+
+    Examples:
+      | rule                          |
+      | B3/S23                        |
+      | B36/S23                       |
+      | B2/S34H                       |
+      | B2-a/S12                      |
+      | R1,C0,M1,S3..4,B3..3,NM       |
+
+  Scenario Outline: Other rules have no lookup table
+    When I parse the rule "<rule>"
+    Then the rule should have no lookup table
+
+    Examples:
+      | rule                                      |
+      | B2/S/C3                                   |
+      | R2,C0,M1,S3..5,B3..4,NM                   |
+      | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15 |
+      | WireWorld                                 |
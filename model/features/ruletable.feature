@@ -0,0 +1,138 @@
+Feature: Rule tables
+
+  Scenario: WireWorld is bundled with the program
+    When I parse the rule "WireWorld"
+    Then the rule should be "WireWorld" with 4 states
+    And state 1 of the rule should be coloured 0,128,255
+    And "WireWorld" should be one of the bundled rules
+
+  Scenario Outline: WireWorld cells follow the electron rules
+    Given a 3x3 colony under the rule "WireWorld"
+    And the cell at (1,1) is in state <centre>
+    And the cells <heads> are in state 1
+    When the next generation is computed
+    Then the cell at (1,1) should be in state <next>
+
+    Examples:
+      | centre | heads             | next |
+      | 1      |                   | 2    |
+      | 2      |                   | 3    |
+      | 3      |                   | 3    |
+      | 3      | (0,0)             | 1    |
+      | 3      | (2,1)             | 1    |
+      | 3      | (0,0) (2,2)       | 1    |
+      | 3      | (0,0) (1,0) (2,0) | 3    |
+      | 0      | (0,0) (1,0)       | 0    |
+
+  Scenario: A WireWorld clock loop keeps time
+    Given a 12x6 colony under the rule "WireWorld"
+    And the cells (0,0) (1,0) (2,0) (3,0) (4,0) (5,0) (0,5) (1,5) (2,5) (3,5) (4,5) (5,5) are in state 3
+    And the cells (0,1) (0,2) (0,3) (0,4) (5,1) (5,2) (5,3) (5,4) are in state 3
+    And the cell at (2,0) is in state 1
+    And the cell at (3,0) is in state 2
+    When 16 generations are computed
+    Then the cell at (2,0) should be in state 1
+    And the cell at (3,0) should be in state 2
+    And the colony should have 1 cells in state 1
+
+  Scenario: A WireWorld clock sends a pulse down its output wire every period
+    Given a 12x6 colony under the rule "WireWorld"
+    And the cells (0,0) (1,0) (2,0) (3,0) (4,0) (5,0) (0,5) (1,5) (2,5) (3,5) (4,5) (5,5) are in state 3
+    And the cells (0,1) (0,2) (0,3) (0,4) (5,1) (5,2) (5,3) (5,4) are in state 3
+    And the cells (6,2) (7,2) (8,2) (9,2) (10,2) (11,2) are in state 3
+    And the cell at (2,0) is in state 1
+    And the cell at (3,0) is in state 2
+    When 70 generations are computed
+    Then the cell at (11,2) should have been in state 1 in 4 generations, 16 apart
+
+  Scenario: Variables used more than once are bound to the same state
+    Given the rule table
+      """
+      @RULE Bound
+      @TABLE
+      n_states:3
+      neighborhood:vonNeumann
+      symmetries:none
+      var a={1,2}
+      var b={0,1,2}
+      var c={0,1,2}
+      0,a,a,b,c,a
+      """
+    And a 4x3 colony under the rule table
+    And the cell at (1,0) is in state 2
+    And the cell at (2,1) is in state 2
+    And the cell at (3,2) is in state 1
+    When the next generation is computed
+    Then the cell at (1,1) should be in state 2
+    And the cell at (2,2) should be in state 0
+
+  Scenario Outline: Symmetries stand for rotated and reflected transitions
+    Given the rule table
+      """
+      @RULE Symmetric
+      @TABLE
+      n_states:2
+      neighborhood:vonNeumann
+      symmetries:<symmetries>
+      0,1,0,0,0,1
+      """
+    And a 3x3 colony under the rule table
+    And the cell at (<x>,<y>) is in state 1
+    When the next generation is computed
+    Then the cell at (1,1) should be in state <state>
+
+    Examples:
+      | symmetries         | x | y | state |
+      | none               | 1 | 0 | 1     |
+      | none               | 2 | 1 | 0     |
+      | rotate4            | 2 | 1 | 1     |
+      | rotate4            | 1 | 2 | 1     |
+      | reflect_horizontal | 0 | 1 | 0     |
+      | permute            | 0 | 1 | 1     |
+
+  Scenario: Transitions may leave out commas
+    Given the rule table
+      """
+      @RULE Compact
+      @TABLE
+      n_states:2
+      neighborhood:Moore
+      symmetries:rotate8
+      0100000001
+      """
+    And a 3x3 colony under the rule table
+    And the cell at (2,2) is in state 1
+    When the next generation is computed
+    Then the cell at (1,1) should be in state 1
+
+  Scenario: Colours may be given as a gradient
+    Given the rule table
+      """
+      @RULE Gradient
+      @TABLE
+      n_states:3
+      @COLORS
+      0 0 0 0
+      0 0 0 255 255 255
+      """
+    Then state 1 of the rule should be coloured 0,0,0
+    And state 2 of the rule should be coloured 255,255,255
+
+  Scenario Outline: Invalid rule tables are rejected
+    When I parse the rule table
+      """
+      <source>
+      """
+    Then the rule table should be invalid with "<message>"
+
+    Examples:
+      | source                                                              | message                    |
+      | @RULE Empty                                                         | missing @TABLE             |
+      | @TABLE\nn_states:2                                                  | missing @RULE              |
+      | @RULE Tree\n@TREE                                                   | not supported              |
+      | @RULE Big\n@TABLE\nn_states:256                                     | n_states must be between   |
+      | @RULE Odd\n@TABLE\nneighborhood:oneDimensional                      | unsupported neighborhood   |
+      | @RULE Odd\n@TABLE\nsymmetries:rotate3                               | unsupported symmetries     |
+      | @RULE Short\n@TABLE\n0,1,0                                          | expected 10 states         |
+      | @RULE State\n@TABLE\n0,1,0,0,0,0,0,0,0,2                            | invalid state              |
+      | @RULE Free\n@TABLE\nvar a={0,1}\n0,1,0,0,0,0,0,0,0,a                | is not used in the inputs  |
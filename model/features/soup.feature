@@ -0,0 +1,74 @@
+Feature: Random soups
+
+  Scenario: The same options always give the same soup
+    Given a 16x16 colony
+    When it is randomised with seed 42 at density 0.5
+    Then randomising another 16x16 colony with the same options should give the same cells
+    And randomising with seed 43 should give different cells
+
+  Scenario: Only the region is filled
+    Given a 16x16 colony
+    And the cell at (0,0) is alive
+    And the cell at (15,15) is alive
+    When it is randomised with seed 7 at density 1 in a 4x3 region at (2,5)
+    Then the colony should have 14 live cells
+    And the cell at (0,0) should be alive
+    And the cell at (15,15) should be alive
+    And the cell at (2,5) should be alive
+    And the cell at (5,7) should be alive
+    And the cell at (6,7) should be dead
+
+  Scenario Outline: Density sets the share of live cells
+    Given a 40x40 colony
+    When it is randomised with seed 3 at density <density>
+    Then between <least> and <most> cells should be alive
+
+    Examples:
+      | density | least | most |
+      | 0       | 0     | 0    |
+      | 0.25    | 300   | 500  |
+      | 0.5     | 700   | 900  |
+      | 1       | 1600  | 1600 |
+
+  Scenario Outline: Soups have the requested symmetry
+    Given a 20x20 colony
+    When it is randomised with seed 5 at density 0.5 with <symmetry> symmetry in a <width>x<height> region at (3,4)
+    Then the region should be invariant under <transform>
+
+    Examples:
+      | symmetry | width | height | transform           |
+      | C2       | 10    | 10     | rotation by 180     |
+      | C2       | 7     | 12     | rotation by 180     |
+      | C4       | 9     | 9      | rotation by 90      |
+      | D2       | 6     | 11     | a vertical mirror   |
+      | D4       | 8     | 5      | a horizontal mirror |
+      | D4       | 8     | 5      | a vertical mirror   |
+      | D8       | 12    | 12     | a diagonal mirror   |
+      | D8       | 12    | 12     | rotation by 90      |
+
+  Scenario Outline: Options that don't fit are rejected
+    Given a 10x10 colony
+    When it is randomised with seed 1 at density <density> with <symmetry> symmetry in a <width>x<height> region at (<x>,<y>)
+    Then randomising should fail because "<reason>"
+
+    Examples:
+      | density | symmetry | width | height | x  | y | reason                     |
+      | 1.5     | C1       | 0     | 0      | 0  | 0 | density 1.5 is not between |
+      | 0.5     | C1       | 6     | 6      | 5  | 5 | outside the colony         |
+      | 0.5     | C1       | 0     | 0      | 11 | 0 | outside the colony         |
+      | 0.5     | C4       | 6     | 5      | 0  | 0 | C4 symmetry needs a square |
+      | 0.5     | D8       | 0     | 5      | 0  | 0 | D8 symmetry needs a square |
+
+  Scenario: The colony remembers its soup until it is cleared
+    Given a 10x10 colony
+    When it is randomised with seed 9 at density 0.3 with D2 symmetry in a 6x6 region at (2,2)
+    Then the colony should remember seed 9 with D2 symmetry
+    And after clearing the colony it should remember no soup
+
+  Scenario: Randomising a multi-state colony only makes live and dead cells
+    Given a 10x10 colony under the rule "B2/S/C4"
+    And the cell at (0,0) is in state 3
+    And the generation is 5
+    When it is randomised with seed 11 at density 0.5
+    Then every cell should be in state 0 or 1
+    And the generation should be 0
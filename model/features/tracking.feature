@@ -0,0 +1,153 @@
+Feature: Tracking cell age and activity
+
+  Scenario: The heat map tells a blinker's rotor from its stator
+    Given the tracked colony
+      """
+      .....
+      .....
+      .OOO.
+      .....
+      .....
+      """
+    When 4 generations pass
+    Then 5 generations should have been tracked
+    And the cells should have the heat
+      | cell  | heat |
+      | (2,2) | 1    |
+      | (1,2) | 0.6  |
+      | (2,1) | 0.4  |
+      | (0,0) | 0    |
+
+  Scenario: Cells age while they stay alive
+    Given the tracked colony
+      """
+      .....
+      .OO..
+      .OO..
+      .....
+      """
+    When 3 generations pass
+    Then the cells should have the age
+      | cell  | age |
+      | (1,1) | 4   |
+      | (0,0) | 0   |
+
+  Scenario: Cells of an oscillator's rotor are born again young
+    Given the tracked colony
+      """
+      .....
+      .....
+      .OOO.
+      .....
+      .....
+      """
+    When 3 generations pass
+    Then the cells should have the age
+      | cell  | age |
+      | (2,2) | 4   |
+      | (2,1) | 1   |
+      | (1,2) | 0   |
+
+  Scenario: Dead cells leave a fading trail
+    Given the tracked colony
+      """
+      .....
+      .....
+      .OOO.
+      .....
+      .....
+      """
+    When 1 generation passes
+    Then the cells should have the activity
+      | cell  | activity |
+      | (2,2) | 1        |
+      | (1,2) | 0.75     |
+      | (0,0) | 0        |
+    When 2 generations pass
+    Then the cells should have the activity
+      | cell  | activity |
+      | (1,2) | 0.75     |
+      | (2,1) | 1        |
+
+  Scenario: The trail fades with each generation a cell stays dead
+    Given the tracked colony
+      """
+      ...
+      .O.
+      ...
+      """
+    When 2 generations pass
+    Then the cells should have the activity
+      | cell  | activity |
+      | (1,1) | 0.5625   |
+
+  Scenario: An untracked colony only knows which cells are alive
+    Given the colony
+      """
+      .O.
+      .O.
+      .O.
+      """
+    When 1 generation passes
+    Then 0 generations should have been tracked
+    And the cells should have the heat
+      | cell  | heat |
+      | (0,1) | 1    |
+      | (1,0) | 0    |
+    And the cells should have the activity
+      | cell  | activity |
+      | (1,0) | 0        |
+
+  Scenario: Clearing the colony restarts tracking
+    Given the tracked colony
+      """
+      .O.
+      .O.
+      .O.
+      """
+    When 3 generations pass
+    And the colony is reset
+    Then 1 generation should have been tracked
+    And the cells should have the heat
+      | cell  | heat |
+      | (1,1) | 0    |
+
+  Scenario: Centring the cells moves their history with them
+    Given the tracked colony
+      """
+      .......
+      .O.....
+      .O.....
+      .O.....
+      .......
+      """
+    When 1 generation passes
+    And the live cells are centred
+    Then the cells should have the activity
+      | cell  | activity |
+      | (3,1) | 0.75     |
+      | (3,3) | 0.75     |
+      | (1,1) | 0        |
+    And the cells should have the age
+      | cell  | age |
+      | (3,2) | 2   |
+      | (2,2) | 1   |
+      | (1,2) | 0   |
+    And the cells should have the heat
+      | cell  | heat |
+      | (3,2) | 1    |
+      | (1,2) | 0    |
+
+  Scenario: Clones keep their own history
+    Given the tracked colony
+      """
+      .....
+      .....
+      .OOO.
+      .....
+      .....
+      """
+    When it is cloned
+    And 1 generation passes
+    Then the clone should have tracked 1 generation
+    And 2 generations should have been tracked
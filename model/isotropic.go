@@ -0,0 +1,197 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// henselLetters lists the letters of Hensel notation for each neighbour count, in canonical order.
+// Counts 0 and 8 have a single configuration and no letters.
+var henselLetters = [9]string{"", "ce", "ceaikn", "ceaiknjqry", "ceaiknjqrytwz", "ceaiknjqry", "ceaikn", "ce", ""}
+
+// henselNeighbourhoods holds one configuration for each letter of counts 1 to 4, as masks in the bit order of
+// Colony.neighbourhood. Counts 5 to 7 use the complements of the configurations of 3 to 1 with the same letter.
+var henselNeighbourhoods = [5][]uint16{
+	{},
+	{0x001, 0x002},
+	{0x005, 0x00a, 0x003, 0x028, 0x021, 0x044},
+	{0x045, 0x02a, 0x00b, 0x007, 0x062, 0x00d, 0x00e, 0x046, 0x029, 0x061},
+	{0x145, 0x0aa, 0x00f, 0x02d, 0x063, 0x047, 0x06a, 0x066, 0x02b, 0x065, 0x069, 0x04e, 0x06c},
+}
+
+// centreBit is the bit of a neighbourhood mask holding the cell itself.
+const centreBit = 1 << 4
+
+// neighbourMask has a bit set for each of the eight neighbours.
+const neighbourMask = 0x1ff &^ centreBit
+
+// henselRepresentative returns the configuration standing for the letter at index i of neighbour count n.
+func henselRepresentative(n, i int) uint16 {
+	switch {
+	case n == 0:
+		return 0
+	case n == 8:
+		return neighbourMask
+	case n > 4:
+		return neighbourMask ^ henselNeighbourhoods[8-n][i]
+	}
+	return henselNeighbourhoods[n][i]
+}
+
+// henselClass maps every configuration of the eight neighbours to the index of its letter.
+var henselClass = func() [512]uint8 {
+	var class [512]uint8
+	for n := 1; n <= 7; n++ {
+		for i := range henselLetters[n] {
+			for _, mask := range symmetries(henselRepresentative(n, i)) {
+				class[mask] = uint8(i)
+			}
+		}
+	}
+	return class
+}()
+
+// symmetries returns the images of a neighbourhood under the eight rotations and reflections of the square.
+func symmetries(mask uint16) [8]uint16 {
+	var images [8]uint16
+	for s := range images {
+		for bit := 0; bit < 9; bit++ {
+			if mask&(1<<bit) == 0 {
+				continue
+			}
+			x, y := bit%3-1, bit/3-1
+			if s&1 != 0 {
+				x = -x
+			}
+			if s&2 != 0 {
+				y = -y
+			}
+			if s&4 != 0 {
+				x, y = y, x
+			}
+			images[s] |= 1 << ((y+1)*3 + x + 1)
+		}
+	}
+	return images
+}
+
+// Isotropic is an isotropic non-totalistic rule on the Moore neighbourhood, written in Hensel notation such as
+// "B2-a/S12". A cell's next state is looked up from the exact arrangement of its live neighbours, up to rotation
+// and reflection. Like Totalistic, it supports Generations style refractory states.
+type Isotropic struct {
+	Table [512]bool // Whether a cell is alive next, indexed by its neighbourhood with the cell itself as bit 4
+	Count uint8     // Number of states, 2 for two state rules
+}
+
+func (r *Isotropic) String() string {
+	s := "B" + r.half(0) + "/S" + r.half(centreBit)
+	if r.Count > 2 {
+		s += fmt.Sprintf("/C%d", r.Count)
+	}
+	return s
+}
+
+// half writes the birth or survival conditions of the rule, choosing the shorter of listing the letters present
+// or, after a minus sign, the letters absent.
+func (r *Isotropic) half(centre uint16) string {
+	var b strings.Builder
+	for n := 0; n <= 8; n++ {
+		letters := henselLetters[n]
+		if letters == "" {
+			if r.Table[henselRepresentative(n, 0)|centre] {
+				b.WriteByte(byte('0' + n))
+			}
+			continue
+		}
+		var in, out strings.Builder
+		for i := range letters {
+			if r.Table[henselRepresentative(n, i)|centre] {
+				in.WriteByte(letters[i])
+			} else {
+				out.WriteByte(letters[i])
+			}
+		}
+		switch {
+		case in.Len() == 0:
+		case out.Len() == 0:
+			b.WriteByte(byte('0' + n))
+		case out.Len() < in.Len():
+			b.WriteString(fmt.Sprintf("%d-%s", n, out.String()))
+		default:
+			b.WriteString(fmt.Sprintf("%d%s", n, in.String()))
+		}
+	}
+	return b.String()
+}
+
+func (r *Isotropic) States() int {
+	return int(max(r.Count, 2))
+}
+
+func (r *Isotropic) Next(c *Colony, x, y int) uint8 {
+	state := c.State(x, y)
+	if state <= 1 && r.Table[c.neighbourhood(x, y)] {
+		return 1
+	}
+	if state == 0 {
+		return 0
+	}
+	return decay(state, r.States())
+}
+
+// newIsotropic builds the transition table from the letters of each neighbour count allowing birth and survival.
+func newIsotropic(birth, survival [9]uint16, count uint8) *Isotropic {
+	r := &Isotropic{Count: count}
+	for mask := range r.Table {
+		neighbours := uint16(mask) & neighbourMask
+		n, letter := 0, henselClass[neighbours]
+		for m := neighbours; m != 0; m &= m - 1 {
+			n++
+		}
+		conditions := birth
+		if mask&centreBit != 0 {
+			conditions = survival
+		}
+		r.Table[mask] = conditions[n]&(1<<letter) != 0
+	}
+	return r
+}
+
+// parseNeighbourhoods parses one half of a rulestring such as "2-a3ce" into the set of letters allowed for each
+// neighbour count, with a bare digit allowing all of them. It reports whether any letters were used.
+func parseNeighbourhoods(s string) (sets [9]uint16, letters bool, err error) {
+	for i := 0; i < len(s); {
+		ch := s[i]
+		if ch < '0' || ch > '8' {
+			return sets, letters, fmt.Errorf("unexpected %q", ch)
+		}
+		n := int(ch - '0')
+		i++
+		negate := i < len(s) && s[i] == '-'
+		if negate {
+			i++
+		}
+		var set uint16
+		for ; i < len(s) && s[i] >= 'a' && s[i] <= 'z'; i++ {
+			j := strings.IndexByte(henselLetters[n], s[i])
+			if j < 0 {
+				return sets, letters, fmt.Errorf("%q is not a configuration of %d neighbours", s[i], n)
+			}
+			set |= 1 << j
+		}
+		all := uint16(1)<<max(len(henselLetters[n]), 1) - 1
+		switch {
+		case set == 0 && negate:
+			return sets, letters, fmt.Errorf("%q must be followed by letters", s[:i])
+		case set == 0:
+			set = all
+		case negate:
+			set = all &^ set
+			letters = true
+		default:
+			letters = true
+		}
+		sets[n] |= set
+	}
+	return sets, letters, nil
+}
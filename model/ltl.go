@@ -0,0 +1,267 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Stepper is implemented by rules that compute a whole generation more efficiently than cell by cell.
+// Colony.Generate uses Step instead of calling Next for every cell.
+type Stepper interface {
+	Rule
+	// Step returns the state of every cell of the colony in its next generation, indexed [y][x].
+	Step(c *Colony) [][]uint8
+}
+
+// Shape is the shape of an extended range neighbourhood.
+type Shape uint8
+
+const (
+	MooreShape      Shape = iota // Square of cells within range on both axes
+	VonNeumannShape              // Diamond of cells within range in Manhattan distance
+	CircularShape                // Disc of cells whose centres are within range plus half a cell
+)
+
+func (s Shape) String() string {
+	switch s {
+	case MooreShape:
+		return "M"
+	case VonNeumannShape:
+		return "N"
+	case CircularShape:
+		return "C"
+	default:
+		return "?"
+	}
+}
+
+// maxRange is the largest neighbourhood range accepted for Larger than Life rules.
+const maxRange = 500
+
+// LargerThanLife is a rule from the Larger than Life family, where a cell's next state depends on the number of
+// live cells within range R of it, written as in "R5,C0,M1,S34..58,B34..45,NM". Like Totalistic, it supports
+// Generations style refractory states.
+type LargerThanLife struct {
+	Range                    int   // Distance to the furthest neighbour
+	Count                    uint8 // Number of states, 2 for two state rules
+	Middle                   bool  // Whether the cell itself is counted
+	SurvivalMin, SurvivalMax int   // A live cell with a count in this range survives
+	BirthMin, BirthMax       int   // A dead cell with a count in this range is born
+	Shape                    Shape // Shape of the neighbourhood
+}
+
+func (r LargerThanLife) String() string {
+	count, middle := 0, 0
+	if r.Count > 2 {
+		count = int(r.Count)
+	}
+	if r.Middle {
+		middle = 1
+	}
+	return fmt.Sprintf("R%d,C%d,M%d,S%d..%d,B%d..%d,N%s",
+		r.Range, count, middle, r.SurvivalMin, r.SurvivalMax, r.BirthMin, r.BirthMax, r.Shape)
+}
+
+func (r LargerThanLife) States() int {
+	return int(max(r.Count, 2))
+}
+
+// halfWidth returns how far the neighbourhood extends either side of the cell in the row dy away from it.
+func (r LargerThanLife) halfWidth(dy int) int {
+	dy = abs(dy)
+	switch r.Shape {
+	case VonNeumannShape:
+		return r.Range - dy
+	case CircularShape:
+		return int(math.Sqrt(float64(r.Range*r.Range + r.Range - dy*dy)))
+	default:
+		return r.Range
+	}
+}
+
+// next returns the next state of a cell from its current state and the number of live cells around it.
+func (r LargerThanLife) next(state uint8, count int) uint8 {
+	switch state {
+	case 0:
+		if count >= r.BirthMin && count <= r.BirthMax {
+			return 1
+		}
+		return 0
+	case 1:
+		if count >= r.SurvivalMin && count <= r.SurvivalMax {
+			return 1
+		}
+	}
+	return decay(state, r.States())
+}
+
+// Next counts the neighbourhood of a single cell directly. Colony.Generate uses Step instead.
+func (r LargerThanLife) Next(c *Colony, x, y int) uint8 {
+	count := 0
+	for dy := -r.Range; dy <= r.Range; dy++ {
+		w := r.halfWidth(dy)
+		for dx := -w; dx <= w; dx++ {
+			if dx != 0 || dy != 0 || r.Middle {
+				count += int(c.count(x+dx, y+dy))
+			}
+		}
+	}
+	return r.next(c.State(x, y), count)
+}
+
+func (r LargerThanLife) Step(c *Colony) [][]uint8 {
+	counts := r.counts(c)
+	grid := make([][]uint8, c.dy)
+	for y := range grid {
+		grid[y] = make([]uint8, c.dx)
+		for x := range grid[y] {
+			grid[y][x] = r.next(c.State(x, y), counts[y][x])
+		}
+	}
+	return grid
+}
+
+// counts returns the number of live cells in the neighbourhood of every cell, indexed [y][x].
+// Moore neighbourhoods are a single lookup in a summed-area table, other shapes add up one lookup per row.
+func (r LargerThanLife) counts(c *Colony) [][]int {
+	table := newSummedArea(c)
+	counts := make([][]int, c.dy)
+	for y := range counts {
+		counts[y] = make([]int, c.dx)
+		for x := range counts[y] {
+			n := 0
+			if r.Shape == MooreShape {
+				n = table.sum(x-r.Range, y-r.Range, x+r.Range, y+r.Range)
+			} else {
+				for dy := -r.Range; dy <= r.Range; dy++ {
+					w := r.halfWidth(dy)
+					n += table.sum(x-w, y+dy, x+w, y+dy)
+				}
+			}
+			if !r.Middle {
+				n -= int(c.count(x, y))
+			}
+			counts[y][x] = n
+		}
+	}
+	return counts
+}
+
+// summedArea is a summed-area table of live cells: entry [y][x] counts the live cells above and left of (x, y).
+type summedArea struct {
+	dx, dy int
+	sums   [][]int
+}
+
+func newSummedArea(c *Colony) summedArea {
+	sums := make([][]int, c.dy+1)
+	sums[0] = make([]int, c.dx+1)
+	for y := 0; y < c.dy; y++ {
+		sums[y+1] = make([]int, c.dx+1)
+		row := 0
+		for x := 0; x < c.dx; x++ {
+			row += int(c.count(x, y))
+			sums[y+1][x+1] = sums[y][x+1] + row
+		}
+	}
+	return summedArea{dx: c.dx, dy: c.dy, sums: sums}
+}
+
+// sum counts the live cells in the rectangle from (x0, y0) to (x1, y1) inclusive, clipped to the colony.
+func (t summedArea) sum(x0, y0, x1, y1 int) int {
+	x0, y0 = max(x0, 0), max(y0, 0)
+	x1, y1 = min(x1, t.dx-1), min(y1, t.dy-1)
+	if x0 > x1 || y0 > y1 {
+		return 0
+	}
+	return t.sums[y1+1][x1+1] - t.sums[y0][x1+1] - t.sums[y1+1][x0] + t.sums[y0][x0]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// parseLargerThanLife parses an uppercased rulestring such as "R5,C0,M1,S34..58,B34..45,NM".
+func parseLargerThanLife(s string) (LargerThanLife, error) {
+	r := LargerThanLife{Count: 2}
+	var seen string
+	for _, part := range strings.Split(s, ",") {
+		if part == "" {
+			return r, fmt.Errorf("empty term")
+		}
+		key, value := part[0], part[1:]
+		if strings.IndexByte(seen, key) >= 0 {
+			return r, fmt.Errorf("%c given more than once", key)
+		}
+		seen += string(key)
+		var err error
+		switch key {
+		case 'R':
+			r.Range, err = strconv.Atoi(value)
+			if err == nil && (r.Range < 1 || r.Range > maxRange) {
+				err = fmt.Errorf("range must be between 1 and %d", maxRange)
+			}
+		case 'C':
+			var n int
+			n, err = strconv.Atoi(value)
+			if err == nil && (n < 0 || n == 1 || n > 255) {
+				err = fmt.Errorf("number of states must be 0 or between 2 and 255")
+			}
+			r.Count = uint8(max(n, 2))
+		case 'M':
+			if value != "0" && value != "1" {
+				err = fmt.Errorf("middle must be 0 or 1")
+			}
+			r.Middle = value == "1"
+		case 'S':
+			r.SurvivalMin, r.SurvivalMax, err = parseRange(value)
+		case 'B':
+			r.BirthMin, r.BirthMax, err = parseRange(value)
+		case 'N':
+			switch value {
+			case "M":
+				r.Shape = MooreShape
+			case "N":
+				r.Shape = VonNeumannShape
+			case "C":
+				r.Shape = CircularShape
+			default:
+				err = fmt.Errorf("unknown neighbourhood %q", value)
+			}
+		default:
+			err = fmt.Errorf("unexpected %q", part)
+		}
+		if err != nil {
+			return r, err
+		}
+	}
+	for _, key := range "RSB" {
+		if !strings.ContainsRune(seen, key) {
+			return r, fmt.Errorf("missing %c", key)
+		}
+	}
+	return r, nil
+}
+
+// parseRange parses a range of counts such as "34..58", or a single count.
+func parseRange(s string) (lo, hi int, err error) {
+	from, to, found := strings.Cut(s, "..")
+	if !found {
+		to = from
+	}
+	if lo, err = strconv.Atoi(from); err != nil {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+	if hi, err = strconv.Atoi(to); err != nil {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+	if lo < 0 || lo > hi {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+	return lo, hi, nil
+}
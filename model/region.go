@@ -0,0 +1,89 @@
+package model
+
+// Region returns a copy of the w×h rectangle of the colony with its top left cell at (x, y), under the same rule.
+// Cells of the rectangle beyond the edges of the colony are dead.
+func (c *Colony) Region(x, y, w, h int) *Colony {
+	region := NewColony(w, h)
+	region.SetRule(c.Rule())
+	for ry := 0; ry < h; ry++ {
+		for rx := 0; rx < w; rx++ {
+			region.SetState(rx, ry, c.State(x+rx, y+ry))
+		}
+	}
+	return region
+}
+
+// SetRegion copies every cell of another colony into this one with its top left cell at (x, y). Cells that would
+// fall beyond the edges of the colony are dropped.
+func (c *Colony) SetRegion(src *Colony, x, y int) {
+	for sy := 0; sy < src.dy; sy++ {
+		for sx := 0; sx < src.dx; sx++ {
+			c.SetState(x+sx, y+sy, src.State(sx, sy))
+		}
+	}
+}
+
+// Clear kills the cells of the w×h rectangle with its top left cell at (x, y).
+func (c *Colony) Clear(x, y, w, h int) {
+	c.clear(func(cx, cy int) bool { return cx >= x && cx < x+w && cy >= y && cy < y+h })
+}
+
+// ClearOutside kills the cells outside the w×h rectangle with its top left cell at (x, y).
+func (c *Colony) ClearOutside(x, y, w, h int) {
+	c.clear(func(cx, cy int) bool { return cx < x || cx >= x+w || cy < y || cy >= y+h })
+}
+
+func (c *Colony) clear(inside func(x, y int) bool) {
+	for y := 0; y < c.dy; y++ {
+		for x := 0; x < c.dx; x++ {
+			if inside(x, y) {
+				c.SetState(x, y, 0)
+			}
+		}
+	}
+}
+
+// Rotated returns a copy of the colony turned a quarter turn clockwise, so its width and height swap.
+func (c *Colony) Rotated() *Colony {
+	return c.transformed(c.dy, c.dx, func(x, y int) (int, int) { return c.dy - 1 - y, x })
+}
+
+// Mirrored returns a copy of the colony reflected left to right.
+func (c *Colony) Mirrored() *Colony {
+	return c.transformed(c.dx, c.dy, func(x, y int) (int, int) { return c.dx - 1 - x, y })
+}
+
+// Flipped returns a copy of the colony reflected top to bottom.
+func (c *Colony) Flipped() *Colony {
+	return c.transformed(c.dx, c.dy, func(x, y int) (int, int) { return x, c.dy - 1 - y })
+}
+
+// transformed returns a w×h copy of the colony with each cell moved to where the function sends it.
+func (c *Colony) transformed(w, h int, to func(x, y int) (int, int)) *Colony {
+	t := NewColony(w, h)
+	t.SetRule(c.Rule())
+	t.generation = c.generation
+	for y := 0; y < c.dy; y++ {
+		for x := 0; x < c.dx; x++ {
+			tx, ty := to(x, y)
+			t.SetState(tx, ty, c.State(x, y))
+		}
+	}
+	return t
+}
+
+// Shift moves every cell of the colony dx columns right and dy rows down. If that would move an occupied cell off
+// the colony, nothing moves and Shift returns false.
+func (c *Colony) Shift(dx, dy int) bool {
+	for y := 0; y < c.dy; y++ {
+		for x := 0; x < c.dx; x++ {
+			if c.State(x, y) != 0 && (x+dx < 0 || y+dy < 0 || x+dx >= c.dx || y+dy >= c.dy) {
+				return false
+			}
+		}
+	}
+	before := c.Region(0, 0, c.dx, c.dy)
+	c.Clear(0, 0, c.dx, c.dy)
+	c.SetRegion(before, dx, dy)
+	return true
+}
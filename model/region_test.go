@@ -0,0 +1,154 @@
+package model
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"strings"
+	"testing"
+)
+
+type regionFeature struct {
+	colony  *Colony
+	shifted bool
+}
+
+// parseCells makes a colony from rows of cells, where . is dead, O is alive and digits are other states.
+func parseCells(rule Rule, rows string) *Colony {
+	lines := strings.Split(strings.TrimSpace(rows), "\n")
+	c := NewColony(len(lines[0]), len(lines))
+	c.SetRule(rule)
+	for y, line := range lines {
+		for x, ch := range line {
+			switch {
+			case ch == 'O':
+				c.SetState(x, y, 1)
+			case ch >= '2' && ch <= '9':
+				c.SetState(x, y, uint8(ch-'0'))
+			}
+		}
+	}
+	return c
+}
+
+// formatCells writes the cells of a colony in the form parseCells reads.
+func formatCells(c *Colony) string {
+	var b strings.Builder
+	for y := 0; y < c.Height(); y++ {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+		for x := 0; x < c.Width(); x++ {
+			switch s := c.State(x, y); s {
+			case 0:
+				b.WriteByte('.')
+			case 1:
+				b.WriteByte('O')
+			default:
+				b.WriteByte('0' + s)
+			}
+		}
+	}
+	return b.String()
+}
+
+func (f *regionFeature) theColony(rows *godog.DocString) error {
+	f.colony = parseCells(Life, rows.Content)
+	return nil
+}
+
+func (f *regionFeature) theColonyUnderTheRule(rulestring string, rows *godog.DocString) error {
+	rule, err := ParseRule(rulestring)
+	if err != nil {
+		return err
+	}
+	f.colony = parseCells(rule, rows.Content)
+	return nil
+}
+
+func (f *regionFeature) iTakeTheRegionAt(w, h, x, y int) error {
+	f.colony = f.colony.Region(x, y, w, h)
+	return nil
+}
+
+func (f *regionFeature) iTransformIt(transform string) error {
+	switch transform {
+	case "rotate":
+		f.colony = f.colony.Rotated()
+	case "mirror":
+		f.colony = f.colony.Mirrored()
+	default:
+		f.colony = f.colony.Flipped()
+	}
+	return nil
+}
+
+func (f *regionFeature) iSetTheRegionAtTo(x, y int, rows *godog.DocString) error {
+	f.colony.SetRegion(parseCells(f.colony.Rule(), rows.Content), x, y)
+	return nil
+}
+
+func (f *regionFeature) iClearTheRectangleAt(w, h, x, y int) error {
+	f.colony.Clear(x, y, w, h)
+	return nil
+}
+
+func (f *regionFeature) iClearOutsideTheRectangleAt(w, h, x, y int) error {
+	f.colony.ClearOutside(x, y, w, h)
+	return nil
+}
+
+func (f *regionFeature) iShiftItBy(dx, dy int) error {
+	f.shifted = f.colony.Shift(dx, dy)
+	return nil
+}
+
+func (f *regionFeature) itShouldHaveMoved(not string) error {
+	if f.shifted != (not == "") {
+		return fmt.Errorf("expected Shift to return %v", !f.shifted)
+	}
+	return nil
+}
+
+func (f *regionFeature) theColonyShouldBe(rows *godog.DocString) error {
+	if got, want := formatCells(f.colony), strings.TrimSpace(rows.Content); got != want {
+		return fmt.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+	return nil
+}
+
+func (f *regionFeature) theRuleShouldBe(rulestring string) error {
+	if got := f.colony.Rule().String(); got != rulestring {
+		return fmt.Errorf("expected rule %q, got %q", rulestring, got)
+	}
+	return nil
+}
+
+func InitializeRegionScenario(ctx *godog.ScenarioContext) {
+	f := &regionFeature{}
+	ctx.Step(`^the colony$`, f.theColony)
+	ctx.Step(`^the colony under the rule "([^"]*)"$`, f.theColonyUnderTheRule)
+	ctx.Step(`^I take the (\d+)x(\d+) region at \((\d+),(\d+)\)$`, f.iTakeTheRegionAt)
+	ctx.Step(`^I (rotate|mirror|flip) it$`, f.iTransformIt)
+	ctx.Step(`^I set the region at \((\d+),(\d+)\) to$`, f.iSetTheRegionAtTo)
+	ctx.Step(`^I clear the (\d+)x(\d+) rectangle at \((\d+),(\d+)\)$`, f.iClearTheRectangleAt)
+	ctx.Step(`^I clear outside the (\d+)x(\d+) rectangle at \((\d+),(\d+)\)$`, f.iClearOutsideTheRectangleAt)
+	ctx.Step(`^I shift it by \((-?\d+),(-?\d+)\)$`, f.iShiftItBy)
+	ctx.Step(`^it should (not )?have moved$`, f.itShouldHaveMoved)
+	ctx.Step(`^the colony should be$`, f.theColonyShouldBe)
+	ctx.Step(`^the rule should be "([^"]*)"$`, f.theRuleShouldBe)
+}
+
+func TestRegionFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "region",
+		ScenarioInitializer: InitializeRegionScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/region.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
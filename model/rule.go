@@ -0,0 +1,285 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Rule is the transition function of a cellular automaton run on a colony.
+type Rule interface {
+	// String returns the rulestring in canonical form.
+	String() string
+	// States returns the number of cell states, 2 for rules where cells are only alive or dead.
+	States() int
+	// Next returns the state of the cell at (x, y) in the next generation of the colony.
+	Next(c *Colony, x, y int) uint8
+}
+
+// Neighbourhood selects which of the eight surrounding cells are a cell's neighbours.
+type Neighbourhood uint8
+
+const (
+	Moore      Neighbourhood = iota // All eight surrounding cells
+	Hexagonal                       // Six cells, leaving out the top right and bottom left corners
+	VonNeumann                      // The four orthogonally adjacent cells
+)
+
+// Suffix returns the letter appended to rulestrings using the neighbourhood, as in Golly.
+func (n Neighbourhood) Suffix() string {
+	switch n {
+	case Hexagonal:
+		return "H"
+	case VonNeumann:
+		return "V"
+	default:
+		return ""
+	}
+}
+
+// Size returns the number of neighbours in the neighbourhood.
+func (n Neighbourhood) Size() int {
+	return bits.OnesCount16(n.mask())
+}
+
+// mask returns the neighbours in the bit order of Colony.neighbourhood.
+// On a hexagonal grid drawn with each row offset half a cell left of the one above, the neighbours are the
+// cells left and right, the two above at top left and top, and the two below at bottom and bottom right.
+func (n Neighbourhood) mask() uint16 {
+	switch n {
+	case Hexagonal:
+		return neighbourMask &^ (1<<2 | 1<<6)
+	case VonNeumann:
+		return 1<<1 | 1<<3 | 1<<5 | 1<<7
+	default:
+		return neighbourMask
+	}
+}
+
+// NeighbourhoodOf returns the neighbourhood a rule runs on, Moore for rules that don't choose one.
+func NeighbourhoodOf(r Rule) Neighbourhood {
+	if n, ok := r.(interface{ Neighbourhood() Neighbourhood }); ok {
+		return n.Neighbourhood()
+	}
+	return Moore
+}
+
+// LookupTable returns whether a cell is alive in the next generation for each of the 512 configurations of the
+// 3×3 block centred on it, indexed by a mask with one bit per cell row by row from the top left, so bit 4 is the
+// cell itself. Only two-state rules that look no further than the adjacent cells have one.
+func LookupTable(r Rule) (*[512]bool, bool) {
+	if r.States() != 2 {
+		return nil, false
+	}
+	switch r := r.(type) {
+	case Totalistic, *Isotropic, *RuleTable:
+	case LargerThanLife:
+		if r.Range != 1 {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	var table [512]bool
+	c := NewColony(3, 3)
+	c.SetRule(r)
+	for mask := range table {
+		for i := 0; i < 9; i++ {
+			c.SetState(i%3, i/3, uint8(mask>>i&1))
+		}
+		table[mask] = r.Next(c, 1, 1) == 1
+	}
+	return &table, true
+}
+
+// Totalistic is an outer totalistic rule, where a cell's next state depends on its own state and the number of
+// its neighbours that are alive. With more than two states it is a rule of the Generations family: live cells
+// that don't survive pass through refractory states 2 to States-1 before dying, and only cells in state 1 count
+// as live neighbours.
+type Totalistic struct {
+	Birth      uint16        // Bit n is set when a dead cell with n live neighbours is born
+	Survival   uint16        // Bit n is set when a live cell with n live neighbours survives
+	Count      uint8         // Number of states, 2 for Life-like rules
+	Neighbours Neighbourhood // Which surrounding cells are counted
+}
+
+// Life is Conway's Game of Life, B3/S23.
+var Life = Totalistic{Birth: 1 << 3, Survival: 1<<2 | 1<<3, Count: 2}
+
+func (r Totalistic) String() string {
+	s := "B" + digits(r.Birth) + "/S" + digits(r.Survival)
+	if r.Count > 2 {
+		s += fmt.Sprintf("/C%d", r.Count)
+	}
+	return s + r.Neighbours.Suffix()
+}
+
+func (r Totalistic) Neighbourhood() Neighbourhood {
+	return r.Neighbours
+}
+
+func (r Totalistic) States() int {
+	return int(max(r.Count, 2))
+}
+
+func (r Totalistic) Next(c *Colony, x, y int) uint8 {
+	state := c.State(x, y)
+	switch state {
+	case 0:
+		if r.Birth&(1<<r.count(c, x, y)) != 0 {
+			return 1
+		}
+		return 0
+	case 1:
+		if r.Survival&(1<<r.count(c, x, y)) != 0 {
+			return 1
+		}
+	}
+	return decay(state, r.States())
+}
+
+// count returns the number of live neighbours of the cell at (x, y).
+func (r Totalistic) count(c *Colony, x, y int) int {
+	if r.Neighbours == Moore {
+		return int(c.countNeighbours(x, y))
+	}
+	return bits.OnesCount16(c.neighbourhood(x, y) & r.Neighbours.mask())
+}
+
+// decay returns the state following a refractory or dying cell's state, wrapping round to dead after the last.
+func decay(state uint8, states int) uint8 {
+	if int(state)+1 >= states {
+		return 0
+	}
+	return state + 1
+}
+
+// digits lists the neighbour counts set in a mask, in ascending order.
+func digits(mask uint16) string {
+	var b strings.Builder
+	for n := 0; n <= 8; n++ {
+		if mask&(1<<n) != 0 {
+			b.WriteByte(byte('0' + n))
+		}
+	}
+	return b.String()
+}
+
+var InvalidRule = errors.New("invalid rule")
+
+// ParseRule parses a rulestring. Life-like rules may be written as "B3/S23", "b3s23" or "23/3" (survival first).
+// Generations rules add the number of states, as in "B2/S/C3", "B2/S/3" or "345/2/4". Neighbour counts followed
+// by Hensel notation letters, as in "B2-a/S12", give an isotropic non-totalistic rule, and Larger than Life rules
+// are written as in "R5,C0,M1,S34..58,B34..45,NM". Margolus block rules are written in MCell notation, as in
+// "MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15". A final "H" or "V" runs a totalistic rule on the hexagonal
+// or von Neumann neighbourhood, as in "B2/S34H". The names of bundled rule tables such as "WireWorld" and the
+// contents of Golly .rule files are accepted too.
+func ParseRule(s string) (Rule, error) {
+	if strings.HasPrefix(strings.TrimSpace(s), "@RULE") {
+		return ParseRuleTable(s)
+	}
+	if r, ok := bundledRule(strings.TrimSpace(s)); ok {
+		return r, nil
+	}
+	str := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if str == "" {
+		return nil, fmt.Errorf("%w: empty rulestring", InvalidRule)
+	}
+	if strings.HasPrefix(str, "MS,D") {
+		r, err := parseMargolus(str)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", InvalidRule, s, err)
+		}
+		return r, nil
+	}
+	if strings.HasPrefix(str, "R") && strings.Contains(str, ",") {
+		r, err := parseLargerThanLife(str)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", InvalidRule, s, err)
+		}
+		return r, nil
+	}
+	neighbours := Moore
+	switch {
+	case strings.HasSuffix(str, "H"):
+		neighbours, str = Hexagonal, strings.TrimSuffix(str, "H")
+	case strings.HasSuffix(str, "V"):
+		neighbours, str = VonNeumann, strings.TrimSuffix(str, "V")
+	}
+	var birth, survival, count string
+	parts := strings.Split(str, "/")
+	switch {
+	case strings.HasPrefix(str, "B") || strings.HasPrefix(str, "S"):
+		// B/S notation, where the slash between the two halves is optional.
+		if len(parts) == 1 {
+			i := strings.IndexAny(str[1:], "BS")
+			if i < 0 {
+				return nil, fmt.Errorf("%w: %q", InvalidRule, s)
+			}
+			parts = []string{str[:i+1], str[i+1:]}
+		}
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("%w: %q", InvalidRule, s)
+		}
+		for _, part := range parts[:2] {
+			switch {
+			case strings.HasPrefix(part, "B"):
+				birth = part[1:]
+			case strings.HasPrefix(part, "S"):
+				survival = part[1:]
+			default:
+				return nil, fmt.Errorf("%w: %q", InvalidRule, s)
+			}
+		}
+		if len(parts) == 3 {
+			count = strings.TrimPrefix(parts[2], "C")
+		}
+	default:
+		// S/B notation, with the number of states as an optional third part.
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("%w: %q", InvalidRule, s)
+		}
+		survival, birth = parts[0], parts[1]
+		if len(parts) == 3 {
+			count = parts[2]
+		}
+	}
+
+	births, birthLetters, err := parseNeighbourhoods(strings.ToLower(birth))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", InvalidRule, s, err)
+	}
+	survivals, survivalLetters, err := parseNeighbourhoods(strings.ToLower(survival))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", InvalidRule, s, err)
+	}
+	states := uint8(2)
+	if count != "" {
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 2 || n > 255 {
+			return nil, fmt.Errorf("%w: %q: number of states must be between 2 and 255", InvalidRule, s)
+		}
+		states = uint8(n)
+	}
+	if birthLetters || survivalLetters {
+		if neighbours != Moore {
+			return nil, fmt.Errorf("%w: %q: Hensel notation needs the Moore neighbourhood", InvalidRule, s)
+		}
+		return newIsotropic(births, survivals, states), nil
+	}
+	r := Totalistic{Count: states, Neighbours: neighbours}
+	for n := range births {
+		if n > neighbours.Size() && (births[n] != 0 || survivals[n] != 0) {
+			return nil, fmt.Errorf("%w: %q: the neighbourhood has only %d cells", InvalidRule, s, neighbours.Size())
+		}
+		if births[n] != 0 {
+			r.Birth |= 1 << n
+		}
+		if survivals[n] != 0 {
+			r.Survival |= 1 << n
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,312 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"math/bits"
+	"strings"
+	"testing"
+)
+
+// directions maps compass points to their bit in a neighbourhood mask.
+var directions = map[string]uint16{
+	"NW": 1 << 0, "N": 1 << 1, "NE": 1 << 2,
+	"W": 1 << 3, "E": 1 << 5,
+	"SW": 1 << 6, "S": 1 << 7, "SE": 1 << 8,
+}
+
+type ruleFeature struct {
+	rule    Rule
+	err     error
+	colony  *Colony
+	source  string
+	history [][][]uint8
+}
+
+func (f *ruleFeature) iParseTheRule(s string) error {
+	f.rule, f.err = ParseRule(s)
+	return nil
+}
+
+func (f *ruleFeature) theRuleShouldBe(canonical string, states int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.rule.String() != canonical {
+		return fmt.Errorf("expected rule %q, got %q", canonical, f.rule.String())
+	}
+	if f.rule.States() != states {
+		return fmt.Errorf("expected %d states, got %d", states, f.rule.States())
+	}
+	return nil
+}
+
+func (f *ruleFeature) theRuleShouldBeInvalid() error {
+	if !errors.Is(f.err, InvalidRule) {
+		return fmt.Errorf("expected an invalid rule error, got rule %v and error %v", f.rule, f.err)
+	}
+	return nil
+}
+
+func (f *ruleFeature) aColonyUnderTheRule(w, h int, s string) error {
+	rule, err := ParseRule(s)
+	if err != nil {
+		return err
+	}
+	f.colony = NewColony(w, h)
+	f.colony.SetRule(rule)
+	return nil
+}
+
+func (f *ruleFeature) theCellAtIsInState(x, y, state int) error {
+	f.colony.SetState(x, y, uint8(state))
+	return nil
+}
+
+func (f *ruleFeature) nextGenerationIsComputed() error {
+	f.colony.Generate()
+	return nil
+}
+
+func (f *ruleFeature) iToggleTheCellAt(x, y int) error {
+	f.colony.Toggle(x, y)
+	return nil
+}
+
+func (f *ruleFeature) theRuleIsChangedTo(s string) error {
+	rule, err := ParseRule(s)
+	if err != nil {
+		return err
+	}
+	f.colony.SetRule(rule)
+	return nil
+}
+
+func (f *ruleFeature) theCellAtShouldBeInState(x, y, state int) error {
+	if got := f.colony.State(x, y); int(got) != state {
+		return fmt.Errorf("expected cell (%d,%d) to be in state %d, but it was in state %d", x, y, state, got)
+	}
+	return nil
+}
+
+func (f *ruleFeature) theConfigurationShouldBe(neighbours, name string) error {
+	var mask uint16
+	for _, d := range strings.Split(neighbours, ",") {
+		bit, ok := directions[d]
+		if !ok {
+			return fmt.Errorf("unknown direction %q", d)
+		}
+		mask |= bit
+	}
+	n := bits.OnesCount16(mask)
+	got := fmt.Sprintf("%d%c", n, henselLetters[n][henselClass[mask]])
+	if got != name {
+		return fmt.Errorf("expected %s to be %s, got %s", neighbours, name, got)
+	}
+	return nil
+}
+
+func (f *ruleFeature) theLettersShouldPartitionConfigurations() error {
+	for n := 1; n <= 7; n++ {
+		seen := map[uint16]byte{}
+		for i := range henselLetters[n] {
+			for _, mask := range symmetries(henselRepresentative(n, i)) {
+				if other, ok := seen[mask]; ok && other != henselLetters[n][i] {
+					return fmt.Errorf("configuration %03x is both %d%c and %d%c", mask, n, other, n, henselLetters[n][i])
+				}
+				seen[mask] = henselLetters[n][i]
+			}
+		}
+		total := 0
+		for mask := uint16(0); mask < 512; mask++ {
+			if mask&centreBit == 0 && bits.OnesCount16(mask) == n {
+				total++
+			}
+		}
+		if len(seen) != total {
+			return fmt.Errorf("letters of %d neighbours cover %d of %d configurations", n, len(seen), total)
+		}
+	}
+	return nil
+}
+
+func (f *ruleFeature) theRuleShouldRunLike(s string, w, h int, reference string, generations int) error {
+	rule, err := ParseRule(s)
+	if err != nil {
+		return err
+	}
+	other, err := ParseRule(reference)
+	if err != nil {
+		return err
+	}
+	a := NewColony(w, h)
+	a.Randomize(DefaultRandomOptions())
+	b := a.Clone()
+	a.SetRule(rule)
+	b.SetRule(other)
+	for g := 0; g < generations; g++ {
+		a.Generate()
+		b.Generate()
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if a.State(x, y) != b.State(x, y) {
+					return fmt.Errorf("runs differ at (%d,%d) in generation %d", x, y, g+1)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (f *ruleFeature) theRuleShouldCountAroundTheCentre(s string, count, w, h int) error {
+	rule, err := ParseRule(s)
+	if err != nil {
+		return err
+	}
+	c := NewColony(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c.SetState(x, y, 1)
+		}
+	}
+	if got := rule.(LargerThanLife).counts(c)[h/2][w/2]; got != count {
+		return fmt.Errorf("expected %d live cells around the centre, got %d", count, got)
+	}
+	return nil
+}
+
+func (f *ruleFeature) theRuleShouldStepLikeCountingCellByCell(s string, w, h int) error {
+	rule, err := ParseRule(s)
+	if err != nil {
+		return err
+	}
+	c := NewColony(w, h)
+	c.Randomize(DefaultRandomOptions())
+	c.SetRule(rule)
+	for g := 0; g < 5; g++ {
+		step := rule.(Stepper).Step(c)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if want := rule.Next(c, x, y); step[y][x] != want {
+					return fmt.Errorf("cell (%d,%d) in generation %d: expected state %d, got %d", x, y, g, want, step[y][x])
+				}
+			}
+		}
+		c.Generate()
+	}
+	return nil
+}
+
+func (f *ruleFeature) theRuleShouldBeReversible(be string) error {
+	r, ok := f.rule.(Reverser)
+	if reversible := ok && r.Reversible(); reversible != (be == "be") {
+		return fmt.Errorf("expected %v to %s reversible", f.rule, be)
+	}
+	return nil
+}
+
+func (f *ruleFeature) aRandomColonyUnderTheRule(w, h int, s string) error {
+	if err := f.aColonyUnderTheRule(w, h, s); err != nil {
+		return err
+	}
+	f.colony.Randomize(DefaultRandomOptions())
+	return nil
+}
+
+func (f *ruleFeature) theLookupTableShouldStepTheColonyLikeTheRule() error {
+	table, ok := LookupTable(f.colony.Rule())
+	if !ok {
+		return fmt.Errorf("rule %s has no lookup table", f.colony.Rule())
+	}
+	next := f.colony.Clone()
+	next.Generate()
+	for y := 0; y < f.colony.Height(); y++ {
+		for x := 0; x < f.colony.Width(); x++ {
+			if table[f.colony.neighbourhood(x, y)] != next.IsAlive(x, y) {
+				return fmt.Errorf("lookup table disagrees with the rule at (%d,%d)", x, y)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *ruleFeature) theRuleShouldHaveNoLookupTable() error {
+	if f.err != nil {
+		return f.err
+	}
+	if _, ok := LookupTable(f.rule); ok {
+		return fmt.Errorf("rule %s has a lookup table", f.rule)
+	}
+	return nil
+}
+
+func (f *ruleFeature) runningGenerationsForwardsAndBackShouldRestoreTheColony(n int) error {
+	start := f.colony.StateGrid()
+	for i := 0; i < n; i++ {
+		f.colony.Generate()
+	}
+	for i := 0; i < n; i++ {
+		if err := f.colony.GenerateBackwards(); err != nil {
+			return err
+		}
+	}
+	if f.colony.GetGeneration() != 0 {
+		return fmt.Errorf("expected generation 0, got %d", f.colony.GetGeneration())
+	}
+	for y, row := range f.colony.StateGrid() {
+		for x, state := range row {
+			if state != start[y][x] {
+				return fmt.Errorf("cell (%d,%d) is in state %d, expected %d", x, y, state, start[y][x])
+			}
+		}
+	}
+	return nil
+}
+
+func (f *ruleFeature) steppingBackShouldFail() error {
+	if err := f.colony.GenerateBackwards(); !errors.Is(err, Irreversible) {
+		return fmt.Errorf("expected an irreversible rule error, got %v", err)
+	}
+	return nil
+}
+
+func InitializeRuleScenario(ctx *godog.ScenarioContext) {
+	f := &ruleFeature{}
+	ctx.Step(`^I parse the rule "([^"]*)"$`, f.iParseTheRule)
+	ctx.Step(`^the rule should be "([^"]*)" with (\d+) states$`, f.theRuleShouldBe)
+	ctx.Step(`^the rule should be invalid$`, f.theRuleShouldBeInvalid)
+	ctx.Step(`^a (\d+)x(\d+) colony under the rule "([^"]*)"$`, f.aColonyUnderTheRule)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) is in state (\d+)$`, f.theCellAtIsInState)
+	ctx.Step(`^the next generation is computed$`, f.nextGenerationIsComputed)
+	ctx.Step(`^I toggle the cell at \((\d+),(\d+)\)$`, f.iToggleTheCellAt)
+	ctx.Step(`^the rule is changed to "([^"]*)"$`, f.theRuleIsChangedTo)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) should be in state (\d+)$`, f.theCellAtShouldBeInState)
+	ctx.Step(`^the configuration "([^"]*)" should be "([^"]*)"$`, f.theConfigurationShouldBe)
+	ctx.Step(`^the letters of every neighbour count should partition its configurations$`, f.theLettersShouldPartitionConfigurations)
+	ctx.Step(`^the rule "([^"]*)" should count (\d+) live cells around the centre of a full (\d+)x(\d+) colony$`, f.theRuleShouldCountAroundTheCentre)
+	ctx.Step(`^the rule "([^"]*)" should step a random (\d+)x(\d+) colony like counting cell by cell$`, f.theRuleShouldStepLikeCountingCellByCell)
+	f.ruleTableSteps(ctx)
+	ctx.Step(`^the rule should (be|not be) reversible$`, f.theRuleShouldBeReversible)
+	ctx.Step(`^a random (\d+)x(\d+) colony under the rule "([^"]*)"$`, f.aRandomColonyUnderTheRule)
+	ctx.Step(`^running (\d+) generations forwards and back should restore the colony$`, f.runningGenerationsForwardsAndBackShouldRestoreTheColony)
+	ctx.Step(`^stepping back should fail$`, f.steppingBackShouldFail)
+	ctx.Step(`^the lookup table of the rule should step the colony like the rule$`, f.theLookupTableShouldStepTheColonyLikeTheRule)
+	ctx.Step(`^the rule should have no lookup table$`, f.theRuleShouldHaveNoLookupTable)
+	ctx.Step(`^the rule "([^"]*)" should run a random (\d+)x(\d+) colony like "([^"]*)" for (\d+) generations$`, f.theRuleShouldRunLike)
+}
+
+func TestRuleFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "rule",
+		ScenarioInitializer: InitializeRuleScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/rule.feature", "features/ruletable.feature", "features/margolus.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
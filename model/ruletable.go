@@ -0,0 +1,524 @@
+package model
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RuleTable is a multi-state rule loaded from a Golly .rule file, such as WireWorld or Langton's loops.
+// The @TABLE section lists transitions from a cell and its neighbours to the cell's next state, using variables
+// for sets of states and symmetries to stand for rotated or reflected copies. A cell no transition matches keeps
+// its state. Colours for the states are taken from the optional @COLORS section.
+type RuleTable struct {
+	Name   string // Name of the rule from its @RULE line
+	Source string // Contents of the .rule file
+
+	count       int
+	neighbours  Neighbourhood
+	transitions []transition
+	colours     map[uint8]color.RGBA
+
+	mu    sync.Mutex
+	cache map[[9]uint8]uint8 // Next state of each neighbourhood seen so far
+}
+
+// transition is one transition of a table after expanding its symmetries. Entry 0 is the cell itself, followed
+// by its neighbours in Golly's order.
+type transition struct {
+	inputs []stateSet
+	binds  []int // Index of the variable binding each input, -1 for inputs that are free
+	output uint8
+	bound  int // Variable binding the output, -1 for a fixed output
+}
+
+// stateSet is a set of cell states, one bit per state.
+type stateSet [4]uint64
+
+func (s *stateSet) add(state int) {
+	s[state/64] |= 1 << (state % 64)
+}
+
+func (s stateSet) has(state uint8) bool {
+	return s[state/64]&(1<<(state%64)) != 0
+}
+
+// neighbourOffsets lists the neighbours of each neighbourhood in the order used by Golly rule tables:
+// clockwise from north.
+var neighbourOffsets = map[Neighbourhood][][2]int{
+	Moore:      {{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}},
+	VonNeumann: {{0, -1}, {1, 0}, {0, 1}, {-1, 0}},
+	Hexagonal:  {{0, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 0}, {-1, -1}},
+}
+
+func (r *RuleTable) String() string {
+	return r.Name
+}
+
+func (r *RuleTable) States() int {
+	return r.count
+}
+
+func (r *RuleTable) Neighbourhood() Neighbourhood {
+	return r.neighbours
+}
+
+// Colour returns the colour given to a state by the @COLORS section, if any.
+func (r *RuleTable) Colour(state uint8) (color.RGBA, bool) {
+	c, ok := r.colours[state]
+	return c, ok
+}
+
+func (r *RuleTable) Next(c *Colony, x, y int) uint8 {
+	var key [9]uint8
+	key[0] = c.State(x, y)
+	for i, offset := range neighbourOffsets[r.neighbours] {
+		key[i+1] = c.State(x+offset[0], y+offset[1])
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if next, ok := r.cache[key]; ok {
+		return next
+	}
+	next := r.lookup(key[:len(neighbourOffsets[r.neighbours])+1])
+	r.cache[key] = next
+	return next
+}
+
+// lookup returns the output of the first transition matching the states, or the cell's own state.
+func (r *RuleTable) lookup(states []uint8) uint8 {
+	values := make([]uint8, len(states))
+	for _, t := range r.transitions {
+		if next, ok := t.match(states, values); ok {
+			return next
+		}
+	}
+	return states[0]
+}
+
+// match reports whether the transition applies to the states and returns its output.
+// Inputs bound to the same variable must all have the same state.
+func (t transition) match(states, values []uint8) (uint8, bool) {
+	bound := make([]bool, len(states))
+	for i, set := range t.inputs {
+		state := states[i]
+		if !set.has(state) {
+			return 0, false
+		}
+		if v := t.binds[i]; v >= 0 {
+			if bound[v] && values[v] != state {
+				return 0, false
+			}
+			bound[v], values[v] = true, state
+		}
+	}
+	if t.bound >= 0 {
+		return values[t.bound], true
+	}
+	return t.output, true
+}
+
+var InvalidRuleTable = errors.New("invalid rule table")
+
+// maxTableStates is the largest number of states a rule table may have, so every state fits an image palette.
+const maxTableStates = 255
+
+// ParseRuleTable parses the contents of a Golly .rule file. Only the @RULE, @TABLE and @COLORS sections are used.
+func ParseRuleTable(source string) (*RuleTable, error) {
+	r := &RuleTable{Source: source, count: 2, neighbours: Moore, colours: map[uint8]color.RGBA{}}
+	fail := func(line int, format string, args ...any) error {
+		return fmt.Errorf("%w: line %d: %s", InvalidRuleTable, line, fmt.Sprintf(format, args...))
+	}
+	var (
+		section    string
+		table      bool
+		symmetries = "none"
+		variables  = map[string]stateSet{}
+		pending    []tableLine
+		colours    []tableLine
+	)
+	scanner := bufio.NewScanner(strings.NewReader(source))
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "@") {
+			fields := strings.Fields(line)
+			section = fields[0]
+			switch section {
+			case "@RULE":
+				if len(fields) < 2 {
+					return nil, fail(n, "@RULE needs a name")
+				}
+				r.Name = fields[1]
+			case "@TABLE":
+				table = true
+			case "@TREE":
+				return nil, fail(n, "@TREE sections are not supported")
+			}
+			continue
+		}
+		switch section {
+		case "@TABLE":
+			key, value, found := strings.Cut(line, ":")
+			switch {
+			case found && strings.TrimSpace(key) == "n_states":
+				count, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil || count < 2 || count > maxTableStates {
+					return nil, fail(n, "n_states must be between 2 and %d", maxTableStates)
+				}
+				r.count = count
+			case found && strings.TrimSpace(key) == "neighborhood":
+				switch strings.TrimSpace(value) {
+				case "Moore":
+					r.neighbours = Moore
+				case "vonNeumann":
+					r.neighbours = VonNeumann
+				case "hexagonal":
+					r.neighbours = Hexagonal
+				default:
+					return nil, fail(n, "unsupported neighborhood %q", strings.TrimSpace(value))
+				}
+			case found && strings.TrimSpace(key) == "symmetries":
+				symmetries = strings.TrimSpace(value)
+			case strings.HasPrefix(line, "var "):
+				name, set, err := r.parseVariable(strings.TrimSpace(line[4:]), variables)
+				if err != nil {
+					return nil, fail(n, "%v", err)
+				}
+				variables[name] = set
+			default:
+				pending = append(pending, tableLine{n, line})
+			}
+		case "@COLORS":
+			colours = append(colours, tableLine{n, line})
+		}
+	}
+	if r.Name == "" {
+		return nil, fmt.Errorf("%w: missing @RULE", InvalidRuleTable)
+	}
+	if !table {
+		return nil, fmt.Errorf("%w: missing @TABLE", InvalidRuleTable)
+	}
+	group, err := symmetryGroup(symmetries, len(neighbourOffsets[r.neighbours]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidRuleTable, err)
+	}
+	for _, l := range pending {
+		t, err := r.parseTransition(l.text, variables)
+		if err != nil {
+			return nil, fail(l.number, "%v", err)
+		}
+		r.transitions = append(r.transitions, t.symmetric(group, symmetries == "permute")...)
+	}
+	for _, l := range colours {
+		if err := r.parseColour(l.text); err != nil {
+			return nil, fail(l.number, "%v", err)
+		}
+	}
+	r.cache = make(map[[9]uint8]uint8)
+	return r, nil
+}
+
+// tableLine is a line of a rule file kept for parsing once the whole table header has been read.
+type tableLine struct {
+	number int
+	text   string
+}
+
+// parseState parses a state number, checking it is one of the rule's states.
+func (r *RuleTable) parseState(s string) (int, error) {
+	state, err := strconv.Atoi(s)
+	if err != nil || state < 0 || state >= r.count {
+		return 0, fmt.Errorf("invalid state %q", s)
+	}
+	return state, nil
+}
+
+// parseVariable parses a declaration such as "a={0,1,2}" or "b=a". Sets may include earlier variables.
+func (r *RuleTable) parseVariable(s string, variables map[string]stateSet) (string, stateSet, error) {
+	var set stateSet
+	name, value, found := strings.Cut(s, "=")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if !found || name == "" {
+		return "", set, fmt.Errorf("invalid variable %q", s)
+	}
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if other, ok := variables[item]; ok {
+			for i := range set {
+				set[i] |= other[i]
+			}
+			continue
+		}
+		state, err := r.parseState(item)
+		if err != nil {
+			return "", set, err
+		}
+		set.add(state)
+	}
+	return name, set, nil
+}
+
+// parseTransition parses a transition such as "3,1,i,j,k,l,m,n,o,1". Transitions of single digit states may
+// leave out the commas, as in "0123456789". Variables used more than once are bound to the same state.
+func (r *RuleTable) parseTransition(s string, variables map[string]stateSet) (transition, error) {
+	var tokens []string
+	if strings.Contains(s, ",") {
+		for _, token := range strings.Split(s, ",") {
+			tokens = append(tokens, strings.TrimSpace(token))
+		}
+	} else {
+		for _, ch := range strings.ReplaceAll(s, " ", "") {
+			tokens = append(tokens, string(ch))
+		}
+	}
+	want := len(neighbourOffsets[r.neighbours]) + 2
+	if len(tokens) != want {
+		return transition{}, fmt.Errorf("expected %d states in transition %q, got %d", want, s, len(tokens))
+	}
+	uses := map[string]int{}
+	for _, token := range tokens {
+		uses[token]++
+	}
+	var names []string
+	t := transition{bound: -1}
+	for _, token := range tokens[:want-1] {
+		var set stateSet
+		bind := -1
+		if v, ok := variables[token]; ok {
+			set = v
+			if uses[token] > 1 {
+				if bind = slices.Index(names, token); bind < 0 {
+					bind = len(names)
+					names = append(names, token)
+				}
+			}
+		} else {
+			state, err := r.parseState(token)
+			if err != nil {
+				return t, err
+			}
+			set.add(state)
+		}
+		t.inputs = append(t.inputs, set)
+		t.binds = append(t.binds, bind)
+	}
+	output := tokens[want-1]
+	if _, ok := variables[output]; ok {
+		if t.bound = slices.Index(names, output); t.bound < 0 {
+			return t, fmt.Errorf("output variable %q is not used in the inputs", output)
+		}
+		return t, nil
+	}
+	state, err := r.parseState(output)
+	if err != nil {
+		return t, err
+	}
+	t.output = uint8(state)
+	return t, nil
+}
+
+// parseColour parses a line of the @COLORS section: "state r g b" for one state, or "r1 g1 b1 r2 g2 b2" for a
+// gradient over the live states.
+func (r *RuleTable) parseColour(s string) error {
+	var values []int
+	for _, field := range strings.Fields(s) {
+		v, err := strconv.Atoi(field)
+		if err != nil || v < 0 || v > 255 {
+			return fmt.Errorf("invalid colour %q", s)
+		}
+		values = append(values, v)
+	}
+	switch len(values) {
+	case 4:
+		if values[0] >= r.count {
+			return fmt.Errorf("invalid state %d", values[0])
+		}
+		r.colours[uint8(values[0])] = color.RGBA{R: uint8(values[1]), G: uint8(values[2]), B: uint8(values[3]), A: 0xff}
+	case 6:
+		for state := 1; state < r.count; state++ {
+			t := 0.0
+			if r.count > 2 {
+				t = float64(state-1) / float64(r.count-2)
+			}
+			mix := func(a, b int) uint8 {
+				return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+			}
+			r.colours[uint8(state)] = color.RGBA{
+				R: mix(values[0], values[3]), G: mix(values[1], values[4]), B: mix(values[2], values[5]), A: 0xff,
+			}
+		}
+	default:
+		return fmt.Errorf("invalid colour %q", s)
+	}
+	return nil
+}
+
+// symmetryGroup returns the permutations of n neighbours, listed clockwise, named by a Golly symmetries line:
+// none, permute, reflect_horizontal, rotateK or rotateKreflect. Permute is expanded separately.
+func symmetryGroup(name string, n int) ([][]int, error) {
+	rotations, reflect := 1, false
+	switch {
+	case name == "none", name == "permute":
+	case name == "reflect_horizontal":
+		reflect = true
+	case strings.HasPrefix(name, "rotate"):
+		k := strings.TrimPrefix(name, "rotate")
+		k, reflect = strings.CutSuffix(k, "reflect")
+		var err error
+		rotations, err = strconv.Atoi(k)
+		if err != nil || rotations < 1 || rotations > n || n%rotations != 0 {
+			return nil, fmt.Errorf("unsupported symmetries %q", name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported symmetries %q", name)
+	}
+	var group [][]int
+	for r := 0; r < rotations; r++ {
+		shift := r * n / rotations
+		for _, mirror := range []bool{false, true} {
+			if mirror && !reflect {
+				continue
+			}
+			p := make([]int, n)
+			for i := range p {
+				j := i
+				if mirror {
+					j = (n - i) % n
+				}
+				p[i] = (j + shift) % n
+			}
+			group = append(group, p)
+		}
+	}
+	return group, nil
+}
+
+// symmetric returns the distinct copies of the transition under the permutations of its neighbours, or under
+// every rearrangement of them when permute is set.
+func (t transition) symmetric(group [][]int, permute bool) []transition {
+	// Inputs are told apart by their set and binding, so identical free inputs are rearranged only once.
+	n := len(t.inputs) - 1
+	ids := make([]int, n)
+	var kinds []string
+	for i := range ids {
+		kind := fmt.Sprint(t.inputs[i+1], t.binds[i+1])
+		if ids[i] = slices.Index(kinds, kind); ids[i] < 0 {
+			ids[i] = len(kinds)
+			kinds = append(kinds, kind)
+		}
+	}
+	var perms [][]int
+	if permute {
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortFunc(order, func(a, b int) int { return ids[a] - ids[b] })
+		for {
+			perms = append(perms, slices.Clone(order))
+			if !nextPermutation(order, ids) {
+				break
+			}
+		}
+	} else {
+		perms = group
+	}
+	seen := map[string]bool{}
+	var variants []transition
+	for _, p := range perms {
+		key := make([]byte, n)
+		for i, j := range p {
+			key[i] = byte(ids[j])
+		}
+		if seen[string(key)] {
+			continue
+		}
+		seen[string(key)] = true
+		v := transition{
+			inputs: []stateSet{t.inputs[0]},
+			binds:  []int{t.binds[0]},
+			output: t.output,
+			bound:  t.bound,
+		}
+		for _, j := range p {
+			v.inputs = append(v.inputs, t.inputs[j+1])
+			v.binds = append(v.binds, t.binds[j+1])
+		}
+		variants = append(variants, v)
+	}
+	return variants
+}
+
+// nextPermutation rearranges order into the next permutation in lexicographic order of the ids of its elements,
+// skipping rearrangements of equal ids, and reports whether there was one.
+func nextPermutation(order, ids []int) bool {
+	i := len(order) - 2
+	for i >= 0 && ids[order[i]] >= ids[order[i+1]] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	j := len(order) - 1
+	for ids[order[j]] <= ids[order[i]] {
+		j--
+	}
+	order[i], order[j] = order[j], order[i]
+	slices.Reverse(order[i+1:])
+	return true
+}
+
+//go:embed rules/*.rule
+var bundledFiles embed.FS
+
+// bundled holds the rule tables shipped with the program, by lower case name.
+var bundled = sync.OnceValue(func() map[string]string {
+	rules := map[string]string{}
+	files, _ := fs.Glob(bundledFiles, "rules/*.rule")
+	for _, file := range files {
+		data, err := bundledFiles.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(file, "rules/"), ".rule")
+		rules[strings.ToLower(name)] = string(data)
+	}
+	return rules
+})
+
+// BundledRules returns the names of the rule tables shipped with the program, which ParseRule accepts by name.
+func BundledRules() []string {
+	var names []string
+	for _, source := range bundled() {
+		if r, err := ParseRuleTable(source); err == nil {
+			names = append(names, r.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// bundledRule returns the bundled rule table with the given name, if there is one.
+func bundledRule(name string) (*RuleTable, bool) {
+	source, ok := bundled()[strings.ToLower(name)]
+	if !ok {
+		return nil, false
+	}
+	r, err := ParseRuleTable(source)
+	return r, err == nil
+}
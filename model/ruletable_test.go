@@ -0,0 +1,128 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var cellPattern = regexp.MustCompile(`\((\d+),(\d+)\)`)
+
+func (f *ruleFeature) theRuleTable(source *godog.DocString) error {
+	f.source = source.Content
+	f.rule, f.err = ParseRuleTable(f.source)
+	return f.err
+}
+
+func (f *ruleFeature) iParseTheRuleTable(source *godog.DocString) error {
+	f.source = strings.ReplaceAll(source.Content, `\n`, "\n")
+	f.rule, f.err = ParseRuleTable(f.source)
+	return nil
+}
+
+func (f *ruleFeature) aColonyUnderTheRuleTable(w, h int) error {
+	f.colony = NewColony(w, h)
+	f.colony.SetRule(f.rule)
+	return nil
+}
+
+func (f *ruleFeature) theCellsAreInState(cells string, state int) error {
+	for _, m := range cellPattern.FindAllStringSubmatch(cells, -1) {
+		x, _ := strconv.Atoi(m[1])
+		y, _ := strconv.Atoi(m[2])
+		f.colony.SetState(x, y, uint8(state))
+	}
+	return nil
+}
+
+func (f *ruleFeature) generationsAreComputed(n int) error {
+	f.history = [][][]uint8{f.colony.StateGrid()}
+	for i := 0; i < n; i++ {
+		f.colony.Generate()
+		f.history = append(f.history, f.colony.StateGrid())
+	}
+	return nil
+}
+
+func (f *ruleFeature) theColonyShouldHaveCellsInState(n, state int) error {
+	count := 0
+	for _, row := range f.colony.StateGrid() {
+		for _, s := range row {
+			if int(s) == state {
+				count++
+			}
+		}
+	}
+	if count != n {
+		return fmt.Errorf("expected %d cells in state %d, got %d", n, state, count)
+	}
+	return nil
+}
+
+func (f *ruleFeature) theCellShouldHaveBeenInStateApart(x, y, state, times, apart int) error {
+	var generations []int
+	for g, grid := range f.history {
+		if int(grid[y][x]) == state {
+			generations = append(generations, g)
+		}
+	}
+	if len(generations) != times {
+		return fmt.Errorf("expected cell (%d,%d) in state %d in %d generations, got %v", x, y, state, times, generations)
+	}
+	for i := 1; i < len(generations); i++ {
+		if generations[i]-generations[i-1] != apart {
+			return fmt.Errorf("expected generations %d apart, got %v", apart, generations)
+		}
+	}
+	return nil
+}
+
+func (f *ruleFeature) stateOfTheRuleShouldBeColoured(state, r, g, b int) error {
+	table, ok := f.rule.(*RuleTable)
+	if !ok {
+		return fmt.Errorf("%v is not a rule table", f.rule)
+	}
+	c, ok := table.Colour(uint8(state))
+	if !ok {
+		return fmt.Errorf("state %d has no colour", state)
+	}
+	if int(c.R) != r || int(c.G) != g || int(c.B) != b {
+		return fmt.Errorf("expected state %d to be coloured %d,%d,%d, got %d,%d,%d", state, r, g, b, c.R, c.G, c.B)
+	}
+	return nil
+}
+
+func (f *ruleFeature) shouldBeOneOfTheBundledRules(name string) error {
+	if !slices.Contains(BundledRules(), name) {
+		return fmt.Errorf("expected %q in the bundled rules %v", name, BundledRules())
+	}
+	return nil
+}
+
+func (f *ruleFeature) theRuleTableShouldBeInvalidWith(message string) error {
+	if !errors.Is(f.err, InvalidRuleTable) {
+		return fmt.Errorf("expected an invalid rule table error, got %v", f.err)
+	}
+	if !strings.Contains(f.err.Error(), message) {
+		return fmt.Errorf("expected error containing %q, got %q", message, f.err)
+	}
+	return nil
+}
+
+// ruleTableSteps registers the steps of features/ruletable.feature.
+func (f *ruleFeature) ruleTableSteps(ctx *godog.ScenarioContext) {
+	ctx.Step(`^the rule table$`, f.theRuleTable)
+	ctx.Step(`^I parse the rule table$`, f.iParseTheRuleTable)
+	ctx.Step(`^a (\d+)x(\d+) colony under the rule table$`, f.aColonyUnderTheRuleTable)
+	ctx.Step(`^the cells\s*((?:\(\d+,\d+\)\s*)*)are in state (\d+)$`, f.theCellsAreInState)
+	ctx.Step(`^(\d+) generations are computed$`, f.generationsAreComputed)
+	ctx.Step(`^the colony should have (\d+) cells in state (\d+)$`, f.theColonyShouldHaveCellsInState)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) should have been in state (\d+) in (\d+) generations, (\d+) apart$`, f.theCellShouldHaveBeenInStateApart)
+	ctx.Step(`^state (\d+) of the rule should be coloured (\d+),(\d+),(\d+)$`, f.stateOfTheRuleShouldBeColoured)
+	ctx.Step(`^"([^"]*)" should be one of the bundled rules$`, f.shouldBeOneOfTheBundledRules)
+	ctx.Step(`^the rule table should be invalid with "([^"]*)"$`, f.theRuleTableShouldBeInvalidWith)
+}
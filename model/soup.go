@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// Symmetry is the symmetry group imposed on a random soup, named as in apgsearch.
+type Symmetry uint8
+
+const (
+	C1 Symmetry = iota // No symmetry
+	C2                 // Invariant under 180° rotation
+	C4                 // Invariant under 90° rotation
+	D2                 // Mirrored about the vertical axis
+	D4                 // Mirrored about both axes
+	D8                 // Invariant under all rotations and reflections
+)
+
+func (s Symmetry) String() string {
+	switch s {
+	case C1:
+		return "C1"
+	case C2:
+		return "C2"
+	case C4:
+		return "C4"
+	case D2:
+		return "D2"
+	case D4:
+		return "D4"
+	case D8:
+		return "D8"
+	default:
+		return "unknown"
+	}
+}
+
+var InvalidSymmetry = errors.New("invalid symmetry")
+
+// ParseSymmetry parses a symmetry name such as "C1" or "D8", ignoring case.
+func ParseSymmetry(s string) (Symmetry, error) {
+	for sym := C1; sym <= D8; sym++ {
+		if strings.EqualFold(s, sym.String()) {
+			return sym, nil
+		}
+	}
+	return C1, InvalidSymmetry
+}
+
+// Square reports whether the symmetry includes quarter turns, which only fit square soups.
+func (s Symmetry) Square() bool {
+	return s == C4 || s == D8
+}
+
+// images returns the images of cell (x, y) of a w×h soup under the symmetry group.
+func (s Symmetry) images(w, h, x, y int) [][2]int {
+	mx, my := w-1, h-1
+	switch s {
+	case C2:
+		return [][2]int{{x, y}, {mx - x, my - y}}
+	case C4:
+		return [][2]int{{x, y}, {mx - y, x}, {mx - x, my - y}, {y, my - x}}
+	case D2:
+		return [][2]int{{x, y}, {mx - x, y}}
+	case D4:
+		return [][2]int{{x, y}, {mx - x, y}, {x, my - y}, {mx - x, my - y}}
+	case D8:
+		return [][2]int{{x, y}, {mx - y, x}, {mx - x, my - y}, {y, my - x}, {mx - x, y}, {x, my - y}, {y, x}, {my - y, mx - x}}
+	default:
+		return [][2]int{{x, y}}
+	}
+}
+
+// Soup generates a w×h random soup, indexed [y][x], in which each cell is alive with the given density.
+// Cells in the same orbit of the symmetry group share the state of the orbit's first cell.
+// C4 and D8 soups must be square.
+func Soup(rng *rand.Rand, w, h int, density float64, symmetry Symmetry) [][]bool {
+	soup := make([][]bool, h)
+	for y := range soup {
+		soup[y] = make([]bool, w)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			alive := rng.Float64() < density
+			first := true
+			for _, p := range symmetry.images(w, h, x, y) {
+				if p[1] < y || (p[1] == y && p[0] < x) {
+					first = false
+					break
+				}
+			}
+			if first {
+				for _, p := range symmetry.images(w, h, x, y) {
+					soup[p[1]][p[0]] = alive
+				}
+			}
+		}
+	}
+	return soup
+}
+
+// RandomOptions controls how Randomize fills a colony. The same options always give the same cells.
+type RandomOptions struct {
+	Seed          uint64   // Seed of the random number generator
+	Density       float64  // Probability that a cell is alive, between 0 and 1
+	Symmetry      Symmetry // Symmetry imposed on the region
+	X, Y          int      // Top left cell of the region
+	Width, Height int      // Size of the region, 0 to reach the edge of the colony
+}
+
+// DefaultRandomOptions returns options filling the whole colony at 50% density without symmetry.
+func DefaultRandomOptions() RandomOptions {
+	return RandomOptions{Seed: 1, Density: 0.5}
+}
+
+var InvalidRandomOptions = errors.New("invalid random options")
+
+// region returns the rectangle of the colony the options fill, checking it fits the colony and the symmetry.
+func (o RandomOptions) region(c *Colony) (x, y, w, h int, err error) {
+	x, y, w, h = o.X, o.Y, o.Width, o.Height
+	if w == 0 {
+		w = c.dx - x
+	}
+	if h == 0 {
+		h = c.dy - y
+	}
+	switch {
+	case o.Density < 0 || o.Density > 1:
+		err = fmt.Errorf("%w: density %g is not between 0 and 1", InvalidRandomOptions, o.Density)
+	case x < 0 || y < 0 || w < 1 || h < 1 || x+w > c.dx || y+h > c.dy:
+		err = fmt.Errorf("%w: region %dx%d at (%d,%d) is outside the colony", InvalidRandomOptions, w, h, x, y)
+	case o.Symmetry > D8:
+		err = fmt.Errorf("%w: %w", InvalidRandomOptions, InvalidSymmetry)
+	case o.Symmetry.Square() && w != h:
+		err = fmt.Errorf("%w: %s symmetry needs a square region", InvalidRandomOptions, o.Symmetry)
+	}
+	return x, y, w, h, err
+}
@@ -0,0 +1,274 @@
+package model
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"strings"
+	"testing"
+)
+
+type soupFeature struct {
+	colony  *Colony
+	options RandomOptions
+	err     error
+}
+
+func (f *soupFeature) aColony(w, h int) error {
+	f.colony = NewColony(w, h)
+	return nil
+}
+
+func (f *soupFeature) aColonyUnderTheRule(w, h int, rulestring string) error {
+	rule, err := ParseRule(rulestring)
+	if err != nil {
+		return err
+	}
+	f.colony = NewColony(w, h)
+	f.colony.SetRule(rule)
+	return nil
+}
+
+func (f *soupFeature) theCellAtIsAlive(x, y int) error {
+	f.colony.SetState(x, y, 1)
+	return nil
+}
+
+func (f *soupFeature) theCellAtIsInState(x, y, state int) error {
+	f.colony.SetState(x, y, uint8(state))
+	return nil
+}
+
+func (f *soupFeature) theGenerationIs(generation int) error {
+	f.colony.SetGeneration(int64(generation))
+	return nil
+}
+
+func (f *soupFeature) randomise(o RandomOptions) error {
+	f.options = o
+	f.err = f.colony.Randomize(o)
+	return nil
+}
+
+func (f *soupFeature) itIsRandomised(seed int, density float64) error {
+	return f.randomise(RandomOptions{Seed: uint64(seed), Density: density})
+}
+
+func (f *soupFeature) itIsRandomisedInARegion(seed int, density float64, w, h, x, y int) error {
+	return f.randomise(RandomOptions{Seed: uint64(seed), Density: density, X: x, Y: y, Width: w, Height: h})
+}
+
+func (f *soupFeature) itIsRandomisedWithSymmetryInARegion(seed int, density float64, symmetry string, w, h, x, y int) error {
+	s, err := ParseSymmetry(symmetry)
+	if err != nil {
+		return err
+	}
+	return f.randomise(RandomOptions{Seed: uint64(seed), Density: density, Symmetry: s, X: x, Y: y, Width: w, Height: h})
+}
+
+func (f *soupFeature) randomisedLike(o RandomOptions) (*Colony, error) {
+	c := NewColony(f.colony.Width(), f.colony.Height())
+	return c, c.Randomize(o)
+}
+
+func sameCells(a, b *Colony) bool {
+	for y := 0; y < a.Height(); y++ {
+		for x := 0; x < a.Width(); x++ {
+			if a.IsAlive(x, y) != b.IsAlive(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (f *soupFeature) randomisingAnotherColonyWithTheSameOptionsShouldGiveTheSameCells(w, h int) error {
+	if f.err != nil {
+		return f.err
+	}
+	c := NewColony(w, h)
+	if err := c.Randomize(f.options); err != nil {
+		return err
+	}
+	if !sameCells(c, f.colony) {
+		return fmt.Errorf("the same options gave different cells")
+	}
+	return nil
+}
+
+func (f *soupFeature) randomisingWithSeedShouldGiveDifferentCells(seed int) error {
+	o := f.options
+	o.Seed = uint64(seed)
+	c, err := f.randomisedLike(o)
+	if err != nil {
+		return err
+	}
+	if sameCells(c, f.colony) {
+		return fmt.Errorf("seeds %d and %d gave the same cells", f.options.Seed, seed)
+	}
+	return nil
+}
+
+func (f *soupFeature) live() int {
+	n := 0
+	for y := 0; y < f.colony.Height(); y++ {
+		for x := 0; x < f.colony.Width(); x++ {
+			if f.colony.IsAlive(x, y) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func (f *soupFeature) theColonyShouldHaveLiveCells(n int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if got := f.live(); got != n {
+		return fmt.Errorf("expected %d live cells, found %d", n, got)
+	}
+	return nil
+}
+
+func (f *soupFeature) theCellAtShouldBe(x, y int, state string) error {
+	if alive := f.colony.IsAlive(x, y); alive != (state == "alive") {
+		return fmt.Errorf("expected cell (%d,%d) to be %s", x, y, state)
+	}
+	return nil
+}
+
+func (f *soupFeature) betweenCellsShouldBeAlive(least, most int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if n := f.live(); n < least || n > most {
+		return fmt.Errorf("expected between %d and %d live cells, found %d", least, most, n)
+	}
+	return nil
+}
+
+func (f *soupFeature) theRegionShouldBeInvariantUnder(transform string) error {
+	if f.err != nil {
+		return f.err
+	}
+	o := f.options
+	mx, my := o.Width-1, o.Height-1
+	image := map[string]func(x, y int) (int, int){
+		"rotation by 180":     func(x, y int) (int, int) { return mx - x, my - y },
+		"rotation by 90":      func(x, y int) (int, int) { return mx - y, x },
+		"a vertical mirror":   func(x, y int) (int, int) { return mx - x, y },
+		"a horizontal mirror": func(x, y int) (int, int) { return x, my - y },
+		"a diagonal mirror":   func(x, y int) (int, int) { return y, x },
+	}[transform]
+	if image == nil {
+		return fmt.Errorf("unknown transform %q", transform)
+	}
+	live := 0
+	for y := 0; y < o.Height; y++ {
+		for x := 0; x < o.Width; x++ {
+			ix, iy := image(x, y)
+			alive := f.colony.IsAlive(o.X+x, o.Y+y)
+			if alive != f.colony.IsAlive(o.X+ix, o.Y+iy) {
+				return fmt.Errorf("cell (%d,%d) of the region differs from its image (%d,%d)", x, y, ix, iy)
+			}
+			if alive {
+				live++
+			}
+		}
+	}
+	if live == 0 || live == o.Width*o.Height {
+		return fmt.Errorf("the region is uniform, so its symmetry proves nothing")
+	}
+	return nil
+}
+
+func (f *soupFeature) randomisingShouldFailBecause(reason string) error {
+	if f.err == nil {
+		return fmt.Errorf("expected randomising to fail")
+	}
+	if !strings.Contains(f.err.Error(), reason) {
+		return fmt.Errorf("expected an error containing %q, got %q", reason, f.err)
+	}
+	if _, ok := f.colony.RandomOptions(); ok {
+		return fmt.Errorf("the colony remembers options that failed")
+	}
+	return nil
+}
+
+func (f *soupFeature) theColonyShouldRemember(seed int, symmetry string) error {
+	o, ok := f.colony.RandomOptions()
+	if !ok {
+		return fmt.Errorf("the colony remembers no soup")
+	}
+	if o.Seed != uint64(seed) || o.Symmetry.String() != symmetry {
+		return fmt.Errorf("expected seed %d with %s symmetry, got seed %d with %s", seed, symmetry, o.Seed, o.Symmetry)
+	}
+	return nil
+}
+
+func (f *soupFeature) afterClearingTheColonyItShouldRememberNoSoup() error {
+	f.colony.Reset()
+	if _, ok := f.colony.RandomOptions(); ok {
+		return fmt.Errorf("the colony still remembers its soup")
+	}
+	return nil
+}
+
+func (f *soupFeature) everyCellShouldBeInStateOr(a, b int) error {
+	if f.err != nil {
+		return f.err
+	}
+	for y := 0; y < f.colony.Height(); y++ {
+		for x := 0; x < f.colony.Width(); x++ {
+			if s := int(f.colony.State(x, y)); s != a && s != b {
+				return fmt.Errorf("cell (%d,%d) is in state %d", x, y, s)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *soupFeature) theGenerationShouldBe(generation int) error {
+	if got := f.colony.GetGeneration(); got != int64(generation) {
+		return fmt.Errorf("expected generation %d, got %d", generation, got)
+	}
+	return nil
+}
+
+func InitializeSoupScenario(ctx *godog.ScenarioContext) {
+	f := &soupFeature{}
+	ctx.Step(`^a (\d+)x(\d+) colony$`, f.aColony)
+	ctx.Step(`^a (\d+)x(\d+) colony under the rule "([^"]*)"$`, f.aColonyUnderTheRule)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) is alive$`, f.theCellAtIsAlive)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) is in state (\d+)$`, f.theCellAtIsInState)
+	ctx.Step(`^the generation is (\d+)$`, f.theGenerationIs)
+	ctx.Step(`^it is randomised with seed (\d+) at density ([\d.]+)$`, f.itIsRandomised)
+	ctx.Step(`^it is randomised with seed (\d+) at density ([\d.]+) in a (\d+)x(\d+) region at \((\d+),(\d+)\)$`, f.itIsRandomisedInARegion)
+	ctx.Step(`^it is randomised with seed (\d+) at density ([\d.]+) with (\w+) symmetry in a (\d+)x(\d+) region at \((\d+),(\d+)\)$`, f.itIsRandomisedWithSymmetryInARegion)
+	ctx.Step(`^randomising another (\d+)x(\d+) colony with the same options should give the same cells$`, f.randomisingAnotherColonyWithTheSameOptionsShouldGiveTheSameCells)
+	ctx.Step(`^randomising with seed (\d+) should give different cells$`, f.randomisingWithSeedShouldGiveDifferentCells)
+	ctx.Step(`^the colony should have (\d+) live cells$`, f.theColonyShouldHaveLiveCells)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) should be (alive|dead)$`, f.theCellAtShouldBe)
+	ctx.Step(`^between (\d+) and (\d+) cells should be alive$`, f.betweenCellsShouldBeAlive)
+	ctx.Step(`^the region should be invariant under (.+)$`, f.theRegionShouldBeInvariantUnder)
+	ctx.Step(`^randomising should fail because "([^"]*)"$`, f.randomisingShouldFailBecause)
+	ctx.Step(`^the colony should remember seed (\d+) with (\w+) symmetry$`, f.theColonyShouldRemember)
+	ctx.Step(`^after clearing the colony it should remember no soup$`, f.afterClearingTheColonyItShouldRememberNoSoup)
+	ctx.Step(`^every cell should be in state (\d+) or (\d+)$`, f.everyCellShouldBeInStateOr)
+	ctx.Step(`^the generation should be (\d+)$`, f.theGenerationShouldBe)
+}
+
+func TestSoupFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "soup",
+		ScenarioInitializer: InitializeSoupScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/soup.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,184 @@
+package model
+
+// ActivityDecay is the share of its activity a cell keeps for each generation it spends dead, so recently
+// dead cells leave a fading trail.
+const ActivityDecay = 0.75
+
+// tracker records the history of each cell of a tracked colony, indexed [y][x].
+type tracker struct {
+	generations int         // Generations seen since tracking started, counting the first
+	age         [][]int     // Generations each cell has been alive in a row, 0 for cells that aren't
+	heat        [][]int     // Generations each cell has been alive in total
+	activity    [][]float64 // 1 for live cells, decaying by ActivityDecay each generation after they die
+}
+
+// newTracker starts tracking from the current generation of the colony.
+func newTracker(c *Colony) *tracker {
+	t := &tracker{
+		age:      make([][]int, c.dy),
+		heat:     make([][]int, c.dy),
+		activity: make([][]float64, c.dy),
+	}
+	for y := 0; y < c.dy; y++ {
+		t.age[y] = make([]int, c.dx)
+		t.heat[y] = make([]int, c.dx)
+		t.activity[y] = make([]float64, c.dx)
+	}
+	t.update(c)
+	return t
+}
+
+// update records one more generation of the colony.
+func (t *tracker) update(c *Colony) {
+	t.generations++
+	for y := 0; y < c.dy; y++ {
+		for x := 0; x < c.dx; x++ {
+			if c.IsAlive(x, y) {
+				t.age[y][x]++
+				t.heat[y][x]++
+				t.activity[y][x] = 1
+			} else {
+				t.age[y][x] = 0
+				t.activity[y][x] *= ActivityDecay
+			}
+		}
+	}
+}
+
+// shift moves the history of every cell dx columns right and dy rows down, as when the cells themselves move.
+// Cells moved in from beyond the edge have no history.
+func (t *tracker) shift(dx, dy int) {
+	age := make([][]int, len(t.age))
+	heat := make([][]int, len(t.heat))
+	activity := make([][]float64, len(t.activity))
+	for y := range t.age {
+		age[y] = make([]int, len(t.age[y]))
+		heat[y] = make([]int, len(t.heat[y]))
+		activity[y] = make([]float64, len(t.activity[y]))
+	}
+	for y := range t.age {
+		for x := range t.age[y] {
+			nx, ny := x+dx, y+dy
+			if nx < 0 || ny < 0 || ny >= len(age) || nx >= len(age[ny]) {
+				continue
+			}
+			age[ny][nx] = t.age[y][x]
+			heat[ny][nx] = t.heat[y][x]
+			activity[ny][nx] = t.activity[y][x]
+		}
+	}
+	t.age, t.heat, t.activity = age, heat, activity
+}
+
+// clone returns a deep copy of the tracker.
+func (t *tracker) clone() *tracker {
+	clone := &tracker{
+		generations: t.generations,
+		age:         make([][]int, len(t.age)),
+		heat:        make([][]int, len(t.heat)),
+		activity:    make([][]float64, len(t.activity)),
+	}
+	for y := range t.age {
+		clone.age[y] = append([]int(nil), t.age[y]...)
+		clone.heat[y] = append([]int(nil), t.heat[y]...)
+		clone.activity[y] = append([]float64(nil), t.activity[y]...)
+	}
+	return clone
+}
+
+// Track turns tracking of cell age and activity on or off. Turning it on starts from the current generation,
+// and turning it off forgets what was recorded.
+func (c *Colony) Track(on bool) {
+	switch {
+	case !on:
+		c.tracker = nil
+	case c.tracker == nil:
+		c.tracker = newTracker(c)
+	}
+}
+
+// Tracking reports whether the colony tracks cell age and activity.
+func (c *Colony) Tracking() bool {
+	return c.tracker != nil
+}
+
+// retrack restarts tracking from the current generation, if the colony is tracked.
+func (c *Colony) retrack() {
+	if c.tracker != nil {
+		c.tracker = newTracker(c)
+	}
+}
+
+// fits reports whether the tracker covers a colony of the size of c, which it doesn't once the board is resized.
+func (t *tracker) fits(c *Colony) bool {
+	return len(t.age) == c.dy && (c.dy == 0 || len(t.age[0]) == c.dx)
+}
+
+// trackGeneration records a new generation of the colony, if it is tracked.
+func (c *Colony) trackGeneration() {
+	switch {
+	case c.tracker == nil:
+	case c.tracker.fits(c):
+		c.tracker.update(c)
+	default:
+		c.retrack()
+	}
+}
+
+// tracked returns the tracker, if the colony is tracked and (x, y) is on the board. Tracking is restarted
+// first if the board has been resized.
+func (c *Colony) tracked(x, y int) (*tracker, bool) {
+	if c.tracker == nil || x < 0 || y < 0 || x >= c.dx || y >= c.dy {
+		return nil, false
+	}
+	if !c.tracker.fits(c) {
+		c.retrack()
+	}
+	return c.tracker, true
+}
+
+// Age returns the number of generations the cell at (x, y) has been alive in a row, or 0 if it isn't alive.
+// Live cells are at least 1 old, including those of an untracked colony and cells set since the last generation.
+func (c *Colony) Age(x, y int) int {
+	if !c.IsAlive(x, y) {
+		return 0
+	}
+	if t, ok := c.tracked(x, y); ok && t.age[y][x] > 1 {
+		return t.age[y][x]
+	}
+	return 1
+}
+
+// Activity returns how recently the cell at (x, y) was alive: 1 if it is alive, falling towards 0 with each
+// generation since it died. Dead cells of an untracked colony have no activity.
+func (c *Colony) Activity(x, y int) float64 {
+	if c.IsAlive(x, y) {
+		return 1
+	}
+	if t, ok := c.tracked(x, y); ok {
+		return t.activity[y][x]
+	}
+	return 0
+}
+
+// Heat returns the share of the tracked generations the cell at (x, y) has been alive in, from 0 to 1. Cells
+// of an oscillator's stator are always alive and so have a heat of 1, while rotor cells have less.
+// An untracked colony has a heat of 1 for live cells and 0 for the rest.
+func (c *Colony) Heat(x, y int) float64 {
+	t, ok := c.tracked(x, y)
+	if !ok {
+		if c.IsAlive(x, y) {
+			return 1
+		}
+		return 0
+	}
+	return float64(t.heat[y][x]) / float64(t.generations)
+}
+
+// TrackedGenerations returns the number of generations tracked so far, counting the one tracking started at.
+func (c *Colony) TrackedGenerations() int {
+	if c.tracker == nil {
+		return 0
+	}
+	return c.tracker.generations
+}
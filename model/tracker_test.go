@@ -0,0 +1,115 @@
+package model
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"math"
+	"testing"
+)
+
+type trackerFeature struct {
+	colony *Colony
+	clone  *Colony
+}
+
+func (f *trackerFeature) theColony(rows *godog.DocString) error {
+	f.colony = parseCells(Life, rows.Content)
+	return nil
+}
+
+func (f *trackerFeature) theTrackedColony(rows *godog.DocString) error {
+	f.colony = parseCells(Life, rows.Content)
+	f.colony.Track(true)
+	return nil
+}
+
+func (f *trackerFeature) generationsPass(n int) error {
+	for i := 0; i < n; i++ {
+		f.colony.Generate()
+	}
+	return nil
+}
+
+func (f *trackerFeature) theColonyIsReset() error {
+	f.colony.Reset()
+	return nil
+}
+
+func (f *trackerFeature) theLiveCellsAreCentred() error {
+	f.colony.CentreAlive()
+	return nil
+}
+
+func (f *trackerFeature) itIsCloned() error {
+	f.clone = f.colony.Clone()
+	return nil
+}
+
+func (f *trackerFeature) generationsShouldHaveBeenTracked(n int) error {
+	if got := f.colony.TrackedGenerations(); got != n {
+		return fmt.Errorf("expected %d tracked generations, got %d", n, got)
+	}
+	return nil
+}
+
+func (f *trackerFeature) theCloneShouldHaveTracked(n int) error {
+	if got := f.clone.TrackedGenerations(); got != n {
+		return fmt.Errorf("expected the clone to have tracked %d generations, got %d", n, got)
+	}
+	return nil
+}
+
+// theCellsShouldHave checks a table of cells against a measure of the colony, such as their heat.
+func (f *trackerFeature) theCellsShouldHave(measure string, table *godog.Table) error {
+	for _, row := range table.Rows[1:] {
+		var x, y int
+		if _, err := fmt.Sscanf(row.Cells[0].Value, "(%d,%d)", &x, &y); err != nil {
+			return err
+		}
+		var want float64
+		if _, err := fmt.Sscan(row.Cells[1].Value, &want); err != nil {
+			return err
+		}
+		var got float64
+		switch measure {
+		case "age":
+			got = float64(f.colony.Age(x, y))
+		case "activity":
+			got = f.colony.Activity(x, y)
+		case "heat":
+			got = f.colony.Heat(x, y)
+		}
+		if math.Abs(got-want) > 1e-9 {
+			return fmt.Errorf("expected %s %v at (%d,%d), got %v", measure, want, x, y, got)
+		}
+	}
+	return nil
+}
+
+func InitializeTrackerScenario(ctx *godog.ScenarioContext) {
+	f := &trackerFeature{}
+	ctx.Step(`^the colony$`, f.theColony)
+	ctx.Step(`^the tracked colony$`, f.theTrackedColony)
+	ctx.Step(`^(\d+) generations? pass(?:es)?$`, f.generationsPass)
+	ctx.Step(`^the colony is reset$`, f.theColonyIsReset)
+	ctx.Step(`^the live cells are centred$`, f.theLiveCellsAreCentred)
+	ctx.Step(`^it is cloned$`, f.itIsCloned)
+	ctx.Step(`^(\d+) generations? should have been tracked$`, f.generationsShouldHaveBeenTracked)
+	ctx.Step(`^the clone should have tracked (\d+) generations?$`, f.theCloneShouldHaveTracked)
+	ctx.Step(`^the cells should have the (age|activity|heat)$`, f.theCellsShouldHave)
+}
+
+func TestTrackerFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "tracker",
+		ScenarioInitializer: InitializeTrackerScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/tracking.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,262 @@
+package analysis
+
+import (
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/apgcode"
+	"strings"
+)
+
+// Kind is the behaviour of an object when run on its own.
+type Kind uint8
+
+const (
+	Unknown Kind = iota
+	StillLife
+	Oscillator
+	Spaceship
+)
+
+func (k Kind) String() string {
+	switch k {
+	case StillLife:
+		return "still life"
+	case Oscillator:
+		return "oscillator"
+	case Spaceship:
+		return "spaceship"
+	default:
+		return "unknown"
+	}
+}
+
+// Object is a group of live cells that are close enough to interact.
+type Object struct {
+	Cells [][2]int // Live cells (x, y) in colony coordinates
+}
+
+// Bounds returns the origin and size of the object's bounding box.
+func (o Object) Bounds() (x, y, w, h int) {
+	minX, minY, maxX, maxY := bounds(o.Cells)
+	return minX, minY, maxX - minX + 1, maxY - minY + 1
+}
+
+// Classification describes how an object evolves on its own.
+type Classification struct {
+	Kind   Kind
+	Period int    // Generations until the object repeats, 0 if it didn't within the limit
+	Dx     int    // Horizontal displacement per period
+	Dy     int    // Vertical displacement per period
+	Code   string // apgcode of the object, after the rule for rules other than Life, empty if it is unknown
+}
+
+// Neighbourhood is the shape within which live cells are joined into one object.
+type Neighbourhood uint8
+
+const (
+	Moore      Neighbourhood = iota // Square, measured by Chebyshev distance
+	VonNeumann                      // Diamond, measured by Manhattan distance
+)
+
+func (n Neighbourhood) String() string {
+	switch n {
+	case Moore:
+		return "moore"
+	case VonNeumann:
+		return "vonneumann"
+	default:
+		return "unknown"
+	}
+}
+
+// within reports whether an offset of (dx, dy) is inside the neighbourhood of the given radius.
+func (n Neighbourhood) within(dx, dy, radius int) bool {
+	if n == VonNeumann {
+		return abs(dx)+abs(dy) <= radius
+	}
+	return abs(dx) <= radius && abs(dy) <= radius
+}
+
+// Options configures how a colony is split into objects and classified.
+type Options struct {
+	Neighbourhood Neighbourhood // Shape used to join nearby cells
+	MergeDistance int           // Distance up to which live cells belong to the same object
+	MaxPeriod     int           // Longest period looked for when classifying an object
+	Library       Library       // Known objects, used to name the objects found
+	Rule          model.Rule    // Rule the objects run under, Conway's Life if nil
+}
+
+// rule returns the rule the objects run under.
+func (o Options) rule() model.Rule {
+	if o.Rule == nil {
+		return model.Life
+	}
+	return o.Rule
+}
+
+// Supports reports whether objects can be classified under a rule. Objects are sets of live cells run on their
+// own with a margin of one cell, so only two-state rules that look no further than the adjacent cells, don't
+// depend on the generation as Margolus rules do, and don't give birth on empty space can be followed.
+func Supports(rule model.Rule) bool {
+	table, ok := model.LookupTable(rule)
+	return ok && !table[0]
+}
+
+// RuleName returns the rule in the lower case form apgcodes of other rules than Life are qualified with, such
+// as "b36s23".
+func RuleName(rule model.Rule) string {
+	return strings.ToLower(strings.ReplaceAll(rule.String(), "/", ""))
+}
+
+// DefaultOptions joins cells within two cells of each other, which can share a neighbour, and looks for periods up to 64.
+func DefaultOptions() Options {
+	return Options{
+		Neighbourhood: Moore,
+		MergeDistance: 2,
+		MaxPeriod:     64,
+	}
+}
+
+// Separate splits the live cells of the colony into objects of cells within the merge distance of each other.
+func Separate(c *model.Colony, o Options) []Object {
+	var cells [][2]int
+	index := make(map[[2]int]int)
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			if c.IsAlive(x, y) {
+				index[[2]int{x, y}] = len(cells)
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	parent := make([]int, len(cells))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	for i, cell := range cells {
+		for dy := -o.MergeDistance; dy <= o.MergeDistance; dy++ {
+			for dx := -o.MergeDistance; dx <= o.MergeDistance; dx++ {
+				if !o.Neighbourhood.within(dx, dy, o.MergeDistance) {
+					continue
+				}
+				if j, ok := index[[2]int{cell[0] + dx, cell[1] + dy}]; ok {
+					parent[find(i)] = find(j)
+				}
+			}
+		}
+	}
+	groups := make(map[int]int)
+	var objects []Object
+	for i, cell := range cells {
+		root := find(i)
+		g, ok := groups[root]
+		if !ok {
+			g = len(objects)
+			groups[root] = g
+			objects = append(objects, Object{})
+		}
+		objects[g].Cells = append(objects[g].Cells, cell)
+	}
+	return objects
+}
+
+// Classify runs the object on its own under the rule for up to maxPeriod generations to find whether it repeats.
+// Under rules other than Life, the apgcode is qualified with the rule, as in "b36s23/xp2_7".
+func Classify(o Object, rule model.Rule, maxPeriod int) Classification {
+	start := apgcode.Normalise(o.Cells)
+	x0, y0, _, _ := bounds(o.Cells)
+	phases := [][][2]int{start}
+	cells := o.Cells
+	for period := 1; period <= maxPeriod; period++ {
+		cells = Step(cells, rule)
+		if len(cells) == 0 {
+			break
+		}
+		phase := apgcode.Normalise(cells)
+		if equal(phase, start) {
+			x, y, _, _ := bounds(cells)
+			result := Classification{Period: period, Dx: x - x0, Dy: y - y0}
+			moving := result.Dx != 0 || result.Dy != 0
+			switch {
+			case moving:
+				result.Kind = Spaceship
+			case period == 1:
+				result.Kind = StillLife
+			default:
+				result.Kind = Oscillator
+			}
+			result.Code = apgcode.Encode(phases, moving)
+			if rule.String() != model.Life.String() {
+				result.Code = RuleName(rule) + "/" + result.Code
+			}
+			return result
+		}
+		phases = append(phases, phase)
+	}
+	return Classification{Kind: Unknown}
+}
+
+// Step advances live cells one generation under a two-state rule by running them in a colony just large enough
+// to hold the result.
+func Step(cells [][2]int, rule model.Rule) [][2]int {
+	if len(cells) == 0 {
+		return nil
+	}
+	minX, minY, maxX, maxY := bounds(cells)
+	c := model.NewColony(maxX-minX+3, maxY-minY+3)
+	c.SetRule(rule)
+	grid := c.Cells()
+	for _, cell := range cells {
+		(*grid)[cell[1]-minY+1][cell[0]-minX+1] = true
+	}
+	c.Generate()
+	var next [][2]int
+	for y, row := range *c.Cells() {
+		for x, alive := range row {
+			if alive {
+				next = append(next, [2]int{x + minX - 1, y + minY - 1})
+			}
+		}
+	}
+	return next
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// bounds returns the corners of the bounding box of the cells.
+func bounds(cells [][2]int) (minX, minY, maxX, maxY int) {
+	if len(cells) == 0 {
+		return 0, 0, -1, -1
+	}
+	minX, minY, maxX, maxY = cells[0][0], cells[0][1], cells[0][0], cells[0][1]
+	for _, c := range cells {
+		minX, maxX = min(minX, c[0]), max(maxX, c[0])
+		minY, maxY = min(minY, c[1]), max(maxY, c[1])
+	}
+	return minX, minY, maxX, maxY
+}
+
+// equal reports whether two normalised sets of cells are the same.
+func equal(a, b [][2]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
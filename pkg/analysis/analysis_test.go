@@ -0,0 +1,176 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"strings"
+	"testing"
+)
+
+// shapes are the objects used in the scenarios, as live cells (x, y).
+var shapes = map[string][][2]int{
+	"block":       {{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+	"beehive":     {{1, 0}, {2, 0}, {0, 1}, {3, 1}, {1, 2}, {2, 2}},
+	"blinker":     {{0, 1}, {1, 1}, {2, 1}},
+	"glider":      {{1, 0}, {2, 1}, {0, 2}, {1, 2}, {2, 2}},
+	"lwss":        {{1, 0}, {2, 0}, {3, 0}, {4, 0}, {0, 1}, {4, 1}, {4, 2}, {0, 3}, {3, 3}},
+	"r-pentomino": {{1, 0}, {2, 0}, {0, 1}, {1, 1}, {1, 2}},
+}
+
+type analysisFeature struct {
+	colony  *model.Colony
+	options Options
+	objects []Object
+	results []Result
+}
+
+func (f *analysisFeature) aColony(dx, dy int) error {
+	f.colony = model.NewColony(dx, dy)
+	f.options = DefaultOptions()
+	f.objects, f.results = nil, nil
+	return nil
+}
+
+func (f *analysisFeature) aShapeAt(name string, x, y int) error {
+	shape, ok := shapes[name]
+	if !ok {
+		return fmt.Errorf("unknown shape %q", name)
+	}
+	for _, c := range shape {
+		f.colony.Toggle(x+c[0], y+c[1])
+	}
+	return nil
+}
+
+func (f *analysisFeature) theCellAtIsAlive(x, y int) error {
+	f.colony.Toggle(x, y)
+	return nil
+}
+
+func (f *analysisFeature) objectsAreJoinedWithin(distance int, neighbourhood string) error {
+	f.options.MergeDistance = distance
+	switch neighbourhood {
+	case "moore":
+		f.options.Neighbourhood = Moore
+	case "vonneumann":
+		f.options.Neighbourhood = VonNeumann
+	default:
+		return fmt.Errorf("unknown neighbourhood %q", neighbourhood)
+	}
+	return nil
+}
+
+func (f *analysisFeature) objectsAreClassifiedUpToPeriod(period int) error {
+	f.options.MaxPeriod = period
+	return nil
+}
+
+func (f *analysisFeature) aLibraryThatKnows(first, second string) error {
+	f.options.Library = make(Library)
+	for _, name := range []string{first, second} {
+		f.options.Library.Add(strings.ToUpper(name[:1])+name[1:], shapes[name], f.options.MaxPeriod)
+	}
+	return nil
+}
+
+func (f *analysisFeature) theColonyRunsUnderTheRule(s string) error {
+	rule, err := model.ParseRule(s)
+	if err != nil {
+		return err
+	}
+	f.colony.SetRule(rule)
+	f.options.Rule = rule
+	return nil
+}
+
+func (f *analysisFeature) objectShouldHaveTheApgcode(i int, code string) error {
+	if got := f.results[i-1].Code; got != code {
+		return fmt.Errorf("expected object %d to have the apgcode %q, got %q", i, code, got)
+	}
+	return nil
+}
+
+func (f *analysisFeature) theColonyIsSeparated() error {
+	f.objects = Separate(f.colony, f.options)
+	return nil
+}
+
+func (f *analysisFeature) theColonyIsAnalysed() error {
+	f.results = Analyse(f.colony, f.options)
+	f.objects = make([]Object, len(f.results))
+	for i, r := range f.results {
+		f.objects[i] = r.Object
+	}
+	return nil
+}
+
+func (f *analysisFeature) thereShouldBeObjects(n int) error {
+	if len(f.objects) != n {
+		return fmt.Errorf("expected %d objects, got %d", n, len(f.objects))
+	}
+	return nil
+}
+
+func (f *analysisFeature) objectShouldBeA(i int, kind string, period, dx, dy int) error {
+	r := f.results[i-1]
+	if r.Kind.String() != kind || r.Period != period || r.Dx != dx || r.Dy != dy {
+		return fmt.Errorf("expected a %s with period %d moving (%d,%d), got a %s with period %d moving (%d,%d)",
+			kind, period, dx, dy, r.Kind, r.Period, r.Dx, r.Dy)
+	}
+	return nil
+}
+
+func (f *analysisFeature) objectShouldBeUnknown(i int) error {
+	if r := f.results[i-1]; r.Kind != Unknown || r.Code != "" {
+		return fmt.Errorf("expected an unknown object, got a %s %q", r.Kind, r.Code)
+	}
+	return nil
+}
+
+func (f *analysisFeature) theObjectAtShouldBeNamed(x, y int, name string) error {
+	for _, r := range f.results {
+		for _, c := range r.Cells {
+			if c == [2]int{x, y} {
+				if r.Name != name {
+					return fmt.Errorf("expected object at (%d,%d) to be named %q, got %q", x, y, name, r.Name)
+				}
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("no object at (%d,%d)", x, y)
+}
+
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	f := &analysisFeature{}
+	ctx.Step(`^a (\d+)x(\d+) colony$`, f.aColony)
+	ctx.Step(`^an? ([\w-]+) at \((\d+),(\d+)\)$`, f.aShapeAt)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) is alive$`, f.theCellAtIsAlive)
+	ctx.Step(`^objects are joined within (\d+) cells in the (\w+) neighbourhood$`, f.objectsAreJoinedWithin)
+	ctx.Step(`^objects are classified up to period (\d+)$`, f.objectsAreClassifiedUpToPeriod)
+	ctx.Step(`^a library that knows the (\w+) and the (\w+)$`, f.aLibraryThatKnows)
+	ctx.Step(`^the colony runs under the rule "([^"]*)"$`, f.theColonyRunsUnderTheRule)
+	ctx.Step(`^object (\d+) should have the apgcode "([^"]*)"$`, f.objectShouldHaveTheApgcode)
+	ctx.Step(`^the colony is separated$`, f.theColonyIsSeparated)
+	ctx.Step(`^the colony is analysed$`, f.theColonyIsAnalysed)
+	ctx.Step(`^there should be (\d+) objects$`, f.thereShouldBeObjects)
+	ctx.Step(`^object (\d+) should be a (.+) with period (\d+) moving \((-?\d+),(-?\d+)\)$`, f.objectShouldBeA)
+	ctx.Step(`^object (\d+) should be unknown$`, f.objectShouldBeUnknown)
+	ctx.Step(`^the object at \((\d+),(\d+)\) should be named "([^"]*)"$`, f.theObjectAtShouldBeNamed)
+}
+
+func TestAnalysisFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "analysis",
+		ScenarioInitializer: InitializeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/analysis.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,104 @@
+Feature: Object separation and classification
+
+  Scenario: Distant objects are separated
+    Given a 20x10 colony
+    And a block at (1,1)
+    And a blinker at (10,4)
+    When the colony is separated
+    Then there should be 2 objects
+
+  Scenario Outline: The merge distance and neighbourhood decide which cells belong together
+    Given a 10x10 colony
+    And the cell at (2,2) is alive
+    And the cell at (4,4) is alive
+    And objects are joined within <distance> cells in the <neighbourhood> neighbourhood
+    When the colony is separated
+    Then there should be <objects> objects
+
+    Examples:
+      | distance | neighbourhood | objects |
+      | 1        | moore         | 2       |
+      | 2        | moore         | 1       |
+      | 2        | vonneumann    | 2       |
+      | 4        | vonneumann    | 1       |
+
+  Scenario Outline: Objects are classified by running them on their own
+    Given a 20x20 colony
+    And a <object> at (5,5)
+    When the colony is analysed
+    Then there should be 1 objects
+    And object 1 should be a <kind> with period <period> moving (<dx>,<dy>)
+
+    Examples:
+      | object  | kind       | period | dx | dy |
+      | block   | still life | 1      | 0  | 0  |
+      | beehive | still life | 1      | 0  | 0  |
+      | blinker | oscillator | 2      | 0  | 0  |
+      | glider  | spaceship  | 4      | 1  | 1  |
+      | lwss    | spaceship  | 4      | 2  | 0  |
+
+  Scenario: Known objects are named from the library
+    Given a 30x12 colony
+    And a glider at (2,2)
+    And a beehive at (20,3)
+    And a library that knows the glider and the beehive
+    When the colony is analysed
+    Then the object at (3,2) should be named "Glider"
+    And the object at (21,3) should be named "Beehive"
+
+  Scenario: An object that doesn't repeat in time is unknown
+    Given a 20x20 colony
+    And a r-pentomino at (8,8)
+    And objects are classified up to period 8
+    When the colony is analysed
+    Then object 1 should be unknown
+
+  Scenario Outline: Objects are classified under the colony's rule
+    Given a 20x20 colony
+    And the colony runs under the rule "<rule>"
+    And a <object> at (5,5)
+    When the colony is analysed
+    Then object 1 should be a <kind> with period <period> moving (0,0)
+    And object 1 should have the apgcode "<code>"
+
+    Examples:
+      | rule                                      | object  | kind       | period | code           |
+      | B3/S23  | blinker | oscillator | 2      | xp2_7          |
+      | B36/S23 | blinker | oscillator | 2      | b36s23/xp2_7   |
+      | B36/S23 | beehive | still life | 1      | b36s23/xs6_696 |
+
+  Scenario: A block dies out without survival
+    Given a 20x20 colony
+    And the colony runs under the rule "B3/S"
+    And a block at (5,5)
+    When the colony is analysed
+    Then object 1 should be unknown
+
+  Scenario: Life's library doesn't name the objects of other rules
+    Given a 30x12 colony
+    And the colony runs under the rule "B36/S23"
+    And a beehive at (20,3)
+    And a library that knows the glider and the beehive
+    When the colony is analysed
+    Then the object at (21,3) should be named ""
+
+  Scenario Outline: Objects of rules the analysis can't follow aren't classified
+    Given a 20x20 colony
+    And the colony runs under the rule "<rule>"
+    And a block at (5,5)
+    When the colony is analysed
+    Then there should be 0 objects
+
+    Examples:
+      | rule                                      |
+      | B2/S/C3                                   |
+      | MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15 |
+      | R5,C0,M1,S34..58,B34..45,NM               |
+      | B03/S23                                   |
+
+  Scenario: Larger than Life rules of range 1 are classified
+    Given a 20x20 colony
+    And the colony runs under the rule "R1,C0,M0,S2..3,B3..3,NM"
+    And a blinker at (5,5)
+    When the colony is analysed
+    Then object 1 should be a oscillator with period 2 moving (0,0)
@@ -0,0 +1,43 @@
+package analysis
+
+import "github.com/richardwooding/gameoflife/model"
+
+// Library names objects by their apgcode, which is the same for every phase and orientation of an object.
+type Library map[string]string
+
+// Add classifies a named object given by its live cells under Conway's Life and records its name against its
+// apgcode. Objects that don't repeat within maxPeriod generations aren't recorded.
+func (l Library) Add(name string, cells [][2]int, maxPeriod int) {
+	if class := Classify(Object{Cells: cells}, model.Life, maxPeriod); class.Code != "" {
+		if _, ok := l[class.Code]; !ok {
+			l[class.Code] = name
+		}
+	}
+}
+
+// Name returns the name of a known object, or an empty string.
+func (l Library) Name(code string) string {
+	return l[code]
+}
+
+// Result is an object found in a colony and how it behaves.
+type Result struct {
+	Object
+	Classification
+	Name string // Name of the object from the library, empty if it isn't known
+}
+
+// Analyse splits the colony into objects and classifies and names each of them under the rule of the options.
+// There are no results under rules that aren't supported.
+func Analyse(c *model.Colony, o Options) []Result {
+	if !Supports(o.rule()) {
+		return nil
+	}
+	objects := Separate(c, o)
+	results := make([]Result, len(objects))
+	for i, object := range objects {
+		class := Classify(object, o.rule(), o.MaxPeriod)
+		results[i] = Result{Object: object, Classification: class, Name: o.Library.Name(class.Code)}
+	}
+	return results
+}
@@ -0,0 +1,234 @@
+package apgcode
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// digits are the extended Wechsler characters for a 5-cell column strip, indexed by its bit pattern.
+const digits = "0123456789abcdefghijklmnopqrstuv"
+
+// zeros are the characters following 'y' for runs of 4 to 39 empty columns.
+const zeros = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// Normalise translates live cells (x, y) so their bounding box starts at the origin and sorts them by row.
+func Normalise(cells [][2]int) [][2]int {
+	if len(cells) == 0 {
+		return nil
+	}
+	minX, minY := cells[0][0], cells[0][1]
+	for _, c := range cells {
+		minX = min(minX, c[0])
+		minY = min(minY, c[1])
+	}
+	out := make([][2]int, len(cells))
+	for i, c := range cells {
+		out[i] = [2]int{c[0] - minX, c[1] - minY}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i][1] != out[j][1] {
+			return out[i][1] < out[j][1]
+		}
+		return out[i][0] < out[j][0]
+	})
+	return out
+}
+
+// Wechsler encodes live cells in extended Wechsler format, in the orientation given.
+func Wechsler(cells [][2]int) string {
+	cells = Normalise(cells)
+	w, h := 0, 0
+	for _, c := range cells {
+		w = max(w, c[0]+1)
+		h = max(h, c[1]+1)
+	}
+	strips := make([][]uint8, (h+4)/5)
+	for i := range strips {
+		strips[i] = make([]uint8, w)
+	}
+	for _, c := range cells {
+		strips[c[1]/5][c[0]] |= 1 << (c[1] % 5)
+	}
+	var b strings.Builder
+	for i, strip := range strips {
+		if i > 0 {
+			b.WriteByte('z')
+		}
+		// Trailing empty columns are dropped from each strip.
+		n := len(strip)
+		for n > 0 && strip[n-1] == 0 {
+			n--
+		}
+		run := 0
+		for _, column := range strip[:n] {
+			if column == 0 {
+				run++
+				continue
+			}
+			writeZeros(&b, run)
+			run = 0
+			b.WriteByte(digits[column])
+		}
+	}
+	return b.String()
+}
+
+// writeZeros writes a run of empty columns using the 'w', 'x' and 'y' abbreviations.
+func writeZeros(b *strings.Builder, n int) {
+	for n > 0 {
+		switch {
+		case n == 1:
+			b.WriteByte('0')
+			n = 0
+		case n == 2:
+			b.WriteByte('w')
+			n = 0
+		case n == 3:
+			b.WriteByte('x')
+			n = 0
+		default:
+			run := min(n, 39)
+			b.WriteByte('y')
+			b.WriteByte(zeros[run-4])
+			n -= run
+		}
+	}
+}
+
+// orientations returns the eight rotations and reflections of the cells.
+func orientations(cells [][2]int) [][][2]int {
+	transforms := []func(x, y int) (int, int){
+		func(x, y int) (int, int) { return x, y },
+		func(x, y int) (int, int) { return -x, y },
+		func(x, y int) (int, int) { return x, -y },
+		func(x, y int) (int, int) { return -x, -y },
+		func(x, y int) (int, int) { return y, x },
+		func(x, y int) (int, int) { return -y, x },
+		func(x, y int) (int, int) { return y, -x },
+		func(x, y int) (int, int) { return -y, -x },
+	}
+	out := make([][][2]int, len(transforms))
+	for i, t := range transforms {
+		out[i] = make([][2]int, len(cells))
+		for j, c := range cells {
+			x, y := t(c[0], c[1])
+			out[i][j] = [2]int{x, y}
+		}
+	}
+	return out
+}
+
+// better reports whether representation a sorts before b: shorter first, then lexicographically.
+func better(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// Canonical returns the representation of the object that sorts first over all of its phases and orientations.
+func Canonical(phases [][][2]int) string {
+	best := ""
+	for _, phase := range phases {
+		for _, o := range orientations(phase) {
+			if rep := Wechsler(o); best == "" || better(rep, best) {
+				best = rep
+			}
+		}
+	}
+	return best
+}
+
+// Encode returns the apgcode of an object from its phases over one period.
+// Still lifes are prefixed with their population (xs), oscillators and spaceships with their period (xp and xq).
+func Encode(phases [][][2]int, moving bool) string {
+	period := len(phases)
+	switch {
+	case moving:
+		return fmt.Sprintf("xq%d_%s", period, Canonical(phases))
+	case period == 1:
+		return fmt.Sprintf("xs%d_%s", len(phases[0]), Canonical(phases))
+	default:
+		return fmt.Sprintf("xp%d_%s", period, Canonical(phases))
+	}
+}
+
+var (
+	InvalidCode     = errors.New("invalid apgcode")
+	InvalidWechsler = errors.New("invalid extended Wechsler representation")
+)
+
+// Parse splits an apgcode into its prefix ("xs", "xp" or "xq"), the population or period that follows the prefix,
+// and the extended Wechsler representation.
+func Parse(code string) (prefix string, n int, wechsler string, err error) {
+	head, wechsler, ok := strings.Cut(code, "_")
+	if !ok || len(head) < 3 {
+		return "", 0, "", fmt.Errorf("%w: %q", InvalidCode, code)
+	}
+	prefix = head[:2]
+	if prefix != "xs" && prefix != "xp" && prefix != "xq" {
+		return "", 0, "", fmt.Errorf("%w: unknown prefix in %q", InvalidCode, code)
+	}
+	n, err = strconv.Atoi(head[2:])
+	if err != nil || n < 1 {
+		return "", 0, "", fmt.Errorf("%w: %q", InvalidCode, code)
+	}
+	return prefix, n, wechsler, nil
+}
+
+// Decode returns the live cells (x, y) of the object described by an apgcode.
+func Decode(code string) ([][2]int, error) {
+	prefix, n, wechsler, err := Parse(code)
+	if err != nil {
+		return nil, err
+	}
+	cells, err := DecodeWechsler(wechsler)
+	if err != nil {
+		return nil, err
+	}
+	if prefix == "xs" && len(cells) != n {
+		return nil, fmt.Errorf("%w: %q has %d cells, not %d", InvalidCode, code, len(cells), n)
+	}
+	return cells, nil
+}
+
+// DecodeWechsler returns the live cells (x, y) of an extended Wechsler representation.
+func DecodeWechsler(s string) ([][2]int, error) {
+	var cells [][2]int
+	x, strip := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == 'w':
+			x += 2
+		case ch == 'x':
+			x += 3
+		case ch == 'y':
+			i++
+			if i == len(s) || strings.IndexByte(zeros, s[i]) < 0 {
+				return nil, fmt.Errorf("%w: %q", InvalidWechsler, s)
+			}
+			x += 4 + strings.IndexByte(zeros, s[i])
+		case ch == 'z':
+			x = 0
+			strip++
+		default:
+			column := strings.IndexByte(digits, ch)
+			if column < 0 {
+				return nil, fmt.Errorf("%w: unexpected %q in %q", InvalidWechsler, ch, s)
+			}
+			for bit := 0; bit < 5; bit++ {
+				if column&(1<<bit) != 0 {
+					cells = append(cells, [2]int{x, strip*5 + bit})
+				}
+			}
+			x++
+		}
+	}
+	if len(cells) == 0 {
+		return nil, fmt.Errorf("%w: %q has no live cells", InvalidWechsler, s)
+	}
+	return Normalise(cells), nil
+}
@@ -0,0 +1,111 @@
+package apgcode
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+type apgcodeFeature struct {
+	cells          [][2]int
+	representation string
+	err            error
+}
+
+var coordinates = regexp.MustCompile(`\((\d+),(\d+)\)`)
+
+func (f *apgcodeFeature) liveCellsAt(list string) error {
+	f.cells = nil
+	for _, m := range coordinates.FindAllStringSubmatch(list, -1) {
+		x, _ := strconv.Atoi(m[1])
+		y, _ := strconv.Atoi(m[2])
+		f.cells = append(f.cells, [2]int{x, y})
+	}
+	return nil
+}
+
+func (f *apgcodeFeature) theCellsAreEncoded() error {
+	f.representation = Wechsler(f.cells)
+	return nil
+}
+
+func (f *apgcodeFeature) theCanonicalRepresentationIsComputed() error {
+	f.representation = Canonical([][][2]int{f.cells})
+	return nil
+}
+
+func (f *apgcodeFeature) theRepresentationShouldBe(expected string) error {
+	if f.representation != expected {
+		return fmt.Errorf("expected %q, got %q", expected, f.representation)
+	}
+	return nil
+}
+
+func (f *apgcodeFeature) isDecoded(wechsler string) error {
+	f.cells, f.err = DecodeWechsler(wechsler)
+	return f.err
+}
+
+func (f *apgcodeFeature) itShouldEncodeBackTo(wechsler string) error {
+	if rep := Wechsler(f.cells); rep != wechsler {
+		return fmt.Errorf("expected %q, got %q", wechsler, rep)
+	}
+	return nil
+}
+
+func (f *apgcodeFeature) itShouldHaveLiveCells(n int) error {
+	if len(f.cells) != n {
+		return fmt.Errorf("expected %d live cells, got %d", n, len(f.cells))
+	}
+	return nil
+}
+
+func (f *apgcodeFeature) theApgcodeIsDecoded(code string) error {
+	f.cells, f.err = Decode(code)
+	return nil
+}
+
+func (f *apgcodeFeature) decodingShouldFailWith(message string) error {
+	var expected error
+	switch message {
+	case InvalidCode.Error():
+		expected = InvalidCode
+	case InvalidWechsler.Error():
+		expected = InvalidWechsler
+	}
+	if !errors.Is(f.err, expected) {
+		return fmt.Errorf("expected %q, got %v", message, f.err)
+	}
+	return nil
+}
+
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	f := &apgcodeFeature{}
+	ctx.Step(`^live cells at (.+)$`, f.liveCellsAt)
+	ctx.Step(`^the cells are encoded in extended Wechsler format$`, f.theCellsAreEncoded)
+	ctx.Step(`^the canonical representation is computed$`, f.theCanonicalRepresentationIsComputed)
+	ctx.Step(`^the representation should be "([^"]*)"$`, f.theRepresentationShouldBe)
+	ctx.Step(`^"([^"]*)" is decoded$`, f.isDecoded)
+	ctx.Step(`^it should encode back to "([^"]*)"$`, f.itShouldEncodeBackTo)
+	ctx.Step(`^it should have (\d+) live cells$`, f.itShouldHaveLiveCells)
+	ctx.Step(`^the apgcode "([^"]*)" is decoded$`, f.theApgcodeIsDecoded)
+	ctx.Step(`^decoding should fail with "([^"]*)"$`, f.decodingShouldFailWith)
+}
+
+func TestApgcodeFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "apgcode",
+		ScenarioInitializer: InitializeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/apgcode.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,50 @@
+Feature: apgcode encoding and decoding
+
+  Scenario Outline: Runs of empty columns are abbreviated
+    Given live cells at <cells>
+    When the cells are encoded in extended Wechsler format
+    Then the representation should be "<wechsler>"
+
+    Examples:
+      | cells                 | wechsler |
+      | (0,0) (2,0)           | 101      |
+      | (0,0) (3,0)           | 1w1      |
+      | (0,0) (4,0)           | 1x1      |
+      | (0,0) (5,0)           | 1y01     |
+      | (0,0) (11,0)          | 1y61     |
+      | (0,0) (40,0)          | 1yz1     |
+      | (0,0) (41,0)          | 1yz01    |
+      | (0,0) (0,5)           | 1z1      |
+      | (1,0) (0,10)          | 01zz1    |
+
+  Scenario Outline: Extended Wechsler representations decode to their cells
+    When "<wechsler>" is decoded
+    Then it should encode back to "<wechsler>"
+    And it should have <population> live cells
+
+    Examples:
+      | wechsler     | population |
+      | 33           | 4          |
+      | 153          | 5          |
+      | 6frc         | 12         |
+      | 1y61         | 2          |
+      | 0g0696z321   | 11         |
+
+  Scenario Outline: Malformed apgcodes are rejected
+    When the apgcode "<apgcode>" is decoded
+    Then decoding should fail with "<error>"
+
+    Examples:
+      | apgcode   | error                                         |
+      | 33        | invalid apgcode                               |
+      | xz4_33    | invalid apgcode                               |
+      | xs_33     | invalid apgcode                               |
+      | xs3_33    | invalid apgcode                               |
+      | xp2_7!    | invalid extended Wechsler representation      |
+      | xp2_7y    | invalid extended Wechsler representation      |
+      | xq4_      | invalid extended Wechsler representation      |
+
+  Scenario: The canonical representation is the shortest over all orientations
+    Given live cells at (0,0) (1,0) (2,0)
+    When the canonical representation is computed
+    Then the representation should be "7"
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/game"
+	"io"
+	"os"
+	"sort"
+)
+
+// command is a subcommand of the gameoflife binary.
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"export":      {summary: "write an animated GIF or APNG of a run", run: runExport},
+	"icons":       {summary: "write the app's icons in every size from the logo", run: runIcons},
+	"periodic":    {summary: "search for oscillators and spaceships and write them as RLE", run: runPeriodic},
+	"predecessor": {summary: "search for a pattern that becomes the colony in one generation", run: runPredecessor},
+	"search":      {summary: "run random soups and write a census of the resulting objects", run: runSearch},
+	"snapshot":    {summary: "write a PNG or SVG image of a colony", run: runSnapshot},
+}
+
+var UnknownCommand = errors.New("unknown command")
+
+// Run executes the subcommand named by args[0] with the remaining arguments.
+func Run(args []string) error {
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return UnknownCommand
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(os.Stderr)
+		return fmt.Errorf("%w: %s", UnknownCommand, args[0])
+	}
+	return cmd.run(args[1:])
+}
+
+// usage lists the available subcommands.
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "usage: gameoflife <command> [flags]")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+}
+
+// colonyFlags selects the colony a command starts from.
+type colonyFlags struct {
+	state   string
+	pattern string
+	apgcode string
+	width   int
+	height  int
+}
+
+func (f *colonyFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.state, "state", "", "state string from a shared URL (the part after #)")
+	fs.StringVar(&f.pattern, "pattern", "", "name of a predefined pattern to start from")
+	fs.StringVar(&f.apgcode, "apgcode", "", "apgcode of an object to start from, such as xq4_153")
+	fs.IntVar(&f.width, "width", 64, "colony width when starting from a pattern or apgcode")
+	fs.IntVar(&f.height, "height", 64, "colony height when starting from a pattern or apgcode")
+}
+
+// colony builds the starting colony from the flags.
+func (f *colonyFlags) colony() (*model.Colony, error) {
+	if f.state == "" && (f.width < 1 || f.height < 1) {
+		return nil, fmt.Errorf("invalid colony size %dx%d", f.width, f.height)
+	}
+	switch {
+	case f.state != "":
+		return game.DecodeState(f.state)
+	case f.pattern != "":
+		p, ok := game.PatternByName(f.pattern)
+		if !ok {
+			return nil, fmt.Errorf("unknown pattern %q", f.pattern)
+		}
+		colony := model.NewColony(f.width, f.height)
+		p.Stamp(colony.Cells(), 2, 2)
+		return colony, nil
+	case f.apgcode != "":
+		p, err := game.PatternFromApgcode(f.apgcode, f.apgcode)
+		if err != nil {
+			return nil, err
+		}
+		colony := model.NewColony(f.width, f.height)
+		p.Stamp(colony.Cells(), 2, 2)
+		return colony, nil
+	default:
+		return nil, errors.New("one of -state, -pattern or -apgcode is required")
+	}
+}
+
+// writeOutput writes what write produces to the named output file, with "-" meaning standard output. The file
+// is only created once write has succeeded, so a failed run leaves nothing behind.
+func writeOutput(name string, write func(w io.Writer) error) error {
+	var buff bytes.Buffer
+	if err := write(&buff); err != nil {
+		return err
+	}
+	w, err := create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buff.Bytes()); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// create opens the named output file, with "-" meaning standard output.
+func create(name string) (io.WriteCloser, error) {
+	if name == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.Create(name)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
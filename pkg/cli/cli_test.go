@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"strings"
+	"testing"
+)
+
+type cliFeature struct {
+	err error
+}
+
+func (f *cliFeature) iRun(command string) error {
+	args := strings.Fields(command)
+	f.err = Run(args[1:])
+	return nil
+}
+
+func (f *cliFeature) itShouldFailWith(message string) error {
+	if f.err == nil {
+		return fmt.Errorf("expected the command to fail with %q", message)
+	}
+	if !strings.Contains(f.err.Error(), message) {
+		return fmt.Errorf("expected the command to fail with %q, got %q", message, f.err)
+	}
+	return nil
+}
+
+func InitializeCLIScenario(ctx *godog.ScenarioContext) {
+	f := &cliFeature{}
+	ctx.Step(`^I run "([^"]*)"$`, f.iRun)
+	ctx.Step(`^it should fail with "(.*)"$`, f.itShouldFailWith)
+}
+
+func TestCLIFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "cli",
+		ScenarioInitializer: InitializeCLIScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"github.com/richardwooding/gameoflife/pkg/theme"
+	"image/color"
+	"io"
+)
+
+// renderFlags holds the drawing options shared by the image commands.
+type renderFlags struct {
+	cellSize  int
+	alive     string
+	dead      string
+	grid      string
+	gridLines bool
+	mode      string
+	theme     string
+}
+
+func (f *renderFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&f.cellSize, "cell", 8, "cell size in pixels")
+	fs.StringVar(&f.theme, "theme", theme.Dark.Name, "colour theme: dark, light, high-contrast or golly")
+	fs.StringVar(&f.alive, "alive", "", "colour of live cells, overriding the theme")
+	fs.StringVar(&f.dead, "dead", "", "colour of dead cells, overriding the theme")
+	fs.StringVar(&f.grid, "grid", "", "colour of grid lines, overriding the theme")
+	fs.BoolVar(&f.gridLines, "gridlines", false, "draw grid lines between cells")
+	fs.StringVar(&f.mode, "mode", "states", "what cell colours show: states, age, trail or heat")
+}
+
+// options converts the flags to render options.
+func (f *renderFlags) options() (render.Options, error) {
+	t, ok := theme.Named(f.theme)
+	if !ok {
+		return render.Options{}, fmt.Errorf("unknown theme %q", f.theme)
+	}
+	opts := t.Options()
+	if f.cellSize < 1 {
+		return opts, fmt.Errorf("invalid cell size %d", f.cellSize)
+	}
+	opts.CellSize, opts.GridLines = f.cellSize, f.gridLines
+	var err error
+	if opts.Mode, err = render.ParseMode(f.mode); err != nil {
+		return opts, err
+	}
+	for _, c := range []struct {
+		flag   string
+		colour *color.Color
+	}{{f.alive, &opts.Alive}, {f.dead, &opts.Dead}, {f.grid, &opts.Grid}} {
+		if c.flag == "" {
+			continue
+		}
+		if *c.colour, err = render.ParseColor(c.flag); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// runExport writes an animated image of a run of the colony.
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	var source colonyFlags
+	var drawing renderFlags
+	source.register(fs)
+	drawing.register(fs)
+	defaults := render.DefaultAnimationOptions()
+	format := fs.String("format", "gif", "animation format: gif or apng")
+	generations := fs.Int("generations", defaults.Generations, "number of generations to run")
+	delay := fs.Duration("delay", defaults.Delay, "time each frame is shown for, between 10ms and 1m5.535s")
+	output := fs.String("o", "-", "output file, - for standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := render.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+	if *generations < 0 {
+		return fmt.Errorf("invalid number of generations %d", *generations)
+	}
+	opts, err := drawing.options()
+	if err != nil {
+		return err
+	}
+	colony, err := source.colony()
+	if err != nil {
+		return err
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		return render.Animate(w, colony, f, render.AnimationOptions{
+			Options:     opts,
+			Generations: *generations,
+			Delay:       *delay,
+		})
+	})
+}
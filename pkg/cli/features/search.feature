@@ -0,0 +1,21 @@
+Feature: Soup search from the command line
+
+  Scenario Outline: A search with a bad flag is refused
+    When I run "gameoflife search <flags>"
+    Then it should fail with "<error>"
+
+    Examples:
+      | flags              | error                                                                   |
+      | -soups -1          | soups -1 is negative                                                    |
+      | -size 0            | soups of size 0 padded by 48 are not between 1 and 1024 cells across    |
+      | -size 1000         | soups of size 1000 padded by 48 are not between 1 and 1024 cells across |
+      | -padding -1        | padding -1 is negative                                                  |
+      | -density 2         | density 2 is not between 0 and 1                                        |
+      | -density -0.5      | density -0.5 is not between 0 and 1                                     |
+      | -max-generations 0 | max generations 0 is less than 1                                        |
+      | -max-period 0      | max period 0 is less than 1                                             |
+      | -workers 0         | workers 0 is less than 1                                                |
+      | -samples -1        | samples -1 is negative                                                  |
+      | -rare -1           | rare -1 is negative                                                     |
+      | -symmetry C3       | invalid symmetry                                                        |
+      | -format xml        | invalid report format "xml"                                             |
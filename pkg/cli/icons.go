@@ -0,0 +1,17 @@
+package cli
+
+import (
+	"flag"
+	"github.com/richardwooding/gameoflife/pkg/pwa"
+)
+
+// runIcons writes the icons the app is installed with, in every size, from the logo.
+func runIcons(args []string) error {
+	fs := flag.NewFlagSet("icons", flag.ContinueOnError)
+	src := fs.String("src", "web/logo.png", "PNG image to make the icons from")
+	dir := fs.String("o", "web/icons", "directory to write the icons to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return pwa.GenerateIcons(*src, *dir)
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/periodic"
+	"github.com/richardwooding/gameoflife/pkg/rle"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// runPeriodic searches for oscillators or spaceships and writes each one found as RLE.
+func runPeriodic(args []string) error {
+	fs := flag.NewFlagSet("periodic", flag.ContinueOnError)
+	o := periodic.DefaultOptions()
+	rulestring := fs.String("rule", model.Life.String(), "rule to search under, with two states on adjacent cells")
+	fs.IntVar(&o.Period, "period", o.Period, "period of the oscillators to look for")
+	velocity := fs.String("velocity", "", "speed of the spaceships to look for, such as c/4d, 2c/4 or (2,1)c/6, instead of oscillators")
+	fs.IntVar(&o.Width, "width", o.Width, "width of the box every phase must fit in")
+	fs.IntVar(&o.Height, "height", o.Height, "height of the box every phase must fit in")
+	symmetry := fs.String("symmetry", o.Symmetry.String(), "symmetry of every phase: C1, C2, C4, D2, D4 or D8")
+	fs.IntVar(&o.Results, "results", o.Results, "number of different patterns to find")
+	timeout := fs.Duration("timeout", time.Minute, "give up after this long")
+	output := fs.String("o", "-", "output file, - for standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rule, err := model.ParseRule(*rulestring)
+	if err != nil {
+		return err
+	}
+	if o.Symmetry, err = model.ParseSymmetry(*symmetry); err != nil {
+		return err
+	}
+	if *velocity != "" {
+		if o.DX, o.DY, o.Period, err = periodic.ParseVelocity(*velocity); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	results, err := periodic.Search(ctx, rule, o)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	w, werr := create(*output)
+	if werr != nil {
+		return werr
+	}
+	defer w.Close()
+	for i, r := range results {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if werr := rle.Write(w, r.Pattern, r.String()); werr != nil {
+			return werr
+		}
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("found %d of %d patterns within %s", len(results), o.Results, *timeout)
+	case err != nil:
+		return err
+	case len(results) < o.Results:
+		fmt.Fprintf(os.Stderr, "found %d of %d patterns: there are no more in a %dx%d box\n", len(results), o.Results, o.Width, o.Height)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/game"
+	"github.com/richardwooding/gameoflife/pkg/predecessor"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// runPredecessor searches for a pattern that becomes the colony in one generation.
+func runPredecessor(args []string) error {
+	fs := flag.NewFlagSet("predecessor", flag.ContinueOnError)
+	var source colonyFlags
+	source.register(fs)
+	o := predecessor.DefaultOptions()
+	fs.IntVar(&o.Margin, "margin", o.Margin, "cells the predecessor may extend beyond the target on each side, at most its larger side or 8")
+	fs.BoolVar(&o.FreeBorder, "free-border", o.FreeBorder, "only match the target rectangle, letting cells around it do anything")
+	timeout := fs.Duration("timeout", time.Minute, "give up after this long")
+	format := fs.String("format", "state", "output format: state or cells")
+	output := fs.String("o", "-", "output file, - for standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "state" && *format != "cells" {
+		return fmt.Errorf("invalid output format %q", *format)
+	}
+	target, err := source.colony()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	result, err := predecessor.Find(ctx, target, o)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("no answer within %s", *timeout)
+	}
+	if err != nil && !errors.Is(err, predecessor.NoPredecessor) {
+		return err
+	}
+
+	w, werr := create(*output)
+	if werr != nil {
+		return werr
+	}
+	defer w.Close()
+	if err != nil {
+		_, err = fmt.Fprintf(w, "no predecessor within %d cells of the target\n", o.Margin)
+		return err
+	}
+	if *format == "cells" {
+		return writeCells(w, result.Predecessor)
+	}
+	_, err = fmt.Fprintln(w, game.EncodeState(result.Predecessor))
+	return err
+}
+
+// writeCells writes the colony in plaintext format, with O for live cells and . for dead ones.
+func writeCells(w io.Writer, c *model.Colony) error {
+	for y := 0; y < c.Height(); y++ {
+		row := make([]byte, c.Width())
+		for x := range row {
+			row[x] = '.'
+			if c.IsAlive(x, y) {
+				row[x] = 'O'
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/search"
+	"os"
+	"os/signal"
+)
+
+// runSearch runs random soups and writes a census of the objects they leave behind.
+func runSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	o := search.DefaultOptions()
+	fs.IntVar(&o.Soups, "soups", o.Soups, "number of soups to run")
+	fs.Uint64Var(&o.Seed, "seed", o.Seed, "seed from which the soups are generated")
+	fs.IntVar(&o.Size, "size", o.Size, "width and height of each soup")
+	fs.Float64Var(&o.Density, "density", o.Density, "probability that a soup cell is alive")
+	symmetry := fs.String("symmetry", o.Symmetry.String(), "soup symmetry: C1, C2, C4, D2, D4 or D8")
+	fs.IntVar(&o.Padding, "padding", o.Padding, "dead cells around each soup")
+	fs.IntVar(&o.MaxGenerations, "max-generations", o.MaxGenerations, "generations before a soup is pathological")
+	fs.IntVar(&o.MaxPeriod, "max-period", o.MaxPeriod, "longest period looked for when classifying objects")
+	fs.IntVar(&o.Workers, "workers", o.Workers, "number of soups run in parallel")
+	fs.IntVar(&o.Samples, "samples", o.Samples, "sample soups kept for each object")
+	fs.IntVar(&o.Rare, "rare", o.Rare, "objects seen fewer times than this are reported with sample soups")
+	format := fs.String("format", "json", "report format: json or csv")
+	output := fs.String("o", "-", "output file, - for standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var err error
+	if o.Symmetry, err = model.ParseSymmetry(*symmetry); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("invalid report format %q", *format)
+	}
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	// An interrupted search still reports the soups that finished.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	census, err := search.Run(ctx, o)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	w, err := create(*output)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if *format == "csv" {
+		return census.WriteCSV(w)
+	}
+	return census.WriteJSON(w)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"image/png"
+	"io"
+)
+
+// runSnapshot writes a static PNG or SVG image of the colony.
+func runSnapshot(args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	var source colonyFlags
+	var drawing renderFlags
+	source.register(fs)
+	drawing.register(fs)
+	format := fs.String("format", "png", "image format: png or svg")
+	generations := fs.Int("generations", 0, "number of generations to run before the snapshot")
+	output := fs.String("o", "-", "output file, - for standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts, err := drawing.options()
+	if err != nil {
+		return err
+	}
+	colony, err := source.colony()
+	if err != nil {
+		return err
+	}
+	if *generations < 0 {
+		return fmt.Errorf("invalid number of generations %d", *generations)
+	}
+	colony.Track(opts.Mode.Tracked())
+	for i := 0; i < *generations; i++ {
+		colony.Generate()
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		switch *format {
+		case "png":
+			return png.Encode(w, render.Render(colony, opts))
+		case "svg":
+			return render.WriteSVG(w, colony, opts)
+		default:
+			return fmt.Errorf("invalid image format %q", *format)
+		}
+	})
+}
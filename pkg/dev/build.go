@@ -0,0 +1,23 @@
+package dev
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// BuildWasm builds the app for the browser from the module in the directory dir into the file wasm, returning
+// the compiler's output if the build fails.
+func BuildWasm(dir, wasm string) error {
+	cmd := exec.Command("go", "build", "-o", wasm, ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("building %s: %w\n%s", wasm, err, out.Bytes())
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Package dev serves the game while it is being worked on: it rebuilds app.wasm when the Go sources change,
+// serves everything without caching, logs every request, and reloads the browsers showing the game.
+package dev
+
+import (
+	"context"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"log"
+	"net/http"
+	"time"
+)
+
+// EventsPath is the path browsers listen for reloads on.
+const EventsPath = "/_dev/events"
+
+// Event names.
+const (
+	Reload     = "reload"      // The app or its assets changed
+	BuildError = "build-error" // app.wasm failed to build, with the compiler's output
+)
+
+// reloadScript listens for reloads, and shows build errors in the console.
+const reloadScript = `<script>
+(() => {
+  const events = new EventSource("` + EventsPath + `");
+  events.addEventListener("` + Reload + `", () => location.reload());
+  events.addEventListener("` + BuildError + `", (e) => console.error(e.data));
+})();
+</script>`
+
+// workerJS is the service worker for development. It caches nothing and clears what a deployed version cached,
+// so every request reaches the server.
+const workerJS = `self.addEventListener("install", () => self.skipWaiting());
+self.addEventListener("activate", (event) => {
+  event.waitUntil(
+    caches
+      .keys()
+      .then((keys) => Promise.all(keys.map((key) => caches.delete(key))))
+      .then(() => self.clients.claim())
+  );
+});
+`
+
+// Configure sets up the handler for development: pages reload when the server says so, and the service worker
+// caches nothing.
+func Configure(h *app.Handler) {
+	h.RawHeaders = append(h.RawHeaders, reloadScript)
+	h.ServiceWorkerTemplate = workerJS
+}
+
+// Options configures the development server.
+type Options struct {
+	Dir      string        // Directory of the module the app is built from
+	Wasm     string        // File app.wasm is built into
+	Interval time.Duration // How often the files are checked for changes
+	Log      *log.Logger
+	Build    func(dir, wasm string) error // Builds app.wasm
+}
+
+// DefaultOptions returns the options for the module in the working directory, checking for changes twice a
+// second.
+func DefaultOptions() Options {
+	return Options{
+		Dir:      ".",
+		Wasm:     "web/app.wasm",
+		Interval: 500 * time.Millisecond,
+		Log:      log.New(log.Writer(), "dev: ", log.LstdFlags|log.Lmicroseconds),
+		Build:    BuildWasm,
+	}
+}
+
+// Server rebuilds the app when its files change and reloads the browsers showing it.
+type Server struct {
+	Options
+	Events   *Broker
+	snapshot Snapshot
+}
+
+// NewServer returns a server with the options.
+func NewServer(o Options) *Server {
+	return &Server{Options: o, Events: NewBroker()}
+}
+
+// Handler serves the events browsers reload on, and everything else from h, uncached and logged.
+func (s *Server) Handler(h http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(EventsPath, s.Events)
+	mux.Handle("/", NoCache(h, s.Log))
+	return mux
+}
+
+// Rebuild builds app.wasm, reloading the browsers if it builds and showing them the errors if it doesn't.
+func (s *Server) Rebuild() error {
+	start := time.Now()
+	if err := s.Build(s.Dir, s.Wasm); err != nil {
+		s.Log.Print(err)
+		s.Events.Publish(Event{Name: BuildError, Data: err.Error()})
+		return err
+	}
+	s.Log.Printf("built %s in %s, reloading %d browsers", s.Wasm, time.Since(start).Round(time.Millisecond), s.Events.Clients())
+	s.Events.Publish(Event{Name: Reload})
+	return nil
+}
+
+// Check looks for changes since the last check: changed sources rebuild app.wasm, and changed assets reload
+// the browsers. It reports whether anything changed.
+func (s *Server) Check() (bool, error) {
+	next, err := TakeSnapshot(s.Dir, s.Wasm)
+	if err != nil {
+		return false, err
+	}
+	sources, assets := s.snapshot.Compare(next)
+	s.snapshot = next
+	switch {
+	case sources:
+		s.Log.Print("sources changed, rebuilding")
+		return true, s.Rebuild()
+	case assets:
+		s.Log.Printf("assets changed, reloading %d browsers", s.Events.Clients())
+		s.Events.Publish(Event{Name: Reload})
+		return true, nil
+	}
+	return false, nil
+}
+
+// Watch checks for changes at every interval until the context is done.
+func (s *Server) Watch(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Check(); err != nil {
+				s.Log.Print(err)
+			}
+		}
+	}
+}
+
+// ListenAndServe builds the app, then serves h on the address while watching for changes. The server only
+// rebuilds app.wasm: changes to what the server itself does need it restarted.
+func (s *Server) ListenAndServe(addr string, h http.Handler) error {
+	var err error
+	if s.snapshot, err = TakeSnapshot(s.Dir, s.Wasm); err != nil {
+		return err
+	}
+	// The server runs with a broken build, so fixing it is just another change.
+	s.Rebuild()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Watch(ctx)
+	s.Log.Printf("serving on %s, watching %s every %s", addr, s.Dir, s.Interval)
+	return http.ListenAndServe(addr, s.Handler(h))
+}
@@ -0,0 +1,240 @@
+package dev
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// eventTimeout is how long the browser waits for an event.
+const eventTimeout = 2 * time.Second
+
+type devFeature struct {
+	dir      string
+	server   *Server
+	http     *httptest.Server
+	logs     bytes.Buffer
+	builds   int
+	buildErr error
+	events   chan Event
+	changed  bool
+	response *http.Response
+}
+
+// write writes a file of the module, making it longer each time so the change shows however coarse the clock
+// of the file system.
+func (f *devFeature) write(name string) error {
+	path := filepath.Join(f.dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	old, _ := os.ReadFile(path)
+	return os.WriteFile(path, append(old, "// changed\n"...), 0644)
+}
+
+func (f *devFeature) aModuleWith(a, b, c string) error {
+	dir, err := os.MkdirTemp("", "module")
+	if err != nil {
+		return err
+	}
+	f.dir = dir
+	for _, name := range []string{a, b, c, "go.mod"} {
+		if err := f.write(name); err != nil {
+			return err
+		}
+	}
+	f.server = NewServer(Options{
+		Dir:      dir,
+		Wasm:     filepath.Join(dir, "web", "app.wasm"),
+		Interval: time.Hour,
+		Log:      log.New(&f.logs, "", 0),
+		Build: func(dir, wasm string) error {
+			f.builds++
+			return f.buildErr
+		},
+	})
+	// A page that honours ETags, as go-app's handler does.
+	page := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, "page")
+	})
+	f.http = httptest.NewServer(f.server.Handler(page))
+	return nil
+}
+
+func (f *devFeature) theDevelopmentServerHasTakenASnapshot() error {
+	var err error
+	f.server.snapshot, err = TakeSnapshot(f.server.Dir, f.server.Wasm)
+	return err
+}
+
+func (f *devFeature) aBrowserIsListeningForReloads() error {
+	res, err := http.Get(f.http.URL + EventsPath)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(res.Body)
+	// The browser is subscribed once the stream opens.
+	if line, err := r.ReadString('\n'); err != nil || !strings.HasPrefix(line, ":") {
+		return fmt.Errorf("expected the stream to open, got %q (%v)", line, err)
+	}
+	f.events = make(chan Event, 8)
+	go func() {
+		defer res.Body.Close()
+		var e Event
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				e.Name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				if e.Data != "" {
+					e.Data += "\n"
+				}
+				e.Data += strings.TrimPrefix(line, "data: ")
+			case line == "" && e.Name != "":
+				f.events <- e
+				e = Event{}
+			}
+		}
+	}()
+	return nil
+}
+
+func (f *devFeature) iChange(name string) error {
+	return f.write(name)
+}
+
+func (f *devFeature) theServerChecksForChanges() error {
+	changed, err := f.server.Check()
+	f.changed = changed
+	if err != nil && !errors.Is(err, f.buildErr) {
+		return err
+	}
+	return nil
+}
+
+func (f *devFeature) appwasmShouldHaveBeenBuiltTimes(n int) error {
+	if f.builds != n {
+		return fmt.Errorf("expected %d builds, got %d", n, f.builds)
+	}
+	return nil
+}
+
+func (f *devFeature) nothingShouldHaveChanged() error {
+	if f.changed {
+		return fmt.Errorf("expected no change")
+	}
+	return nil
+}
+
+func (f *devFeature) theBrowserShouldReceiveAnEvent(name string) error {
+	return f.theBrowserShouldReceiveAnEventWith(name, "")
+}
+
+func (f *devFeature) theBrowserShouldReceiveAnEventWith(name, data string) error {
+	select {
+	case e := <-f.events:
+		if e.Name != name || !strings.Contains(e.Data, data) {
+			return fmt.Errorf("expected a %q event with %q, got %q with %q", name, data, e.Name, e.Data)
+		}
+		return nil
+	case <-time.After(eventTimeout):
+		return fmt.Errorf("expected a %q event, got none", name)
+	}
+}
+
+func (f *devFeature) theBuildFailsWith(output string) error {
+	f.buildErr = errors.New(output)
+	return nil
+}
+
+func (f *devFeature) theBrowserAsksForAgainWithItsETag(path string) error {
+	req, err := http.NewRequest(http.MethodGet, f.http.URL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("If-None-Match", `"v1"`)
+	f.response, err = http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return f.response.Body.Close()
+}
+
+func (f *devFeature) theResponseShouldBeWithCacheControlAndNoETag(status int, cacheControl string) error {
+	h := f.response.Header
+	if f.response.StatusCode != status || h.Get("Cache-Control") != cacheControl || h.Get("ETag") != "" {
+		return fmt.Errorf("expected %d with Cache-Control %q and no ETag, got %d with %q and ETag %q",
+			status, cacheControl, f.response.StatusCode, h.Get("Cache-Control"), h.Get("ETag"))
+	}
+	return nil
+}
+
+func (f *devFeature) theRequestShouldBeLoggedAs(entry string) error {
+	if !strings.Contains(f.logs.String(), entry) {
+		return fmt.Errorf("expected %q in the log:\n%s", entry, f.logs.String())
+	}
+	return nil
+}
+
+func InitializeDevScenario(ctx *godog.ScenarioContext) {
+	f := &devFeature{}
+	ctx.After(func(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
+		if f.http != nil {
+			f.http.CloseClientConnections()
+			f.http.Close()
+		}
+		if f.dir != "" {
+			os.RemoveAll(f.dir)
+		}
+		return ctx, nil
+	})
+	ctx.Step(`^a module with "([^"]*)", "([^"]*)" and "([^"]*)"$`, f.aModuleWith)
+	ctx.Step(`^the development server has taken a snapshot$`, f.theDevelopmentServerHasTakenASnapshot)
+	ctx.Step(`^a browser is listening for reloads$`, f.aBrowserIsListeningForReloads)
+	ctx.Step(`^I change "([^"]*)"$`, f.iChange)
+	ctx.Step(`^the server checks for changes$`, f.theServerChecksForChanges)
+	ctx.Step(`^app\.wasm should have been built (\d+) times?$`, f.appwasmShouldHaveBeenBuiltTimes)
+	ctx.Step(`^nothing should have changed$`, f.nothingShouldHaveChanged)
+	ctx.Step(`^the browser should receive a "([^"]*)" event$`, f.theBrowserShouldReceiveAnEvent)
+	ctx.Step(`^the browser should receive a "([^"]*)" event with "([^"]*)"$`, f.theBrowserShouldReceiveAnEventWith)
+	ctx.Step(`^the build fails with "([^"]*)"$`, f.theBuildFailsWith)
+	ctx.Step(`^the browser asks for "([^"]*)" again with its ETag$`, f.theBrowserAsksForAgainWithItsETag)
+	ctx.Step(`^the response should be (\d+) with Cache-Control "([^"]*)" and no ETag$`, f.theResponseShouldBeWithCacheControlAndNoETag)
+	ctx.Step(`^the request should be logged as "([^"]*)"$`, f.theRequestShouldBeLoggedAs)
+}
+
+func TestDevFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "dev",
+		ScenarioInitializer: InitializeDevScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
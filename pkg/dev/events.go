@@ -0,0 +1,89 @@
+package dev
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Event is a server-sent event pushed to the browsers.
+type Event struct {
+	Name string
+	Data string
+}
+
+// Broker pushes events to the browsers listening to it as server-sent events.
+type Broker struct {
+	mu      sync.Mutex
+	clients map[chan Event]bool
+}
+
+// NewBroker returns a broker with no browsers listening.
+func NewBroker() *Broker {
+	return &Broker{clients: map[chan Event]bool{}}
+}
+
+// Clients returns the number of browsers listening.
+func (b *Broker) Clients() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.clients)
+}
+
+// Publish sends an event to every browser listening. Browsers that haven't taken the last event yet miss it.
+func (b *Broker) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.clients {
+		select {
+		case c <- e:
+		default:
+		}
+	}
+}
+
+// subscribe starts sending events to a new browser.
+func (b *Broker) subscribe() chan Event {
+	c := make(chan Event, 1)
+	b.mu.Lock()
+	b.clients[c] = true
+	b.mu.Unlock()
+	return c
+}
+
+// unsubscribe stops sending events to a browser.
+func (b *Broker) unsubscribe(c chan Event) {
+	b.mu.Lock()
+	delete(b.clients, c)
+	b.mu.Unlock()
+}
+
+// ServeHTTP streams the events to a browser until it goes away.
+func (b *Broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	c := b.subscribe()
+	defer b.unsubscribe(c)
+	// A comment opens the stream, so the browser knows it is connected before the first event.
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-c:
+			fmt.Fprintf(w, "event: %s\n", e.Name)
+			for _, line := range strings.Split(e.Data, "\n") {
+				fmt.Fprintf(w, "data: %s\n", line)
+			}
+			fmt.Fprint(w, "\n")
+			flusher.Flush()
+		}
+	}
+}
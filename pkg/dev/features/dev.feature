@@ -0,0 +1,48 @@
+Feature: Development server
+
+  Background:
+    Given a module with "main.go", "main_test.go" and "web/app.css"
+    And the development server has taken a snapshot
+    And a browser is listening for reloads
+
+  Scenario: Changing a Go source rebuilds app.wasm and reloads the browser
+    When I change "main.go"
+    And the server checks for changes
+    Then app.wasm should have been built 1 time
+    And the browser should receive a "reload" event
+
+  Scenario: Adding a Go source rebuilds app.wasm
+    When I change "pkg/new.go"
+    And the server checks for changes
+    Then app.wasm should have been built 1 time
+
+  Scenario: Changing an asset reloads the browser without building
+    When I change "web/app.css"
+    And the server checks for changes
+    Then app.wasm should have been built 0 times
+    And the browser should receive a "reload" event
+
+  Scenario Outline: Files that aren't part of the app change nothing
+    When I change "<file>"
+    And the server checks for changes
+    Then nothing should have changed
+    And app.wasm should have been built 0 times
+
+    Examples:
+      | file              |
+      | main_test.go      |
+      | README.md         |
+      | web/app.wasm      |
+      | dist/index.html   |
+      | .git/HEAD         |
+
+  Scenario: A broken build shows its errors in the browser
+    Given the build fails with "main.go:1: syntax error"
+    When I change "main.go"
+    And the server checks for changes
+    Then the browser should receive a "build-error" event with "main.go:1: syntax error"
+
+  Scenario: Responses are never cached
+    When the browser asks for "/page" again with its ETag
+    Then the response should be 200 with Cache-Control "no-store" and no ETag
+    And the request should be logged as "GET /page 200"
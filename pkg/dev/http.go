@@ -0,0 +1,58 @@
+package dev
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// recorder remembers the status and size of a response for the request log, and keeps the browser from caching
+// it or revalidating it against an ETag.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status != 0 {
+		return
+	}
+	r.status = status
+	h := r.Header()
+	h.Set("Cache-Control", "no-store")
+	h.Del("ETag")
+	h.Del("Last-Modified")
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	n, err := r.ResponseWriter.Write(b)
+	r.size += n
+	return n, err
+}
+
+// Flush sends what has been written so far, for the stream of events.
+func (r *recorder) Flush() {
+	r.WriteHeader(http.StatusOK)
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// NoCache serves every request afresh, as if the browser had nothing cached, and logs it with its status, size
+// and duration.
+func NoCache(h http.Handler, l *log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		r.Header.Del("If-None-Match")
+		r.Header.Del("If-Modified-Since")
+		rec := &recorder{ResponseWriter: w}
+		h.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.WriteHeader(http.StatusOK)
+		}
+		l.Printf("%s %s %d %dB %s %q", r.Method, r.URL.RequestURI(), rec.status, rec.size, time.Since(start).Round(time.Microsecond), r.UserAgent())
+	})
+}
@@ -0,0 +1,95 @@
+package dev
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// skippedDirs are directories whose files never change the app: version control, the static website, and
+// the web directory, whose assets are watched on their own.
+var skippedDirs = map[string]bool{".git": true, "dist": true, "web": true}
+
+// stamp is what a change to a file changes.
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Snapshot is the state of the files of the module the app is built from, by path.
+type Snapshot struct {
+	sources map[string]stamp // Go sources, go.mod and go.sum, and the rule tables the model embeds
+	assets  map[string]stamp // Files under web other than app.wasm itself
+}
+
+// source reports whether a file is part of the build of app.wasm.
+func source(name string) bool {
+	switch filepath.Ext(name) {
+	case ".go", ".rule":
+		return true
+	}
+	return name == "go.mod" || name == "go.sum"
+}
+
+// TakeSnapshot records the files of the module in the directory dir, and the assets in its web directory.
+func TakeSnapshot(dir, wasm string) (Snapshot, error) {
+	s := Snapshot{sources: map[string]stamp{}, assets: map[string]stamp{}}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && (skippedDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !source(d.Name()) || strings.HasSuffix(d.Name(), "_test.go") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		s.sources[path] = stamp{info.ModTime(), info.Size()}
+		return nil
+	})
+	if err != nil {
+		return s, err
+	}
+	err = filepath.WalkDir(filepath.Join(dir, "web"), func(path string, d fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return filepath.SkipDir
+		}
+		if err != nil || d.IsDir() || filepath.Clean(path) == filepath.Clean(wasm) {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		s.assets[path] = stamp{info.ModTime(), info.Size()}
+		return nil
+	})
+	return s, err
+}
+
+// changed reports whether two sets of files differ: a file was added, removed or changed.
+func changed(a, b map[string]stamp) bool {
+	if len(a) != len(b) {
+		return true
+	}
+	for path, s := range a {
+		if t, ok := b[path]; !ok || !t.modTime.Equal(s.modTime) || t.size != s.size {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare reports whether the sources of app.wasm or the other assets changed between two snapshots.
+func (s Snapshot) Compare(next Snapshot) (sources, assets bool) {
+	return changed(s.sources, next.sources), changed(s.assets, next.assets)
+}
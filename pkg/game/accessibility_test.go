@@ -0,0 +1,164 @@
+package game
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"strings"
+	"testing"
+	"time"
+)
+
+type accessibilityFeature struct {
+	x, y, w, h   int
+	moved        bool
+	colony       *model.Colony
+	monitor      *Monitor
+	announcement string
+	announced    bool
+	running      bool
+}
+
+func (f *accessibilityFeature) theFocusIsOnOfABoard(x, y, w, h int) error {
+	f.x, f.y, f.w, f.h = x, y, w, h
+	return nil
+}
+
+func (f *accessibilityFeature) iPress(key string) error {
+	key, ctrl := strings.CutPrefix(key, "Ctrl+")
+	f.x, f.y, f.moved = NavigateGrid(key, ctrl, f.x, f.y, f.w, f.h)
+	return nil
+}
+
+func (f *accessibilityFeature) theFocusShouldBeOn(x, y int) error {
+	if !f.moved {
+		return fmt.Errorf("expected the key to move the focus")
+	}
+	if f.x != x || f.y != y {
+		return fmt.Errorf("expected the focus on (%d,%d), got (%d,%d)", x, y, f.x, f.y)
+	}
+	return nil
+}
+
+func (f *accessibilityFeature) theKeyShouldNotMoveTheFocus() error {
+	if f.moved {
+		return fmt.Errorf("expected the key not to move the focus")
+	}
+	return nil
+}
+
+func (f *accessibilityFeature) theSimulationIs(state string) error {
+	f.running = state == "running"
+	return nil
+}
+
+func (f *accessibilityFeature) theFocusedCellShouldTake(take, key string) error {
+	if key == "Space" {
+		key = " "
+	}
+	if got := GridTakesKey(key, f.running); got != (take == "take") {
+		return fmt.Errorf("expected the focused cell to %s %q", take, key)
+	}
+	return nil
+}
+
+func (f *accessibilityFeature) aColonyUnderTheRule(w, h int, s string) error {
+	rule, err := model.ParseRule(s)
+	if err != nil {
+		return err
+	}
+	f.colony = model.NewColony(w, h)
+	f.colony.SetRule(rule)
+	return nil
+}
+
+func (f *accessibilityFeature) theCellAtIsInState(x, y, state int) error {
+	f.colony.SetState(x, y, uint8(state))
+	return nil
+}
+
+func (f *accessibilityFeature) theLabelOfShouldBe(x, y int, label string) error {
+	if got := CellLabel(i18n.Localizer{}, f.colony, x, y); got != label {
+		return fmt.Errorf("expected %q, got %q", label, got)
+	}
+	return nil
+}
+
+func (f *accessibilityFeature) aColonyWithAGlider(w, h int) error {
+	return f.aColonyWithCellsAt(w, h, "(1,0) (2,1) (0,2) (1,2) (2,2)")
+}
+
+func (f *accessibilityFeature) aColonyWithCellsAt(w, h int, s string) error {
+	f.colony = model.NewColony(w, h)
+	for _, p := range cells(s) {
+		f.colony.SetState(p[0], p[1], 1)
+	}
+	return nil
+}
+
+func (f *accessibilityFeature) aMonitorAnnouncingEvery(seconds int) error {
+	f.monitor = NewMonitor()
+	f.monitor.Interval = time.Duration(seconds) * time.Second
+	return nil
+}
+
+func (f *accessibilityFeature) aGenerationPassesAt(seconds int) error {
+	f.colony.Generate()
+	f.announcement, f.announced = f.monitor.Observe(f.colony, time.Unix(int64(seconds), 0))
+	return nil
+}
+
+func (f *accessibilityFeature) theCellAtIsToggled(x, y int) error {
+	f.colony.Toggle(x, y)
+	return nil
+}
+
+func (f *accessibilityFeature) shouldBeAnnounced(message string) error {
+	if !f.announced || f.announcement != message {
+		return fmt.Errorf("expected %q to be announced, got %q", message, f.announcement)
+	}
+	return nil
+}
+
+func (f *accessibilityFeature) nothingShouldBeAnnounced() error {
+	if f.announced {
+		return fmt.Errorf("expected nothing to be announced, got %q", f.announcement)
+	}
+	return nil
+}
+
+func InitializeAccessibilityScenario(ctx *godog.ScenarioContext) {
+	f := &accessibilityFeature{}
+	ctx.Step(`^the focus is on \((\d+),(\d+)\) of a (\d+)x(\d+) board$`, f.theFocusIsOnOfABoard)
+	ctx.Step(`^I press "([^"]*)"$`, f.iPress)
+	ctx.Step(`^the focus should be on \((\d+),(\d+)\)$`, f.theFocusShouldBeOn)
+	ctx.Step(`^the key should not move the focus$`, f.theKeyShouldNotMoveTheFocus)
+	ctx.Step(`^the simulation is (paused|running)$`, f.theSimulationIs)
+	ctx.Step(`^the focused cell should (take|not take) "([^"]*)"$`, f.theFocusedCellShouldTake)
+	ctx.Step(`^a (\d+)x(\d+) colony under the rule "([^"]*)"$`, f.aColonyUnderTheRule)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) is in state (\d+)$`, f.theCellAtIsInState)
+	ctx.Step(`^the label of \((\d+),(\d+)\) should be "([^"]*)"$`, f.theLabelOfShouldBe)
+	ctx.Step(`^a (\d+)x(\d+) colony with a glider$`, f.aColonyWithAGlider)
+	ctx.Step(`^a (\d+)x(\d+) colony with cells at (.*)$`, f.aColonyWithCellsAt)
+	ctx.Step(`^a monitor announcing every (\d+) seconds$`, f.aMonitorAnnouncingEvery)
+	ctx.Step(`^a generation passes at (\d+) seconds$`, f.aGenerationPassesAt)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) is toggled$`, f.theCellAtIsToggled)
+	ctx.Step(`^"([^"]*)" should be announced$`, f.shouldBeAnnounced)
+	ctx.Step(`^nothing should be announced$`, f.nothingShouldBeAnnounced)
+}
+
+func TestAccessibilityFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "accessibility",
+		ScenarioInitializer: InitializeAccessibilityScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/accessibility.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"hash/fnv"
+	"time"
+)
+
+const (
+	announceInterval = 2 * time.Second // Least time between reports of the generation and population
+	maxPeriod        = 60              // Longest oscillation the monitor recognises as settling down
+)
+
+// Monitor follows a running colony and decides what the live region announces: the generation and population
+// at most once per interval, and straight away when the colony dies out or settles into a still life or an
+// oscillation.
+type Monitor struct {
+	Interval  time.Duration
+	Localizer i18n.Localizer // Language of the announcements
+	last      time.Time      // When the generation and population were last reported
+	history   []uint64       // Hashes of the latest generations, oldest first
+	settled   bool           // Whether the colony has been announced as dead or settled and hasn't changed since
+}
+
+// NewMonitor returns a monitor reporting at most once per announceInterval.
+func NewMonitor() *Monitor {
+	return &Monitor{Interval: announceInterval}
+}
+
+// hashCells returns a hash of the states of the cells of the colony.
+func hashCells(c *model.Colony) uint64 {
+	h := fnv.New64a()
+	for _, row := range c.StateGrid() {
+		h.Write(row)
+	}
+	return h.Sum64()
+}
+
+// population returns the number of live cells in the colony.
+func population(c *model.Colony) int {
+	n := 0
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			if c.IsAlive(x, y) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Observe records a generation of the colony at the time now, and returns the announcement to make for it, if any.
+func (m *Monitor) Observe(c *model.Colony, now time.Time) (string, bool) {
+	hash, pop := hashCells(c), population(c)
+	period := 0
+	for i := len(m.history) - 1; i >= 0; i-- {
+		if m.history[i] == hash {
+			period = len(m.history) - i
+			break
+		}
+	}
+	m.history = append(m.history, hash)
+	if len(m.history) > maxPeriod {
+		m.history = m.history[1:]
+	}
+	generation := c.GetGeneration()
+	switch {
+	case period == 0 && pop > 0:
+		m.settled = false
+	case m.settled:
+		return "", false
+	case pop == 0:
+		m.settled = true
+		return m.Localizer.T("died", generation), true
+	case period == 1:
+		m.settled = true
+		return m.Localizer.N("still-life", pop, generation, pop), true
+	default:
+		m.settled = true
+		return m.Localizer.T("oscillation", generation, period), true
+	}
+	if now.Sub(m.last) < m.Interval {
+		return "", false
+	}
+	m.last = now
+	return m.Localizer.T("report", generation, pop), true
+}
+
+// announce puts a message in the live region for screen readers to read out.
+func (g *Game) announce(message string) {
+	g.announcement = message
+}
+
+// observe passes a new generation to the monitor and announces what it reports.
+func (g *Game) observe() {
+	if g.monitor == nil {
+		g.monitor = NewMonitor()
+	}
+	g.monitor.Localizer = g.localizer
+	if message, ok := g.monitor.Observe(g.colony, time.Now()); ok {
+		g.announce(message)
+	}
+}
+
+// renderLiveRegion renders the visually hidden region screen readers announce changes to the game from.
+func (g *Game) renderLiveRegion() app.UI {
+	return app.Div().Class("visually-hidden").Role("status").Aria("live", "polite").Text(g.announcement)
+}
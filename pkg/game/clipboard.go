@@ -0,0 +1,166 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"github.com/enescakir/emoji"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/rle"
+)
+
+var NoClipboard = errors.New("the browser doesn't allow access to the clipboard")
+
+// clipboard returns the browser's asynchronous clipboard, which is only available to secure pages.
+func clipboard() (app.Value, error) {
+	c := app.Window().Get("navigator").Get("clipboard")
+	if !c.Truthy() {
+		return nil, NoClipboard
+	}
+	return c, nil
+}
+
+// writeClipboard puts text on the system clipboard.
+func writeClipboard(text string) error {
+	c, err := clipboard()
+	if err != nil {
+		return err
+	}
+	c.Call("writeText", text)
+	return nil
+}
+
+// readClipboard reads the text on the system clipboard and passes it, or the reason it couldn't be read, to read.
+func readClipboard(ctx app.Context, read func(ctx app.Context, text string, err error)) {
+	c, err := clipboard()
+	if err != nil {
+		read(ctx, "", err)
+		return
+	}
+	var resolved, rejected app.Func
+	release := func() {
+		resolved.Release()
+		rejected.Release()
+	}
+	resolved = app.FuncOf(func(this app.Value, args []app.Value) any {
+		defer release()
+		text := args[0].String()
+		ctx.Dispatch(func(ctx app.Context) { read(ctx, text, nil) })
+		return nil
+	})
+	rejected = app.FuncOf(func(this app.Value, args []app.Value) any {
+		defer release()
+		err := fmt.Errorf("reading the clipboard: %s", args[0].Call("toString").String())
+		ctx.Dispatch(func(ctx app.Context) { read(ctx, "", err) })
+		return nil
+	})
+	c.Call("readText").Call("then", resolved, rejected)
+}
+
+// copySelection keeps the selected cells in the paste buffer and puts them on the system clipboard as RLE.
+func (g *Game) copySelection() {
+	p := g.selection.Copy(g.colony)
+	g.buffer = &p
+	g.clipboardError = ""
+	if err := writeClipboard(rle.String(g.selection.Region(g.colony))); err != nil {
+		g.clipboardError = err.Error()
+	}
+}
+
+// cutSelection copies the selected cells and clears them.
+func (g *Game) cutSelection(ctx app.Context) {
+	g.copySelection()
+	g.selection.Clear(g.colony)
+	g.saveState(ctx)
+}
+
+// paste stamps the pattern on the system clipboard, in RLE or plaintext, at the top left corner of the selection.
+// If the clipboard can't be read or doesn't hold a pattern, the paste buffer is used instead.
+func (g *Game) paste(ctx app.Context) {
+	readClipboard(ctx, func(ctx app.Context, text string, err error) {
+		if err == nil {
+			var c *model.Colony
+			if c, err = rle.Parse(text); err == nil {
+				p := NewPattern("Clipboard", *c.Cells())
+				g.buffer = &p
+			}
+		}
+		if g.buffer == nil {
+			g.clipboardError = err.Error()
+			return
+		}
+		g.clipboardError = ""
+		g.selection = Paste(g.colony, *g.buffer, g.selection.X, g.selection.Y)
+		g.saveState(ctx)
+	})
+}
+
+// transformSelection rotates or reflects the selected cells in place.
+func (g *Game) transformSelection(ctx app.Context, transform func(*model.Colony) *model.Colony) {
+	g.selection = g.selection.Transform(g.colony, transform)
+	g.saveState(ctx)
+}
+
+// randomFillSelection fills the selection with a soup from a new seed, using the other random soup options.
+func (g *Game) randomFillSelection(ctx app.Context) {
+	g.random.X, g.random.Y = g.selection.X, g.selection.Y
+	g.random.Width, g.random.Height = g.selection.Width, g.selection.Height
+	g.insertRandom(ctx)
+}
+
+// renderSelection renders the buttons that act on the selection and the paste buffer.
+func (g *Game) renderSelection() app.UI {
+	l := g.localizer
+	selected := !g.selection.Empty()
+	action := func(label string, enabled bool, do func(ctx app.Context)) app.UI {
+		return app.Button().Text(label).Disabled(!enabled).OnClick(func(ctx app.Context, e app.Event) {
+			if g.ticker == nil {
+				do(ctx)
+			}
+		})
+	}
+	return app.Div().Body(
+		action(fmt.Sprintf("%s %s", emoji.Clipboard, l.T("copy")), selected, func(ctx app.Context) { g.copySelection() }),
+		action(fmt.Sprintf("%s %s", emoji.Scissors, l.T("cut")), selected, g.cutSelection),
+		action(fmt.Sprintf("%s %s", emoji.Clipboard, l.T("paste")), true, g.paste),
+		action(fmt.Sprintf("%s %s", emoji.CounterclockwiseArrowsButton, l.T("rotate")), selected, func(ctx app.Context) {
+			g.transformSelection(ctx, (*model.Colony).Rotated)
+		}),
+		action(fmt.Sprintf("%s %s", emoji.LeftRightArrow, l.T("flip")), selected, func(ctx app.Context) {
+			g.transformSelection(ctx, (*model.Colony).Mirrored)
+		}),
+		action(fmt.Sprintf("%s %s", emoji.UpDownArrow, l.T("flip")), selected, func(ctx app.Context) {
+			g.transformSelection(ctx, (*model.Colony).Flipped)
+		}),
+		action(l.T("clear-inside"), selected, func(ctx app.Context) {
+			g.selection.Clear(g.colony)
+			g.saveState(ctx)
+		}),
+		action(l.T("clear-outside"), selected, func(ctx app.Context) {
+			g.selection.ClearOutside(g.colony)
+			g.saveState(ctx)
+		}),
+		action(fmt.Sprintf("%s %s", emoji.GameDie, l.T("random-fill")), selected, g.randomFillSelection),
+		action(l.T("select-none"), selected, func(ctx app.Context) { g.selection = Selection{} }),
+		app.If(g.clipboardError != "", func() app.UI {
+			return app.Span().Class("error").Text(g.clipboardError)
+		}),
+	)
+}
+
+// renderSelectionOutline outlines the selection, or the rectangle being dragged out, over the grid.
+func (g *Game) renderSelectionOutline() app.UI {
+	s := g.selection
+	if g.stroke != nil && g.stroke.tool == Select {
+		s = g.stroke.Selection()
+	}
+	if s.Empty() {
+		return nil
+	}
+	return app.Div().
+		Class("selection").
+		Style("left", fmt.Sprintf("%dpx", s.X*cellPitch-cellGap)).
+		Style("top", fmt.Sprintf("%dpx", s.Y*cellPitch-cellGap)).
+		Style("width", fmt.Sprintf("%dpx", s.Width*cellPitch+cellGap)).
+		Style("height", fmt.Sprintf("%dpx", s.Height*cellPitch+cellGap))
+}
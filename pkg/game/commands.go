@@ -0,0 +1,202 @@
+package game
+
+import (
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"github.com/richardwooding/gameoflife/pkg/theme"
+	"slices"
+	"strings"
+)
+
+// Command is an action of the game that can be run from the command palette, and from the keyboard if it has keys.
+type Command struct {
+	Name  string
+	Keys  []string // Values of KeyboardEvent.key that run the command
+	Edits bool     // Whether the command changes the colony, which is only allowed while it is paused
+	Run   func(ctx app.Context)
+}
+
+// commands returns every command of the game, those with keyboard shortcuts first.
+func (g *Game) commands() []Command {
+	selected := func(run func(ctx app.Context)) func(ctx app.Context) {
+		return func(ctx app.Context) {
+			if !g.selection.Empty() {
+				run(ctx)
+			}
+		}
+	}
+	pan := func(dx, dy int) func(ctx app.Context) {
+		return func(ctx app.Context) {
+			if g.colony.Shift(dx, dy) {
+				g.saveState(ctx)
+			}
+		}
+	}
+	l := g.localizer
+	commands := []Command{
+		{Name: l.T("command-play-pause"), Keys: []string{" "}, Run: func(ctx app.Context) {
+			if g.ticker == nil {
+				g.startTicking(ctx)
+			} else {
+				g.stopTicking(ctx)
+			}
+		}},
+		{Name: l.T("command-step"), Keys: []string{"n"}, Edits: true, Run: func(ctx app.Context) {
+			g.Generate(ctx)
+			g.saveState(ctx)
+		}},
+		{Name: l.T("clear"), Keys: []string{"c"}, Edits: true, Run: g.clearColony},
+		{Name: l.T("random-soup"), Keys: []string{"r"}, Edits: true, Run: g.insertRandom},
+		{Name: l.T("undo"), Keys: []string{"z"}, Edits: true, Run: g.undo},
+		{Name: l.T("redo"), Keys: []string{"y"}, Edits: true, Run: g.redo},
+		{Name: l.T("command-faster"), Keys: []string{"+", "="}, Run: func(ctx app.Context) {
+			g.setSpeed(ctx, g.tickInterval.Milliseconds()-10)
+		}},
+		{Name: l.T("command-slower"), Keys: []string{"-", "_"}, Run: func(ctx app.Context) {
+			g.setSpeed(ctx, g.tickInterval.Milliseconds()+10)
+		}},
+		{Name: l.T("command-move-left"), Keys: []string{"ArrowLeft"}, Edits: true, Run: pan(-1, 0)},
+		{Name: l.T("command-move-right"), Keys: []string{"ArrowRight"}, Edits: true, Run: pan(1, 0)},
+		{Name: l.T("command-move-up"), Keys: []string{"ArrowUp"}, Edits: true, Run: pan(0, -1)},
+		{Name: l.T("command-move-down"), Keys: []string{"ArrowDown"}, Edits: true, Run: pan(0, 1)},
+		{Name: l.T("command-shortcuts"), Keys: []string{"?"}, Run: func(ctx app.Context) {
+			g.help = !g.help
+		}},
+		{Name: l.T("step-back"), Edits: true, Run: func(ctx app.Context) {
+			if g.reversible() {
+				g.stepBack(ctx)
+			}
+		}},
+		{Name: l.T("command-centre"), Edits: true, Run: g.centerAlive},
+		{Name: l.T("command-analyse"), Edits: true, Run: func(ctx app.Context) {
+			if g.analysed {
+				g.clearAnalysis()
+			} else if g.analysable() {
+				g.analyse()
+			}
+		}},
+		{Name: l.T("command-copy"), Run: selected(func(ctx app.Context) { g.copySelection() })},
+		{Name: l.T("command-cut"), Edits: true, Run: selected(g.cutSelection)},
+		{Name: l.T("paste"), Edits: true, Run: g.paste},
+		{Name: l.T("command-rotate"), Edits: true, Run: selected(func(ctx app.Context) {
+			g.transformSelection(ctx, (*model.Colony).Rotated)
+		})},
+		{Name: l.T("command-flip-horizontal"), Edits: true, Run: selected(func(ctx app.Context) {
+			g.transformSelection(ctx, (*model.Colony).Mirrored)
+		})},
+		{Name: l.T("command-flip-vertical"), Edits: true, Run: selected(func(ctx app.Context) {
+			g.transformSelection(ctx, (*model.Colony).Flipped)
+		})},
+		{Name: l.T("command-clear-inside"), Edits: true, Run: selected(func(ctx app.Context) {
+			g.selection.Clear(g.colony)
+			g.saveState(ctx)
+		})},
+		{Name: l.T("command-clear-outside"), Edits: true, Run: selected(func(ctx app.Context) {
+			g.selection.ClearOutside(g.colony)
+			g.saveState(ctx)
+		})},
+		{Name: l.T("command-random-fill"), Edits: true, Run: selected(g.randomFillSelection)},
+		{Name: l.T("download-image"), Run: func(ctx app.Context) { g.exportImage() }},
+		{Name: l.T("download-svg"), Run: func(ctx app.Context) { g.exportSVG() }},
+		{Name: l.T("download-gif"), Edits: true, Run: func(ctx app.Context) { g.exportAnimation(render.GIF) }},
+		{Name: l.T("download-apng"), Edits: true, Run: func(ctx app.Context) { g.exportAnimation(render.APNG) }},
+	}
+	for _, tool := range Tools {
+		commands = append(commands, Command{Name: l.T("command-tool", toolLabel(l, tool)), Run: func(ctx app.Context) {
+			g.tool = tool
+		}})
+	}
+	for _, m := range render.Modes {
+		commands = append(commands, Command{Name: l.T("command-display", displayLabel(l, m)), Run: func(ctx app.Context) {
+			g.setDisplay(m)
+		}})
+	}
+	for _, t := range theme.Themes {
+		commands = append(commands, Command{Name: l.T("command-theme", l.T(themeMessage(t.Name))), Run: func(ctx app.Context) {
+			g.setTheme(ctx, t.Name)
+		}})
+	}
+	for i := range Patterns {
+		p := &Patterns[i]
+		commands = append(commands, Command{Name: l.T("stamp", p.GetName()), Edits: true, Run: func(ctx app.Context) {
+			p.Stamp(g.colony.Cells(), 2, 2)
+			g.saveState(ctx)
+		}})
+	}
+	for _, preset := range rulePresets {
+		commands = append(commands, Command{Name: l.T("command-rule", preset.name, preset.rule), Edits: true, Run: func(ctx app.Context) {
+			g.setRule(ctx, preset.rule)
+		}})
+	}
+	return commands
+}
+
+// run runs a command, unless it would change the colony while it is running.
+func (g *Game) run(ctx app.Context, c Command) {
+	if c.Edits && g.ticker != nil {
+		return
+	}
+	c.Run(ctx)
+}
+
+// CommandForKey returns the command the key runs, if any.
+func CommandForKey(commands []Command, key string) (Command, bool) {
+	for _, c := range commands {
+		if slices.Contains(c.Keys, key) {
+			return c, true
+		}
+	}
+	return Command{}, false
+}
+
+// FilterCommands returns the commands whose names contain every word of the query, ignoring case. Commands
+// where the first word starts a word of the name come first, otherwise they keep their order.
+func FilterCommands(commands []Command, query string) []Command {
+	words := strings.Fields(strings.ToLower(query))
+	var starts, contains []Command
+	for _, c := range commands {
+		name := strings.ToLower(c.Name)
+		matches := true
+		for _, w := range words {
+			matches = matches && strings.Contains(name, w)
+		}
+		switch {
+		case !matches:
+		case len(words) > 0 && startsWord(name, words[0]):
+			starts = append(starts, c)
+		default:
+			contains = append(contains, c)
+		}
+	}
+	return append(starts, contains...)
+}
+
+// startsWord reports whether a word of the name starts with the prefix.
+func startsWord(name, prefix string) bool {
+	for _, w := range strings.FieldsFunc(name, func(r rune) bool { return r == ' ' || r == '(' || r == ':' }) {
+		if strings.HasPrefix(w, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyLabel returns how a key is shown in the help.
+func keyLabel(l i18n.Localizer, key string) string {
+	switch key {
+	case " ":
+		return l.T("key-space")
+	case "ArrowLeft":
+		return "←"
+	case "ArrowRight":
+		return "→"
+	case "ArrowUp":
+		return "↑"
+	case "ArrowDown":
+		return "↓"
+	default:
+		return strings.ToUpper(key)
+	}
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"testing"
+)
+
+type commandsFeature struct {
+	commands []Command
+	found    []Command
+}
+
+func (f *commandsFeature) theCommandsOfTheGame() error {
+	f.commands = (&Game{}).commands()
+	return nil
+}
+
+func (f *commandsFeature) theCommandsOfTheGameIn(locale string) error {
+	c, ok := i18n.Lookup(locale)
+	if !ok {
+		return fmt.Errorf("no catalogue for %q", locale)
+	}
+	f.commands = (&Game{localizer: i18n.For(c)}).commands()
+	return nil
+}
+
+func (f *commandsFeature) theKeyShouldRun(key, name string) error {
+	if key == "Space" {
+		key = " "
+	}
+	c, ok := CommandForKey(f.commands, key)
+	if !ok {
+		return fmt.Errorf("no command for %q", key)
+	}
+	if c.Name != name {
+		return fmt.Errorf("expected %q to run %q, got %q", key, name, c.Name)
+	}
+	return nil
+}
+
+func (f *commandsFeature) noTwoCommandsShouldShareANameOrAKey() error {
+	names, keys := make(map[string]bool), make(map[string]string)
+	for _, c := range f.commands {
+		if names[c.Name] {
+			return fmt.Errorf("two commands are named %q", c.Name)
+		}
+		names[c.Name] = true
+		for _, k := range c.Keys {
+			if other, ok := keys[k]; ok {
+				return fmt.Errorf("%q and %q share the key %q", other, c.Name, k)
+			}
+			keys[k] = c.Name
+		}
+	}
+	return nil
+}
+
+func (f *commandsFeature) everyPredefinedPatternShouldHaveACommandToStampIt() error {
+	for _, p := range Patterns {
+		if len(FilterCommands(f.commands, "stamp "+p.GetName())) == 0 {
+			return fmt.Errorf("no command stamps %s", p.GetName())
+		}
+	}
+	return nil
+}
+
+func (f *commandsFeature) iSearchThePaletteFor(query string) error {
+	f.found = FilterCommands(f.commands, query)
+	return nil
+}
+
+func (f *commandsFeature) theFirstCommandShouldBe(name string) error {
+	if len(f.found) == 0 {
+		return fmt.Errorf("no commands found")
+	}
+	if f.found[0].Name != name {
+		return fmt.Errorf("expected %q first, got %q", name, f.found[0].Name)
+	}
+	return nil
+}
+
+func (f *commandsFeature) noCommandsShouldBeListed() error {
+	if len(f.found) > 0 {
+		return fmt.Errorf("expected no commands, got %q and %d more", f.found[0].Name, len(f.found)-1)
+	}
+	return nil
+}
+
+func (f *commandsFeature) pressingShouldBeTheShortcut(key, modifiers, tag, want string) error {
+	got, ok := Shortcut(f.commands, key, modifiers == "ctrl", modifiers == "alt", tag)
+	switch {
+	case want == "" && ok:
+		return fmt.Errorf("expected %q with %s to be left to the browser, got %q", key, modifiers, got)
+	case want != "" && (!ok || got != want):
+		return fmt.Errorf("expected %q with %s to be %q, got %q", key, modifiers, want, got)
+	}
+	return nil
+}
+
+func InitializeCommandsScenario(ctx *godog.ScenarioContext) {
+	f := &commandsFeature{}
+	ctx.Step(`^the commands of the game$`, f.theCommandsOfTheGame)
+	ctx.Step(`^the commands of the game in "([^"]*)"$`, f.theCommandsOfTheGameIn)
+	ctx.Step(`^the key "([^"]*)" should run "([^"]*)"$`, f.theKeyShouldRun)
+	ctx.Step(`^no two commands should share a name or a key$`, f.noTwoCommandsShouldShareANameOrAKey)
+	ctx.Step(`^every predefined pattern should have a command to stamp it$`, f.everyPredefinedPatternShouldHaveACommandToStampIt)
+	ctx.Step(`^I search the palette for "([^"]*)"$`, f.iSearchThePaletteFor)
+	ctx.Step(`^the first command should be "([^"]*)"$`, f.theFirstCommandShouldBe)
+	ctx.Step(`^no commands should be listed$`, f.noCommandsShouldBeListed)
+	ctx.Step(`^pressing "([^"]*)" with (no keys|ctrl|alt) in a (\w+) element should be the shortcut "([^"]*)"$`, f.pressingShouldBeTheShortcut)
+}
+
+func TestCommandsFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "commands",
+		ScenarioInitializer: InitializeCommandsScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/commands.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"github.com/richardwooding/gameoflife/pkg/render"
+)
+
+// displayMessage returns the key of the message naming a display mode.
+func displayMessage(m render.Mode) string {
+	return "display-" + m.String()
+}
+
+// displayLabel returns the name a display mode is offered under.
+func displayLabel(l i18n.Localizer, m render.Mode) string {
+	return l.T(displayMessage(m))
+}
+
+// setDisplay changes what the colours of the board show, tracking the colony when the mode needs it.
+func (g *Game) setDisplay(m render.Mode) {
+	g.display = m
+	g.colony.Track(m.Tracked())
+}
+
+// resetTracking forgets the age and activity of the cells, so the heat map starts again from the current
+// generation.
+func (g *Game) resetTracking() {
+	g.colony.Track(false)
+	g.colony.Track(g.display.Tracked())
+}
+
+// renderOptions returns the options the board and its downloads are drawn with, in the colours of the theme.
+func (g *Game) renderOptions() render.Options {
+	opts := g.currentTheme().Options()
+	opts.Mode = g.display
+	return opts
+}
+
+// renderDisplay renders the picker of display modes.
+func (g *Game) renderDisplay() app.UI {
+	return app.Div().Body(
+		app.Label().Text(g.localizer.T("display")).For("display-select"),
+		app.Select().
+			ID("display-select").
+			OnChange(func(ctx app.Context, e app.Event) {
+				if m, err := render.ParseMode(e.Get("target").Get("value").String()); err == nil {
+					g.setDisplay(m)
+				}
+			}).
+			Body(app.Range(render.Modes).Slice(func(i int) app.UI {
+				m := render.Modes[i]
+				return app.Option().Value(m.String()).Selected(m == g.display).Text(displayLabel(g.localizer, m))
+			})),
+		app.If(g.display.Tracked(), func() app.UI {
+			return app.Button().Text(g.localizer.T("restart")).Title(g.localizer.T("restart-title")).
+				OnClick(func(ctx app.Context, e app.Event) {
+					g.resetTracking()
+				})
+		}),
+	)
+}
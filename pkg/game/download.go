@@ -0,0 +1,17 @@
+package game
+
+import (
+	"encoding/base64"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+)
+
+// download offers data to the user as a file by clicking a temporary link to a data URL.
+func download(filename string, mimeType string, data []byte) {
+	doc := app.Window().Get("document")
+	link := doc.Call("createElement", "a")
+	link.Set("href", "data:"+mimeType+";base64,"+base64.StdEncoding.EncodeToString(data))
+	link.Set("download", filename)
+	doc.Get("body").Call("appendChild", link)
+	link.Call("click")
+	link.Call("remove")
+}
@@ -0,0 +1,209 @@
+package game
+
+import (
+	"github.com/enescakir/emoji"
+	"github.com/richardwooding/gameoflife/model"
+)
+
+// Tool is a way of drawing on the board with the mouse.
+type Tool uint8
+
+const (
+	Pencil          Tool = iota // Sets or clears cells along the drag, depending on the cell it started on
+	Line                        // Draws a straight line of live cells from where the drag started
+	Rectangle                   // Draws the outline of a rectangle with opposite corners at the ends of the drag
+	FilledRectangle             // Draws a solid rectangle with opposite corners at the ends of the drag
+	Fill                        // Flips the connected cells in the same state as the one clicked
+	Eraser                      // Clears cells along the drag
+	Select                      // Picks out the rectangle with opposite corners at the ends of the drag
+)
+
+// Tools lists the drawing tools in the order they are offered.
+var Tools = []Tool{Pencil, Line, Rectangle, FilledRectangle, Fill, Eraser, Select}
+
+func (t Tool) String() string {
+	switch t {
+	case Pencil:
+		return "Pencil"
+	case Line:
+		return "Line"
+	case Rectangle:
+		return "Rectangle"
+	case FilledRectangle:
+		return "Filled rectangle"
+	case Fill:
+		return "Fill"
+	case Eraser:
+		return "Eraser"
+	case Select:
+		return "Select"
+	default:
+		return "unknown"
+	}
+}
+
+// Icon returns the emoji shown on the tool's button.
+func (t Tool) Icon() emoji.Emoji {
+	switch t {
+	case Pencil:
+		return emoji.Pencil
+	case Line:
+		return emoji.StraightRuler
+	case Rectangle:
+		return emoji.WhiteSquareButton
+	case FilledRectangle:
+		return emoji.BlackLargeSquare
+	case Fill:
+		return emoji.Droplet
+	case Eraser:
+		return emoji.Sponge
+	default:
+		return emoji.BlackSquareButton
+	}
+}
+
+// Stroke is a drag across the colony with a tool, from pressing the mouse button to releasing it.
+// The colony shows the result as the stroke goes, so it only needs saving once the stroke ends.
+type Stroke struct {
+	tool   Tool
+	colony *model.Colony
+	paint  uint8     // State the stroke leaves cells in
+	before [][]uint8 // States when the stroke started, which shapes are redrawn over as the drag moves
+	x0, y0 int       // Cell the stroke started on
+	x, y   int       // Cell the stroke last reached
+}
+
+// StartStroke starts drawing on the colony with the tool at (x, y). A click with the pencil toggles the cell,
+// so it still steps through every state of multi-state rules.
+func StartStroke(c *model.Colony, tool Tool, x, y int) *Stroke {
+	s := &Stroke{tool: tool, colony: c, paint: 1, x0: x, y0: y, x: x, y: y}
+	start := c.State(x, y)
+	switch tool {
+	case Pencil:
+		if start != 0 {
+			s.paint = 0
+		}
+		c.Toggle(x, y)
+	case Eraser:
+		s.paint = 0
+		c.SetState(x, y, 0)
+	case Fill:
+		if start != 0 {
+			s.paint = 0
+		}
+		s.set(FloodFill(c, x, y))
+	case Select:
+	default:
+		s.before = c.StateGrid()
+		s.set([][2]int{{x, y}})
+	}
+	return s
+}
+
+// MoveTo continues the stroke to (x, y). Freehand tools draw a line from the last cell so fast drags leave no
+// gaps; shapes are redrawn from where the stroke started.
+func (s *Stroke) MoveTo(x, y int) {
+	if x == s.x && y == s.y {
+		return
+	}
+	switch s.tool {
+	case Pencil, Eraser:
+		s.set(BresenhamLine(s.x, s.y, x, y))
+	case Line:
+		s.colony.SetStateGrid(s.before)
+		s.set(BresenhamLine(s.x0, s.y0, x, y))
+	case Rectangle, FilledRectangle:
+		s.colony.SetStateGrid(s.before)
+		s.set(RectangleCells(s.x0, s.y0, x, y, s.tool == FilledRectangle))
+	}
+	s.x, s.y = x, y
+}
+
+// Selection returns the rectangle the stroke has dragged out so far.
+func (s *Stroke) Selection() Selection {
+	return Selection{X: min(s.x0, s.x), Y: min(s.y0, s.y), Width: abs(s.x-s.x0) + 1, Height: abs(s.y-s.y0) + 1}
+}
+
+// set puts the cells in the stroke's state.
+func (s *Stroke) set(cells [][2]int) {
+	for _, p := range cells {
+		s.colony.SetState(p[0], p[1], s.paint)
+	}
+}
+
+// BresenhamLine returns the cells of the straight line from (x0, y0) to (x1, y1), in order, using
+// Bresenham's algorithm.
+func BresenhamLine(x0, y0, x1, y1 int) [][2]int {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	cells := make([][2]int, 0, max(dx, -dy)+1)
+	for err := dx + dy; ; {
+		cells = append(cells, [2]int{x0, y0})
+		if x0 == x1 && y0 == y1 {
+			return cells
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// RectangleCells returns the cells of the rectangle with opposite corners (x0, y0) and (x1, y1), either all of
+// them or just its outline.
+func RectangleCells(x0, y0, x1, y1 int, filled bool) [][2]int {
+	x0, x1 = min(x0, x1), max(x0, x1)
+	y0, y1 = min(y0, y1), max(y0, y1)
+	var cells [][2]int
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			if filled || x == x0 || x == x1 || y == y0 || y == y1 {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	return cells
+}
+
+// FloodFill returns the cells connected to (x, y) through their edges that are in the same state as it.
+func FloodFill(c *model.Colony, x, y int) [][2]int {
+	if x < 0 || y < 0 || x >= c.Width() || y >= c.Height() {
+		return nil
+	}
+	state := c.State(x, y)
+	seen := make([][]bool, c.Height())
+	for i := range seen {
+		seen[i] = make([]bool, c.Width())
+	}
+	seen[y][x] = true
+	cells := [][2]int{{x, y}}
+	for i := 0; i < len(cells); i++ {
+		for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			nx, ny := cells[i][0]+d[0], cells[i][1]+d[1]
+			if nx < 0 || ny < 0 || nx >= c.Width() || ny >= c.Height() || seen[ny][nx] || c.State(nx, ny) != state {
+				continue
+			}
+			seen[ny][nx] = true
+			cells = append(cells, [2]int{nx, ny})
+		}
+	}
+	return cells
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,270 @@
+package game
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/rle"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type drawFeature struct {
+	colony    *model.Colony
+	line      [][2]int
+	history   History
+	selection Selection
+}
+
+var cellPattern = regexp.MustCompile(`\((\d+),(\d+)\)`)
+
+// cells parses a list of cells such as "(0,0) (1,2)".
+func cells(s string) [][2]int {
+	var cells [][2]int
+	for _, m := range cellPattern.FindAllStringSubmatch(s, -1) {
+		x, _ := strconv.Atoi(m[1])
+		y, _ := strconv.Atoi(m[2])
+		cells = append(cells, [2]int{x, y})
+	}
+	return cells
+}
+
+func (f *drawFeature) aColony(w, h int) error {
+	f.colony = model.NewColony(w, h)
+	return nil
+}
+
+func (f *drawFeature) aColonyUnderTheRule(w, h int, s string) error {
+	rule, err := model.ParseRule(s)
+	if err != nil {
+		return err
+	}
+	f.colony = model.NewColony(w, h)
+	f.colony.SetRule(rule)
+	return nil
+}
+
+func (f *drawFeature) theCellsAreAlive(s string) error {
+	for _, p := range cells(s) {
+		f.colony.SetState(p[0], p[1], 1)
+	}
+	return nil
+}
+
+func (f *drawFeature) iDrawALine(from, to string) error {
+	a, b := cells(from)[0], cells(to)[0]
+	f.line = BresenhamLine(a[0], a[1], b[0], b[1])
+	return nil
+}
+
+func (f *drawFeature) theLineShouldCross(s string) error {
+	if got, want := fmt.Sprint(f.line), fmt.Sprint(cells(s)); got != want {
+		return fmt.Errorf("expected %s, got %s", want, got)
+	}
+	return nil
+}
+
+func (f *drawFeature) iDragAlong(name, path string) error {
+	for _, tool := range Tools {
+		if strings.EqualFold(tool.String(), name) {
+			points := cells(path)
+			stroke := StartStroke(f.colony, tool, points[0][0], points[0][1])
+			for _, p := range points[1:] {
+				stroke.MoveTo(p[0], p[1])
+			}
+			if tool == Select {
+				f.selection = stroke.Selection()
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown tool %q", name)
+}
+
+func (f *drawFeature) theLiveCellsShouldBe(s string) error {
+	var live [][2]int
+	for y := 0; y < f.colony.Height(); y++ {
+		for x := 0; x < f.colony.Width(); x++ {
+			if f.colony.State(x, y) != 0 {
+				live = append(live, [2]int{x, y})
+			}
+		}
+	}
+	if got, want := fmt.Sprint(live), fmt.Sprint(cells(s)); got != want {
+		return fmt.Errorf("expected live cells %s, got %s", want, got)
+	}
+	return nil
+}
+
+func (f *drawFeature) thereShouldBeNoLiveCells() error {
+	return f.theLiveCellsShouldBe("")
+}
+
+func (f *drawFeature) theCellShouldBeInState(x, y, state int) error {
+	if got := f.colony.State(x, y); int(got) != state {
+		return fmt.Errorf("expected cell (%d,%d) to be in state %d, got %d", x, y, state, got)
+	}
+	return nil
+}
+
+func (f *drawFeature) aHistoryOfTheStates(s string) error {
+	for _, state := range strings.Fields(s) {
+		f.history.Push(strings.Trim(state, `"`))
+	}
+	return nil
+}
+
+func (f *drawFeature) aHistoryOfStates(n int) error {
+	for i := 0; i < n; i++ {
+		f.history.Push(strconv.Itoa(i))
+	}
+	return nil
+}
+
+func (f *drawFeature) undoingShouldGive(want string) error {
+	if got, ok := f.history.Undo(); !ok || got != want {
+		return fmt.Errorf("expected to undo to %q, got %q", want, got)
+	}
+	return nil
+}
+
+func (f *drawFeature) redoingShouldGive(want string) error {
+	if got, ok := f.history.Redo(); !ok || got != want {
+		return fmt.Errorf("expected to redo to %q, got %q", want, got)
+	}
+	return nil
+}
+
+func (f *drawFeature) thereShouldBeNothingToUndo() error {
+	if f.history.CanUndo() {
+		return fmt.Errorf("expected nothing to undo")
+	}
+	return nil
+}
+
+func (f *drawFeature) thereShouldBeNothingToRedo() error {
+	if f.history.CanRedo() {
+		return fmt.Errorf("expected nothing to redo")
+	}
+	return nil
+}
+
+func (f *drawFeature) iUndoAndSave(state string) error {
+	f.history.Undo()
+	f.history.Push(state)
+	return nil
+}
+
+func (f *drawFeature) itsStateIsSaved() error {
+	f.history.Push(EncodeState(f.colony))
+	return nil
+}
+
+func (f *drawFeature) undoingShouldGiveTheEmptyColony() error {
+	return f.undoingShouldGive(EncodeState(model.NewColony(f.colony.Width(), f.colony.Height())))
+}
+
+func (f *drawFeature) statesCanBeUndone(n int) error {
+	undone := 0
+	for f.history.CanUndo() {
+		f.history.Undo()
+		undone++
+	}
+	if undone != n {
+		return fmt.Errorf("expected to undo %d states, undid %d", n, undone)
+	}
+	return nil
+}
+
+func (f *drawFeature) theSelectionShouldBeAt(w, h, x, y int) error {
+	if want := (Selection{X: x, Y: y, Width: w, Height: h}); f.selection != want {
+		return fmt.Errorf("expected selection %+v, got %+v", want, f.selection)
+	}
+	return nil
+}
+
+func (f *drawFeature) iCopyTheSelectionAndPasteItAt(x, y int) error {
+	f.selection = Paste(f.colony, f.selection.Copy(f.colony), x, y)
+	return nil
+}
+
+func (f *drawFeature) theSelectionAsRLEShouldBe(doc *godog.DocString) error {
+	if got, want := strings.TrimSpace(rle.String(f.selection.Region(f.colony))), strings.TrimSpace(doc.Content); got != want {
+		return fmt.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+	return nil
+}
+
+func (f *drawFeature) iPasteTheText(doc *godog.DocString) error {
+	c, err := rle.Parse(doc.Content)
+	if err != nil {
+		return err
+	}
+	f.selection = Paste(f.colony, NewPattern("Clipboard", *c.Cells()), f.selection.X, f.selection.Y)
+	return nil
+}
+
+func (f *drawFeature) iTransformTheSelection(transform string) error {
+	t := map[string]func(*model.Colony) *model.Colony{
+		"rotate": (*model.Colony).Rotated,
+		"mirror": (*model.Colony).Mirrored,
+		"flip":   (*model.Colony).Flipped,
+	}[transform]
+	f.selection = f.selection.Transform(f.colony, t)
+	return nil
+}
+
+func (f *drawFeature) iClearTheSelection(where string) error {
+	if where == "inside" {
+		f.selection.Clear(f.colony)
+	} else {
+		f.selection.ClearOutside(f.colony)
+	}
+	return nil
+}
+
+func InitializeDrawScenario(ctx *godog.ScenarioContext) {
+	f := &drawFeature{}
+	ctx.Step(`^a (\d+)x(\d+) colony$`, f.aColony)
+	ctx.Step(`^a (\d+)x(\d+) colony under the rule "([^"]*)"$`, f.aColonyUnderTheRule)
+	ctx.Step(`^the cells ((?:\(\d+,\d+\)\s*)+)are alive$`, f.theCellsAreAlive)
+	ctx.Step(`^I draw a line from (\(\d+,\d+\)) to (\(\d+,\d+\))$`, f.iDrawALine)
+	ctx.Step(`^the line should cross ((?:\(\d+,\d+\)\s*)+)$`, f.theLineShouldCross)
+	ctx.Step(`^I drag the ([a-z ]+) along ((?:\(\d+,\d+\)\s*)+)$`, f.iDragAlong)
+	ctx.Step(`^the live cells should be ((?:\(\d+,\d+\)\s*)+)$`, f.theLiveCellsShouldBe)
+	ctx.Step(`^there should be no live cells$`, f.thereShouldBeNoLiveCells)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) should be in state (\d+)$`, f.theCellShouldBeInState)
+	ctx.Step(`^a history of the states ((?:"[^"]*"\s*)+)$`, f.aHistoryOfTheStates)
+	ctx.Step(`^a history of (\d+) states$`, f.aHistoryOfStates)
+	ctx.Step(`^undoing should give "([^"]*)"$`, f.undoingShouldGive)
+	ctx.Step(`^redoing should give "([^"]*)"$`, f.redoingShouldGive)
+	ctx.Step(`^there should be nothing to undo$`, f.thereShouldBeNothingToUndo)
+	ctx.Step(`^there should be nothing to redo$`, f.thereShouldBeNothingToRedo)
+	ctx.Step(`^I undo and save "([^"]*)"$`, f.iUndoAndSave)
+	ctx.Step(`^its state is saved$`, f.itsStateIsSaved)
+	ctx.Step(`^undoing should give the empty colony$`, f.undoingShouldGiveTheEmptyColony)
+	ctx.Step(`^(\d+) states can be undone$`, f.statesCanBeUndone)
+	ctx.Step(`^the selection should be (\d+)x(\d+) at \((\d+),(\d+)\)$`, f.theSelectionShouldBeAt)
+	ctx.Step(`^I copy the selection and paste it at \((\d+),(\d+)\)$`, f.iCopyTheSelectionAndPasteItAt)
+	ctx.Step(`^the selection as RLE should be$`, f.theSelectionAsRLEShouldBe)
+	ctx.Step(`^I paste the text$`, f.iPasteTheText)
+	ctx.Step(`^I (rotate|mirror|flip) the selection$`, f.iTransformTheSelection)
+	ctx.Step(`^I clear (inside|outside) the selection$`, f.iClearTheSelection)
+}
+
+func TestDrawFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "draw",
+		ScenarioInitializer: InitializeDrawScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/draw.feature", "features/selection.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
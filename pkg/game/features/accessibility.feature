@@ -0,0 +1,105 @@
+Feature: Keyboard and screen reader access to the board
+
+  Scenario Outline: Keys move the focus around the board
+    Given the focus is on (<x>,<y>) of a 10x20 board
+    When I press "<key>"
+    Then the focus should be on (<nx>,<ny>)
+
+    Examples:
+      | x | y  | key        | nx | ny |
+      | 4 | 5  | ArrowLeft  | 3  | 5  |
+      | 4 | 5  | ArrowRight | 5  | 5  |
+      | 4 | 5  | ArrowUp    | 4  | 4  |
+      | 4 | 5  | ArrowDown  | 4  | 6  |
+      | 4 | 5  | Home       | 0  | 5  |
+      | 4 | 5  | End        | 9  | 5  |
+      | 4 | 5  | Ctrl+Home  | 0  | 0  |
+      | 4 | 5  | Ctrl+End   | 9  | 19 |
+      | 4 | 10 | PageUp     | 4  | 2  |
+      | 4 | 10 | PageDown   | 4  | 18 |
+
+  Scenario Outline: The focus stops at the edges of the board
+    Given the focus is on (<x>,<y>) of a 10x20 board
+    When I press "<key>"
+    Then the focus should be on (<x>,<y>)
+
+    Examples:
+      | x | y  | key        |
+      | 0 | 5  | ArrowLeft  |
+      | 9 | 5  | ArrowRight |
+      | 4 | 0  | ArrowUp    |
+      | 4 | 19 | ArrowDown  |
+
+  Scenario: Other keys leave the focus alone
+    Given the focus is on (4,5) of a 10x20 board
+    When I press "n"
+    Then the key should not move the focus
+
+  Scenario Outline: Enter and Space edit the focused cell only while the simulation is paused
+    Given the simulation is <state>
+    Then the focused cell should <take> "<key>"
+
+    Examples:
+      | state   | key       | take     |
+      | paused  | Enter     | take     |
+      | paused  | Space     | take     |
+      | paused  | ArrowLeft | take     |
+      | running | Enter     | not take |
+      | running | Space     | not take |
+      | running | ArrowLeft | take     |
+      | paused  | n         | not take |
+
+  Scenario: Cells are described by state and position
+    Given a 5x5 colony under the rule "B2/S/C3"
+    And the cell at (1,2) is in state 1
+    And the cell at (3,0) is in state 2
+    Then the label of (1,2) should be "alive, row 3, column 2"
+    And the label of (3,0) should be "state 2, row 1, column 4"
+    And the label of (0,0) should be "dead, row 1, column 1"
+
+  Scenario: Generation and population are announced at a throttled rate
+    Given a 16x16 colony with a glider
+    And a monitor announcing every 2 seconds
+    When a generation passes at 0 seconds
+    Then "Generation 1, population 5" should be announced
+    When a generation passes at 1 seconds
+    Then nothing should be announced
+    When a generation passes at 2 seconds
+    Then "Generation 3, population 5" should be announced
+
+  Scenario: A still life is announced once when the colony settles
+    Given a 6x6 colony with cells at (1,1) (2,1) (1,2) (2,2) (4,4)
+    And a monitor announcing every 100 seconds
+    When a generation passes at 0 seconds
+    And a generation passes at 1 seconds
+    Then "Generation 2: settled into a still life of 4 cells" should be announced
+    When a generation passes at 200 seconds
+    Then nothing should be announced
+
+  Scenario: An oscillation is announced with its period
+    Given a 5x5 colony with cells at (1,2) (2,2) (3,2)
+    And a monitor announcing every 100 seconds
+    When a generation passes at 0 seconds
+    And a generation passes at 1 seconds
+    And a generation passes at 2 seconds
+    Then "Generation 3: settled into an oscillation with period 2" should be announced
+
+  Scenario: A colony dying out is announced
+    Given a 5x5 colony with cells at (2,2)
+    And a monitor announcing every 100 seconds
+    When a generation passes at 0 seconds
+    Then "Generation 1: all cells have died" should be announced
+    When a generation passes at 1 seconds
+    Then nothing should be announced
+
+  Scenario: Reports start again when a settled colony is changed
+    Given a 9x9 colony with cells at (1,1) (2,1) (1,2) (2,2)
+    And a monitor announcing every 2 seconds
+    When a generation passes at 0 seconds
+    And a generation passes at 1 seconds
+    Then "Generation 2: settled into a still life of 4 cells" should be announced
+    When the cell at (6,5) is toggled
+    And the cell at (6,6) is toggled
+    And the cell at (6,7) is toggled
+    And a generation passes at 5 seconds
+    Then "Generation 3, population 7" should be announced
@@ -0,0 +1,80 @@
+Feature: Keyboard shortcuts and the command palette
+
+  Scenario Outline: Keys run commands
+    Given the commands of the game
+    Then the key "<key>" should run "<command>"
+
+    Examples:
+      | key        | command                 |
+      | Space      | Play or pause           |
+      | n          | Step one generation     |
+      | c          | Clear                   |
+      | r          | Random soup             |
+      | z          | Undo                    |
+      | y          | Redo                    |
+      | +          | Faster                  |
+      | =          | Faster                  |
+      | -          | Slower                  |
+      | ArrowLeft  | Move cells left         |
+      | ArrowRight | Move cells right        |
+      | ArrowUp    | Move cells up           |
+      | ArrowDown  | Move cells down         |
+      | ?          | Show keyboard shortcuts |
+
+  Scenario: Commands have different names and keys
+    Given the commands of the game
+    Then no two commands should share a name or a key
+
+  Scenario Outline: Commands have different names in every language
+    Given the commands of the game in "<locale>"
+    Then no two commands should share a name or a key
+
+    Examples:
+      | locale |
+      | es     |
+      | fr     |
+      | de     |
+      | pl     |
+
+  Scenario: Every pattern can be stamped from the palette
+    Given the commands of the game
+    Then every predefined pattern should have a command to stamp it
+
+  Scenario Outline: Searching the palette
+    Given the commands of the game
+    When I search the palette for "<query>"
+    Then the first command should be "<command>"
+
+    Examples:
+      | query     | command                       |
+      | gli       | Stamp Glider                  |
+      | stamp gun | Stamp Gosper Glider Gun       |
+      | PAUSE     | Play or pause                 |
+      | rule life | Rule: Life (B3/S23)           |
+      | brain     | Rule: Brian's Brain (B2/S/C3) |
+      | rotate    | Rotate selection              |
+      | eraser    | Tool: Eraser                  |
+
+  Scenario: Searching for something that isn't there
+    Given the commands of the game
+    When I search the palette for "warp drive"
+    Then no commands should be listed
+
+  Scenario Outline: Which key presses are shortcuts
+    Given the commands of the game
+    Then pressing "<key>" with <modifiers> in a <tag> element should be the shortcut "<shortcut>"
+
+    Examples:
+      | key    | modifiers | tag      | shortcut |
+      | n      | no keys   | DIV      | n        |
+      | k      | ctrl      | DIV      | Ctrl+K   |
+      | K      | ctrl      | INPUT    | Ctrl+K   |
+      | Escape | no keys   | INPUT    | Escape   |
+      | Z      | ctrl      | DIV      | z        |
+      | y      | ctrl      | BODY     | y        |
+      | n      | no keys   | INPUT    |          |
+      | c      | no keys   | TEXTAREA |          |
+      | c      | ctrl      | DIV      |          |
+      | n      | alt       | DIV      |          |
+      | q      | no keys   | DIV      |          |
+      | z      | ctrl      | INPUT    |          |
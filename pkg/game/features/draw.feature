@@ -0,0 +1,113 @@
+Feature: Drawing tools
+
+  Scenario Outline: Lines are drawn with Bresenham's algorithm
+    When I draw a line from <from> to <to>
+    Then the line should cross <cells>
+
+    Examples:
+      | from  | to    | cells                                   |
+      | (0,0) | (0,0) | (0,0)                                   |
+      | (0,0) | (4,0) | (0,0) (1,0) (2,0) (3,0) (4,0)           |
+      | (2,3) | (2,0) | (2,3) (2,2) (2,1) (2,0)                 |
+      | (0,0) | (3,3) | (0,0) (1,1) (2,2) (3,3)                 |
+      | (0,0) | (5,2) | (0,0) (1,0) (2,1) (3,1) (4,2) (5,2)     |
+      | (5,2) | (0,0) | (5,2) (4,2) (3,1) (2,1) (1,0) (0,0)     |
+
+  Scenario: A click with the pencil toggles the cell
+    Given a 6x6 colony
+    When I drag the pencil along (2,2)
+    Then the live cells should be (2,2)
+    When I drag the pencil along (2,2)
+    Then there should be no live cells
+
+  Scenario: Dragging the pencil from a dead cell draws without gaps
+    Given a 8x8 colony
+    When I drag the pencil along (0,0) (3,0) (3,2)
+    Then the live cells should be (0,0) (1,0) (2,0) (3,0) (3,1) (3,2)
+
+  Scenario: Dragging the pencil from a live cell clears
+    Given a 8x8 colony
+    And the cells (1,1) (2,1) (3,1) (1,2) are alive
+    When I drag the pencil along (1,1) (3,1)
+    Then the live cells should be (1,2)
+
+  Scenario: The line follows the drag from where it started
+    Given a 8x8 colony
+    When I drag the line along (0,0) (5,5) (4,0)
+    Then the live cells should be (0,0) (1,0) (2,0) (3,0) (4,0)
+
+  Scenario: A line over live cells leaves the cells it moved off alive
+    Given a 8x8 colony
+    And the cells (2,2) (6,6) are alive
+    When I drag the line along (0,2) (4,6) (4,2)
+    Then the live cells should be (0,2) (1,2) (2,2) (3,2) (4,2) (6,6)
+
+  Scenario: An outlined rectangle
+    Given a 6x6 colony
+    When I drag the rectangle along (1,1) (3,3)
+    Then the live cells should be (1,1) (2,1) (3,1) (1,2) (3,2) (1,3) (2,3) (3,3)
+
+  Scenario: A filled rectangle dragged up and to the left
+    Given a 6x6 colony
+    When I drag the filled rectangle along (3,4) (5,5) (2,3)
+    Then the live cells should be (2,3) (3,3) (2,4) (3,4)
+
+  Scenario: Fill stops at cells in another state
+    Given a 7x7 colony
+    And the cells (2,1) (3,1) (4,1) (1,2) (5,2) (1,3) (5,3) (2,4) (3,4) (4,4) are alive
+    When I drag the fill along (3,3)
+    Then the live cells should be (2,1) (3,1) (4,1) (1,2) (2,2) (3,2) (4,2) (5,2) (1,3) (2,3) (3,3) (4,3) (5,3) (2,4) (3,4) (4,4)
+
+  Scenario: Fill on a live cell clears the cells connected to it
+    Given a 6x6 colony
+    And the cells (1,1) (2,1) (2,2) (4,4) are alive
+    When I drag the fill along (1,1)
+    Then the live cells should be (4,4)
+
+  Scenario: Fill only spreads through edges
+    Given a 3x3 colony
+    And the cells (1,0) (0,1) (2,1) (1,2) are alive
+    When I drag the fill along (0,0)
+    Then the live cells should be (0,0) (1,0) (0,1) (2,1) (1,2)
+
+  Scenario: The eraser clears whatever it passes over
+    Given a 6x6 colony
+    And the cells (0,0) (1,0) (2,0) (3,0) (0,1) are alive
+    When I drag the eraser along (1,0) (4,0) (4,2)
+    Then the live cells should be (0,0) (0,1)
+
+  Scenario: Drawing in a multi-state colony
+    Given a 6x6 colony under the rule "B2/S/C3"
+    And the cells (1,1) are alive
+    When I drag the pencil along (1,1)
+    Then the cell at (1,1) should be in state 2
+    When I drag the filled rectangle along (0,0) (1,0)
+    Then the cell at (1,0) should be in state 1
+
+  Scenario: Undo and redo step through saved states
+    Given a history of the states "a" "b" "c"
+    Then undoing should give "b"
+    And undoing should give "a"
+    And there should be nothing to undo
+    And redoing should give "b"
+
+  Scenario: Saving after undoing forgets what was undone
+    Given a history of the states "a" "b" "c"
+    When I undo and save "d"
+    Then there should be nothing to redo
+    And undoing should give "b"
+
+  Scenario: Saving the same state twice records it once
+    Given a history of the states "a" "b" "b"
+    Then undoing should give "a"
+
+  Scenario: Each stroke is one step of the history
+    Given a 8x8 colony
+    And its state is saved
+    When I drag the pencil along (0,0) (7,0) (7,7)
+    And its state is saved
+    Then undoing should give the empty colony
+
+  Scenario: The history is limited
+    Given a history of 150 states
+    Then 99 states can be undone
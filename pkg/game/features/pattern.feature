@@ -22,3 +22,46 @@ Feature: Game of Life Patterns
     Then each pattern should have a non-empty name
     And each pattern should have valid coordinates
 
+
+  Scenario: Creating a pattern from a grid trims it to its live cells
+    Given a 5x5 grid
+    And the grid cells (2,1) and (3,3) are alive
+    When I create a pattern named "Imported" from the grid
+    Then the pattern should be 2 wide and 3 high
+    And stamping the pattern at offset (0,0) onto a 4x4 grid should make (0,0) and (1,2) alive
+
+  Scenario Outline: The apgcode of a predefined pattern is found by running it
+    Given the predefined pattern "<name>"
+    When I compute the apgcode of the pattern
+    Then the result should be "<apgcode>"
+
+    Examples:
+      | name    | apgcode   |
+      | Block   | xs4_33    |
+      | Beehive | xs6_696   |
+      | Loaf    | xs7_2596  |
+      | Boat    | xs5_253   |
+      | Tub     | xs4_252   |
+      | Blinker | xp2_7     |
+      | Toad    | xp2_7e    |
+      | Beacon  | xp2_318c  |
+      | Glider  | xq4_153   |
+      | LWSS    | xq4_6frc  |
+
+  Scenario Outline: A pattern decoded from an apgcode encodes back to the same apgcode
+    Given a pattern decoded from the apgcode "<apgcode>"
+    Then the pattern should be <width> wide and <height> high
+    When I compute the apgcode of the pattern
+    Then the result should be "<apgcode>"
+
+    Examples:
+      | apgcode                                        | width | height |
+      | xs4_33                                         | 2     | 2      |
+      | xp2_7                                          | 1     | 3      |
+      | xq4_153                                        | 3     | 3      |
+      | xq4_6frc                                       | 4     | 5      |
+      | xp3_co9nas0san9oczgoldlo0oldlogz1047210127401  | 13    | 13     |
+
+  Scenario: An invalid apgcode is rejected
+    Given a pattern decoded from the apgcode "xs5_33"
+    Then decoding should have failed
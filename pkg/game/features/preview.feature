@@ -0,0 +1,31 @@
+Feature: Previews of shared links
+
+  Background:
+    Given the live server for "example.com"
+    And a shared colony with a blinker
+
+  Scenario Outline: A shared link points previews at a thumbnail of its colony
+    When a crawler fetches the link in <form> form
+    Then the page should have the meta tag "og:image" for the thumbnail
+    And the page should have the meta tag "twitter:image" for the thumbnail
+    And the page should have the meta tag "twitter:card" with "summary_large_image"
+    And the page should have the meta tag "og:description" with "3 cells alive in a 5x5 colony at generation 0, under the rule B3/S23."
+
+    Examples:
+      | form  |
+      | path  |
+      | query |
+
+  Scenario Outline: The start page has no thumbnail
+    When a crawler fetches "<path>"
+    Then the meta tag "og:image" should not point at a thumbnail
+
+    Examples:
+      | path         |
+      | /            |
+      | /gameoflife  |
+      | /gameoflife/ |
+
+  Scenario: A link to a colony too large to share has no thumbnail
+    When a crawler fetches the link to a 1025x1 colony
+    Then the meta tag "og:image" should not point at a thumbnail
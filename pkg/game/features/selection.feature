@@ -0,0 +1,96 @@
+Feature: Selection and the clipboard
+
+  Scenario: Dragging with the select tool picks out a rectangle without changing cells
+    Given a 8x8 colony
+    And the cells (2,2) are alive
+    When I drag the select along (5,4) (1,6) (2,1)
+    Then the selection should be 4x4 at (2,1)
+    And the live cells should be (2,2)
+
+  Scenario: Copying and pasting a glider
+    Given a 10x10 colony
+    And the cells (1,0) (2,1) (0,2) (1,2) (2,2) are alive
+    When I drag the select along (0,0) (3,3)
+    And I copy the selection and paste it at (5,5)
+    Then the live cells should be (1,0) (2,1) (0,2) (1,2) (2,2) (6,5) (7,6) (5,7) (6,7) (7,7)
+    And the selection should be 3x3 at (5,5)
+
+  Scenario: Pasting keeps the cells already alive
+    Given a 6x6 colony
+    And the cells (0,0) (3,3) are alive
+    When I drag the select along (0,0) (1,0)
+    And I copy the selection and paste it at (3,3)
+    Then the live cells should be (0,0) (3,3)
+
+  Scenario: A paste hanging over the edge is cut off
+    Given a 5x5 colony
+    And the cells (0,0) (1,0) (2,0) are alive
+    When I drag the select along (0,0) (2,0)
+    And I copy the selection and paste it at (3,4)
+    Then the live cells should be (0,0) (1,0) (2,0) (3,4) (4,4)
+    And the selection should be 2x1 at (3,4)
+
+  Scenario: The clipboard holds the selection as RLE
+    Given a 10x10 colony
+    And the cells (4,3) (5,4) (3,5) (4,5) (5,5) are alive
+    When I drag the select along (3,3) (6,6)
+    Then the selection as RLE should be
+      """
+      x = 4, y = 4, rule = B3/S23
+      bo$2bo$3o!
+      """
+
+  Scenario Outline: Pasting text from the clipboard
+    Given a 8x8 colony
+    When I drag the select along (2,2)
+    And I paste the text
+      """
+      <line 1>
+      <line 2>
+      <line 3>
+      """
+    Then the live cells should be (3,2) (4,3) (2,4) (3,4) (4,4)
+
+    Examples:
+      | line 1                      | line 2     | line 3 |
+      | x = 3, y = 3, rule = B3/S23 | bo$2bo$3o! |        |
+      | #C Headerless               | bo$2bo$    | 3o!    |
+      | .O.                         | ..O        | OOO    |
+
+  Scenario: Rotating the selection in place turns it about its top left corner
+    Given a 8x8 colony
+    And the cells (1,1) (2,1) (3,1) (1,2) are alive
+    When I drag the select along (1,1) (3,2)
+    And I rotate the selection
+    Then the live cells should be (1,1) (2,1) (2,2) (2,3)
+    And the selection should be 2x3 at (1,1)
+
+  Scenario: Rotating near the edge cuts off what no longer fits
+    Given a 4x4 colony
+    And the cells (0,2) (1,2) (2,2) (3,2) are alive
+    When I drag the select along (0,2) (3,2)
+    And I rotate the selection
+    Then the live cells should be (0,2) (0,3)
+    And the selection should be 1x2 at (0,2)
+
+  Scenario Outline: Flipping the selection in place
+    Given a 6x6 colony
+    And the cells (1,1) (2,1) (1,2) (5,5) are alive
+    When I drag the select along (1,1) (3,3)
+    And I <flip> the selection
+    Then the live cells should be <cells>
+
+    Examples:
+      | flip   | cells                   |
+      | mirror | (2,1) (3,1) (3,2) (5,5) |
+      | flip   | (1,2) (1,3) (2,3) (5,5) |
+
+  Scenario: Clearing inside and outside the selection
+    Given a 6x6 colony
+    And the cells (0,0) (2,2) (3,3) (5,5) are alive
+    When I drag the select along (2,2) (3,4)
+    And I clear inside the selection
+    Then the live cells should be (0,0) (5,5)
+    When I drag the select along (0,0) (1,1)
+    And I clear outside the selection
+    Then the live cells should be (0,0)
@@ -0,0 +1,74 @@
+Feature: Shared state
+
+  Scenario Outline: A colony survives a round trip through the URL state
+    Given a 6x6 colony under the rule "<rule>"
+    And the cell at (1,1) is in state <state>
+    When the colony is encoded and decoded
+    Then the decoded colony should run under the rule "<decoded>"
+    And the decoded cell at (1,1) should be in state <state>
+
+    Examples:
+      | rule        | decoded     | state |
+      | B3/S23      | B3/S23      | 1     |
+      | B2/S/C3     | B2/S/C3     | 2     |
+      | B2-a/S12    | B2-a/S12    | 1     |
+      | WireWorld   | WireWorld   | 3     |
+
+  Scenario: A pasted rule table is kept in the state
+    Given a 6x6 colony under the rule table
+      """
+      @RULE Pasted
+      @TABLE
+      n_states:3
+      neighborhood:vonNeumann
+      symmetries:rotate4
+      0,1,0,0,0,2
+      """
+    And the cell at (1,1) is in state 2
+    When the colony is encoded and decoded
+    Then the decoded colony should run under the rule "Pasted"
+    And the decoded cell at (1,1) should be in state 2
+
+  Scenario: A rule table named like a rulestring is kept in full
+    Given a 6x6 colony under the rule table
+      """
+      @RULE B3S23
+      @TABLE
+      n_states:3
+      neighborhood:vonNeumann
+      symmetries:rotate4
+      0,1,0,0,0,2
+      """
+    And the cell at (1,1) is in state 2
+    When the colony is encoded and decoded
+    Then the decoded colony should run under the rule "B3S23"
+    And the decoded cell at (1,1) should be in state 2
+
+  Scenario: The generation count is kept so block rules stay in phase
+    Given a 6x6 colony under the rule "MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15"
+    And the cell at (1,1) is in state 1
+    And 3 generations are computed
+    When the colony is encoded and decoded
+    Then the decoded colony should be at generation 3
+    And the decoded colony should step back to the cell at (1,1)
+
+  Scenario: A random soup can be recreated from the state
+    Given a 16x16 colony under the rule "B3/S23"
+    And it is randomised with seed 42 at density 0.3 with D4 symmetry in an 8x8 region at (4,4)
+    When the colony is encoded and decoded
+    Then the decoded colony should remember seed 42 at density 0.3 with D4 symmetry in an 8x8 region at (4,4)
+    And randomising a blank colony with the decoded options should give the same cells
+
+  Scenario Outline: States that don't describe a colony are refused
+    Given a state whose cells are <cells> and whose states are <states>
+    When the state is decoded
+    Then it should be refused as invalid
+
+    Examples:
+      | cells       | states      |
+      | empty       | absent      |
+      | ragged      | absent      |
+      | 1025x4      | absent      |
+      | 4x1025      | absent      |
+      | 4x4         | ragged      |
+      | 4x4         | 5x4         |
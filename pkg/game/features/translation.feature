@@ -0,0 +1,33 @@
+Feature: Translation of the interface
+
+  Scenario: Every message the game asks for is in the English catalogue
+    When I collect the messages the game's source asks for
+    Then there should be some messages
+    And every message should be in the English catalogue
+
+  Scenario: The interface only shows text from the catalogues
+    When I collect the text the game's source writes into the interface
+    Then no text should be written without a catalogue
+
+  Scenario: Every language names every tool, display mode, theme and kind of object
+    When I collect the names of the tools, display modes, themes and kinds of object
+    Then every message should be in every catalogue
+
+  Scenario: Cells are described in the language of the interface
+    Given a 3x3 colony with cells at 1,1
+    When the interface is in "de"
+    Then the label of (1,1) should read "lebend, Zeile 2, Spalte 2"
+    And the label of (0,0) should read "tot, Zeile 1, Spalte 1"
+
+  Scenario Outline: Announcements are made in the language of the interface
+    Given a 4x4 colony with cells at 1,1 2,1 1,2 2,2
+    And a monitor announcing in "<locale>"
+    When a generation passes
+    And a generation passes
+    Then "<announcement>" should be announced in the language of the interface
+
+    Examples:
+      | locale | announcement                                                    |
+      | en     | Generation 2: settled into a still life of 4 cells              |
+      | fr     | Génération 2 : stabilisée en une structure stable de 4 cellules |
+      | pl     | Pokolenie 2: ustabilizowało się w martwą naturę z 4 komórek     |
@@ -2,13 +2,16 @@ package game
 
 import (
 	"bytes"
-	"compress/flate"
-	"encoding/base64"
-	"encoding/gob"
 	"fmt"
 	"github.com/enescakir/emoji"
 	"github.com/maxence-charriere/go-app/v10/pkg/app"
 	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/analysis"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"github.com/richardwooding/gameoflife/pkg/preview"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"github.com/richardwooding/gameoflife/pkg/theme"
+	"image/png"
 	"net/url"
 	"strconv"
 	"strings"
@@ -17,40 +20,67 @@ import (
 
 type Game struct {
 	app.Compo
-	colony       *model.Colony
-	ticker       *time.Ticker
-	done         chan bool
-	tickInterval time.Duration
-}
-
-type exported struct {
-	Cells [][]bool
+	colony          *model.Colony
+	ticker          *time.Ticker
+	done            chan bool
+	tickInterval    time.Duration
+	imported        *Pattern
+	importError     string
+	objects         []analysis.Result
+	analysed        bool
+	ruleError       string
+	ruleSource      string
+	random          model.RandomOptions
+	randomError     string
+	tool            Tool
+	stroke          *Stroke
+	history         History
+	selection       Selection
+	buffer          *Pattern // Cells last copied or pasted
+	clipboardError  string
+	keys            app.Func // Listener for keyboard shortcuts
+	help            bool
+	palette         bool
+	paletteQuery    string
+	paletteIndex    int
+	display         render.Mode // What the colours of the board show
+	theme           theme.Settings
+	prefersDark     bool      // Whether the system asks for dark colours
+	scheme          app.Value // Media query list of the system's dark mode
+	schemeChanged   app.Func  // Listener for changes of the system's dark mode
+	focusX, focusY  int       // Cell of the board in the tab order
+	announcement    string    // Message in the live region
+	monitor         *Monitor
+	localizer       i18n.Localizer // Language of the interface
+	updateAvailable bool           // Whether a new version of the app is ready to reload into
 }
 
 // NewColony initializes a new colony with the given dimensions and resets the simulation state.
 func (g *Game) NewColony(context app.Context, dx uint, dy uint) {
 	g.colony = model.NewColony(int(dx), int(dy))
 	g.tickInterval = 50 * time.Millisecond
+	g.random = model.DefaultRandomOptions()
 	g.saveState(context)
 }
 
 func (g *Game) Generate(ctx app.Context) {
+	// The colony is replaced when states are loaded or undone, so it is tracked again here.
+	g.colony.Track(g.display.Tracked())
 	g.colony.Generate()
+	g.observe()
+	g.clearAnalysis()
 	ctx.Update()
 }
 
-// toggle toggles the alive state of the cell at (x, y) and saves the current state.
-func (g *Game) toggle(context app.Context, x int, y int) {
-	g.colony.Toggle(x, y)
-	g.saveState(context)
-}
-
-// className returns the CSS class name ("alive" or "dead") for the cell at (x, y).
+// className returns the CSS class name ("alive", "dying" or "dead") for the cell at (x, y).
 func (g *Game) className(x int, y int) string {
-	if g.colony.IsAlive(x, y) {
-		return "alive"
-	} else {
+	switch g.colony.State(x, y) {
+	case 0:
 		return "dead"
+	case 1:
+		return "alive"
+	default:
+		return "dying"
 	}
 }
 
@@ -80,55 +110,87 @@ func (g *Game) stopTicking(ctx app.Context) {
 	g.saveState(ctx)
 }
 
-// OnNav loads the simulation state from the URL path if present.
+// stateFromURL returns the encoded state a URL carries in its path, as in "/<state>", or its query, as in
+// "/?state=<state>", if any.
+func stateFromURL(u *url.URL) (string, bool) {
+	if state := u.Query().Get("state"); state != "" {
+		return state, true
+	}
+	state := strings.TrimPrefix(strings.TrimPrefix(u.Path, "/gameoflife"), "/")
+	return state, state != ""
+}
+
+// OnNav loads the simulation state from the URL path or query if present.
 func (g *Game) OnNav(ctx app.Context) {
-	path := ctx.Page().URL().Path
-	if path != "/" && path != "/gameoflife/" {
-		g.loadState(strings.TrimPrefix(path, "/gameoflife")[1:])
+	if state, ok := stateFromURL(ctx.Page().URL()); ok {
+		g.loadState(state)
+	}
+}
+
+// OnPreRender describes the colony a shared link carries to the chat apps and social networks that show a
+// preview of it, with a thumbnail the server draws.
+func (g *Game) OnPreRender(ctx app.Context) {
+	state, ok := stateFromURL(ctx.Page().URL())
+	if !ok {
+		return
+	}
+	colony, err := DecodeState(state)
+	if err != nil {
+		return
 	}
+	description := preview.Describe(colony)
+	image := preview.ImageURL(state)
+	page := ctx.Page()
+	page.SetDescription("%s", description)
+	page.SetImage(image)
+	page.SetTwitterCard(app.TwitterCard{
+		Card:        "summary_large_image",
+		Title:       page.Title(),
+		Description: description,
+		Image:       image,
+		ImageAlt:    "The colony: " + description,
+	})
 }
 
-// OnMount is called when the component is mounted. (Implementation may be added as needed.)
+// OnMount loads the simulation state from the URL fragment if present, applies the saved theme and language and
+// starts listening for keyboard shortcuts.
 func (g *Game) OnMount(ctx app.Context) {
 	fragment := ctx.Page().URL().Fragment
 	if fragment != "" {
 		g.loadState(fragment)
 	}
+	g.loadTheme(ctx)
+	g.loadLocale(ctx)
+	g.listenForKeys(ctx)
 }
 
 // loadState decodes and loads the simulation state from a base64-encoded string.
 func (g *Game) loadState(state string) {
-	exp := &exported{}
-	b, err := base64.RawURLEncoding.DecodeString(state)
-	if err != nil {
-		return
-	}
-	buff := bytes.NewBuffer(b)
-	reader := flate.NewReader(buff)
-
-	dec := gob.NewDecoder(reader)
-	err = dec.Decode(exp)
+	colony, err := DecodeState(state)
 	if err == nil {
 		g.tickInterval = 50 * time.Millisecond
-		if g.colony == nil {
-			g.colony = model.NewColony(len(exp.Cells[0]), len(exp.Cells))
-		} else {
-			g.colony.Reset()
+		g.colony = colony
+		g.history = History{}
+		g.history.Push(EncodeState(colony))
+		g.random = model.DefaultRandomOptions()
+		if random, ok := colony.RandomOptions(); ok {
+			g.random = random
 		}
-		g.colony.SetCells(exp.Cells)
 		//g.Update()
 	}
 }
 
-// saveState encodes and saves the current simulation state as a base64-encoded string in the URL.
+// saveState encodes and saves the current simulation state as a base64-encoded string in the URL, and records
+// it in the undo history.
 func (g *Game) saveState(context app.Context) {
-	exp := exported{Cells: *g.colony.Cells()}
-	var buff bytes.Buffer
-	writer, _ := flate.NewWriter(&buff, flate.BestCompression)
-	enc := gob.NewEncoder(writer)
-	_ = enc.Encode(exp)
-	_ = writer.Flush()
-	str := base64.RawURLEncoding.EncodeToString(buff.Bytes())
+	g.clearAnalysis()
+	str := EncodeState(g.colony)
+	g.history.Push(str)
+	g.replaceURL(context, str)
+}
+
+// replaceURL puts an encoded state in the URL.
+func (g *Game) replaceURL(context app.Context, str string) {
 	path := context.Page().URL().Path
 	var prefix string
 	if strings.HasPrefix(path, "/gameoflife") {
@@ -149,6 +211,21 @@ func (g *Game) clearColony(ctx app.Context) {
 	g.saveState(ctx)
 }
 
+// reversible reports whether the colony's rule can step back a generation.
+func (g *Game) reversible() bool {
+	r, ok := g.colony.Rule().(model.Reverser)
+	return ok && r.Reversible()
+}
+
+// stepBack returns the colony to its previous generation.
+func (g *Game) stepBack(ctx app.Context) {
+	if err := g.colony.GenerateBackwards(); err != nil {
+		g.ruleError = err.Error()
+		return
+	}
+	g.saveState(ctx)
+}
+
 // setSpeed adjusts the simulation speed and restarts the ticker with the new intervag.
 func (g *Game) setSpeed(ctx app.Context, ms int64) {
 	if ms < 10 {
@@ -166,15 +243,18 @@ func (g *Game) setSpeed(ctx app.Context, ms int64) {
 
 // Render generates the UI for the Game of Game component.
 func (g *Game) Render() app.UI {
-
+	l := g.localizer
 	return app.Div().Body(
-		app.H1().Text("Conway's Game of life"),
-		app.Button().Textf("%s Open on Github", emoji.Laptop).OnClick(func(ctx app.Context, e app.Event) {
+		app.If(g.updateAvailable, g.renderUpdate),
+		app.H1().Text(l.T("title")),
+		app.Button().Textf("%s %s", emoji.Laptop, l.T("github")).OnClick(func(ctx app.Context, e app.Event) {
 			ctx.Navigate("https://github.com/richardwooding/gameoflife")
 		}),
+		g.renderLanguage(),
+		g.renderTheme(),
 		app.If(g.colony == nil,
 			func() app.UI {
-				return app.Button().Textf("%s Make Cells", emoji.Hut).OnClick(func(ctx app.Context, e app.Event) {
+				return app.Button().Textf("%s %s", emoji.Hut, l.T("make-cells")).OnClick(func(ctx app.Context, e app.Event) {
 					g.NewColony(ctx, 64, 64)
 					g.tickInterval = 50 * time.Millisecond
 				})
@@ -182,7 +262,7 @@ func (g *Game) Render() app.UI {
 			return app.Div().Body(
 				// Range slider for speed
 				app.Div().Body(
-					app.Label().Text("Interval: ").For("interval-slider"),
+					app.Label().Text(l.T("interval")).For("interval-slider"),
 					app.Input().
 						Type("range").
 						ID("interval-slider").
@@ -190,7 +270,7 @@ func (g *Game) Render() app.UI {
 						Max("1000").
 						Step(10).
 						Value(fmt.Sprintf("%d", g.tickInterval.Milliseconds())).
-						Aria("label", "Simulation speed interval in milliseconds").
+						Aria("label", l.T("interval-label")).
 						Aria("valuenow", fmt.Sprintf("%d", g.tickInterval.Milliseconds())).
 						Aria("valuemin", "10").
 						Aria("valuemax", "1000").
@@ -199,20 +279,34 @@ func (g *Game) Render() app.UI {
 								g.setSpeed(ctx, int64(targetSpeed))
 							}
 						}),
-					app.Span().Style("margin-left", "8px").Textf("%d ms", g.tickInterval.Milliseconds()),
+					app.Span().Style("margin-left", "8px").Text(l.T("milliseconds", g.tickInterval.Milliseconds())),
 				),
+				g.renderRule(),
+				g.renderDisplay(),
+				g.renderTools(),
+				app.Button().Textf("%s %s", emoji.Keyboard, l.T("shortcuts")).Title(l.T("shortcuts-title")).OnClick(func(ctx app.Context, e app.Event) {
+					g.help = !g.help
+				}),
+				g.renderSelection(),
 				// Play/Pause and other controls
 				app.If(g.ticker == nil,
 					func() app.UI {
-						return app.Button().Text(emoji.PlayButton).OnClick(func(ctx app.Context, e app.Event) {
+						return app.Button().Text(emoji.PlayButton).Title(l.T("play")).OnClick(func(ctx app.Context, e app.Event) {
 							g.startTicking(ctx)
 						})
 					}).Else(func() app.UI {
-					return app.Button().Text(emoji.PauseButton).OnClick(func(ctx app.Context, e app.Event) {
+					return app.Button().Text(emoji.PauseButton).Title(l.T("pause")).OnClick(func(ctx app.Context, e app.Event) {
 						g.stopTicking(ctx)
 					})
 				}),
-				app.Button().Text(emoji.ClButton).OnClick(func(ctx app.Context, e app.Event) {
+				app.If(g.reversible(), func() app.UI {
+					return app.Button().Text(emoji.ReverseButton).Title(l.T("step-back")).OnClick(func(ctx app.Context, e app.Event) {
+						if g.ticker == nil {
+							g.stepBack(ctx)
+						}
+					})
+				}),
+				app.Button().Text(emoji.ClButton).Title(l.T("clear")).OnClick(func(ctx app.Context, e app.Event) {
 					if g.ticker == nil {
 						g.clearColony(ctx)
 					}
@@ -223,45 +317,125 @@ func (g *Game) Render() app.UI {
 						g.saveState(ctx)
 					})
 				}),
-				app.Button().Textf("%s Random", emoji.GameDie).OnClick(func(ctx app.Context, e app.Event) {
+				app.Button().Textf("%s %s", emoji.GameDie, l.T("random")).OnClick(func(ctx app.Context, e app.Event) {
 					if g.colony != nil && g.ticker == nil {
 						g.insertRandom(ctx)
 					}
 				}),
-				app.Button().Textf("%s Center", emoji.Compass).OnClick(func(ctx app.Context, e app.Event) {
+				app.Button().Textf("%s %s", emoji.Compass, l.T("center")).OnClick(func(ctx app.Context, e app.Event) {
 					if g.colony != nil && g.ticker == nil {
 						g.centerAlive(ctx)
 					}
 				}),
+				app.If(g.analysable(), func() app.UI {
+					return app.Button().Textf("%s %s", emoji.MagnifyingGlassTiltedLeft, l.T("analyse")).OnClick(func(ctx app.Context, e app.Event) {
+						if g.colony == nil || g.ticker != nil {
+							return
+						}
+						if g.analysed {
+							g.clearAnalysis()
+						} else {
+							g.analyse()
+						}
+					})
+				}),
+				app.Button().Textf("%s %s", emoji.FramedPicture, l.T("download-image")).OnClick(func(ctx app.Context, e app.Event) {
+					if g.colony != nil {
+						g.exportImage()
+					}
+				}),
+				app.Button().Textf("%s %s", emoji.FramedPicture, l.T("download-svg")).OnClick(func(ctx app.Context, e app.Event) {
+					if g.colony != nil {
+						g.exportSVG()
+					}
+				}),
+				app.Button().Textf("%s %s", emoji.FilmFrames, l.T("download-gif")).OnClick(func(ctx app.Context, e app.Event) {
+					if g.colony != nil && g.ticker == nil {
+						g.exportAnimation(render.GIF)
+					}
+				}),
+				app.Button().Textf("%s %s", emoji.FilmFrames, l.T("download-apng")).OnClick(func(ctx app.Context, e app.Event) {
+					if g.colony != nil && g.ticker == nil {
+						g.exportAnimation(render.APNG)
+					}
+				}),
+				g.renderRandom(),
+				g.renderImport(),
 			)
 		}),
 		app.Hr(),
 		app.If(g.colony != nil, func() app.UI {
-			return app.Div().Textf("Generation: %d", g.colony.GetGeneration())
+			pop := population(g.colony)
+			return app.Div().Text(l.T("generation", g.colony.GetGeneration()) + " · " + l.N("live-cells", pop, pop))
 		}),
 		app.If(g.colony != nil, func() app.UI {
-			return app.Div().Class("wrapper").Body(
-				app.Range(*g.colony.Cells()).Slice(func(y int) app.UI {
-					return app.Range((*g.colony.Cells())[y]).Slice(func(x int) app.UI {
-						return app.Div().Class(g.className(x, y)).OnClick(func(ctx app.Context, e app.Event) {
-							if g.ticker == nil {
-								g.toggle(ctx, x, y)
-							}
-						})
-					})
-				}))
+			if g.hexagonal() {
+				return app.Div().Class("board").Body(g.renderHexBoard())
+			}
+			return app.Div().Class("board").Body(
+				g.gridBoard(app.Div().Class("wrapper")).
+					OnMouseUp(g.endStroke).
+					OnMouseLeave(g.endStroke).
+					Body(
+						app.Range(*g.colony.Cells()).Slice(func(y int) app.UI {
+							return gridRow(y, app.Range((*g.colony.Cells())[y]).Slice(func(x int) app.UI {
+								return g.gridCell(app.Div(), x, y).Class(g.className(x, y)).Styles(g.cellStyles(x, y)).
+									OnMouseDown(func(ctx app.Context, e app.Event) {
+										e.PreventDefault()
+										g.startStroke(x, y)
+									}).
+									OnMouseEnter(func(ctx app.Context, e app.Event) {
+										g.continueStroke(x, y)
+									})
+							}))
+						})),
+				g.renderObjects(),
+				g.renderSelectionOutline(),
+			)
 		}),
+		g.renderLiveRegion(),
+		app.If(g.palette, g.renderPalette),
+		app.If(g.help, g.renderHelp),
 	)
 }
 
-func (g *Game) insertRandom(ctx app.Context) {
-	g.colony.Randomize()
-	g.saveState(ctx)
-}
-
 // centerAlive shifts the bounding box of alive cells to the center of the grid.
 func (g *Game) centerAlive(ctx app.Context) {
 	g.colony.CentreAlive()
 	g.saveState(ctx)
 	ctx.Update()
 }
+
+// exportImage downloads the current generation as a PNG image.
+func (g *Game) exportImage() {
+	var buff bytes.Buffer
+	if err := png.Encode(&buff, render.Render(g.colony, g.renderOptions())); err != nil {
+		app.Log(err)
+		return
+	}
+	download("gameoflife.png", "image/png", buff.Bytes())
+}
+
+// exportSVG downloads the current generation as an SVG drawing.
+func (g *Game) exportSVG() {
+	var buff bytes.Buffer
+	if err := render.WriteSVG(&buff, g.colony, g.renderOptions()); err != nil {
+		app.Log(err)
+		return
+	}
+	download("gameoflife.svg", "image/svg+xml", buff.Bytes())
+}
+
+// exportAnimation downloads the next generations of the colony as an animated image.
+func (g *Game) exportAnimation(format render.Format) {
+	opts := render.DefaultAnimationOptions()
+	opts.Options = g.renderOptions()
+	opts.CellSize = 4
+	opts.Delay = g.tickInterval
+	var buff bytes.Buffer
+	if err := render.Animate(&buff, g.colony, format, opts); err != nil {
+		app.Log(err)
+		return
+	}
+	download("gameoflife."+format.Extension(), format.MimeType(), buff.Bytes())
+}
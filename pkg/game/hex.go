@@ -0,0 +1,69 @@
+package game
+
+import (
+	"fmt"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/render"
+)
+
+// hexagonal reports whether the colony runs on a hexagonal grid.
+func (g *Game) hexagonal() bool {
+	return model.NeighbourhoodOf(g.colony.Rule()) == model.Hexagonal
+}
+
+// hexLayout returns the placement of hexagons on the board, spaced like the cells of the square grid.
+func (g *Game) hexLayout() render.HexLayout {
+	return render.HexLayout{Width: g.colony.Width(), Height: g.colony.Height(), Size: cellPitch}
+}
+
+// px formats a length in pixels for a CSS property.
+func px(v float64) string {
+	return fmt.Sprintf("%.1fpx", v)
+}
+
+// renderHexBoard draws the colony as hexagons with each row offset by half a cell. Strokes are hit-tested on
+// the board as a whole, since the hexagons' bounding boxes overlap.
+func (g *Game) renderHexBoard() app.UI {
+	l := g.hexLayout()
+	w, h := l.Bounds()
+	corners := l.Corners(0, 0)
+	// Hexagons are drawn slightly smaller than the layout to leave a gap like the square grid's.
+	width, height := corners[1][0]-corners[5][0]-cellGap, corners[3][1]-corners[0][1]-cellGap
+	rows := make([]app.UI, 0, l.Height)
+	for y := 0; y < l.Height; y++ {
+		cells := make([]app.UI, 0, l.Width)
+		for x := 0; x < l.Width; x++ {
+			cx, cy := l.Centre(x, y)
+			cells = append(cells, g.gridCell(app.Div(), x, y).
+				Class("hex", g.className(x, y)).
+				Styles(g.cellStyles(x, y)).
+				Style("left", px(cx-width/2)).
+				Style("top", px(cy-height/2)).
+				Style("width", px(width)).
+				Style("height", px(height)))
+		}
+		rows = append(rows, gridRow(y, cells...))
+	}
+	return g.gridBoard(app.Div()).
+		Class("hex-board").
+		Style("width", px(w)).
+		Style("height", px(h)).
+		OnMouseDown(func(ctx app.Context, e app.Event) {
+			e.PreventDefault()
+			if x, y, ok := l.CellAt(e.Get("offsetX").Float(), e.Get("offsetY").Float()); ok {
+				g.startStroke(x, y)
+			}
+		}).
+		OnMouseMove(func(ctx app.Context, e app.Event) {
+			if g.stroke == nil {
+				return
+			}
+			if x, y, ok := l.CellAt(e.Get("offsetX").Float(), e.Get("offsetY").Float()); ok {
+				g.continueStroke(x, y)
+			}
+		}).
+		OnMouseUp(g.endStroke).
+		OnMouseLeave(g.endStroke).
+		Body(rows...)
+}
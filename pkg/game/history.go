@@ -0,0 +1,54 @@
+package game
+
+// historyLimit is the number of states kept for undo.
+const historyLimit = 100
+
+// History keeps the encoded states the colony has been saved in, so edits can be undone and redone.
+type History struct {
+	states []string
+	index  int // Position of the current state in states
+}
+
+// Push records a newly saved state, dropping any states that were undone. Saving the current state again
+// doesn't add an entry.
+func (h *History) Push(state string) {
+	if len(h.states) > 0 && h.states[h.index] == state {
+		return
+	}
+	if len(h.states) > 0 {
+		h.states = h.states[:h.index+1]
+	}
+	h.states = append(h.states, state)
+	if len(h.states) > historyLimit {
+		h.states = h.states[len(h.states)-historyLimit:]
+	}
+	h.index = len(h.states) - 1
+}
+
+// CanUndo reports whether there is an earlier state to go back to.
+func (h *History) CanUndo() bool {
+	return h.index > 0
+}
+
+// CanRedo reports whether there is an undone state to go forward to.
+func (h *History) CanRedo() bool {
+	return h.index < len(h.states)-1
+}
+
+// Undo moves back to the previous state and returns it.
+func (h *History) Undo() (string, bool) {
+	if !h.CanUndo() {
+		return "", false
+	}
+	h.index--
+	return h.states[h.index], true
+}
+
+// Redo moves forward to the state last undone and returns it.
+func (h *History) Redo() (string, bool) {
+	if !h.CanRedo() {
+		return "", false
+	}
+	h.index++
+	return h.states[h.index], true
+}
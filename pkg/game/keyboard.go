@@ -0,0 +1,213 @@
+package game
+
+import (
+	"github.com/enescakir/emoji"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"strings"
+)
+
+// paletteSize is the number of matching commands the palette lists.
+const paletteSize = 10
+
+// Keys with a meaning of their own rather than a command.
+const (
+	paletteKey = "Ctrl+K"
+	closeKey   = "Escape"
+)
+
+// listenForKeys runs the keyboard shortcuts of the game for keys pressed anywhere on the page, and the keys that
+// move around the board while a cell has the focus. Whether a key is taken is decided as it is pressed, so the
+// browser's default action can be prevented in time.
+func (g *Game) listenForKeys(ctx app.Context) {
+	commands := g.commands()
+	g.keys = app.FuncOf(func(this app.Value, args []app.Value) any {
+		event := args[0]
+		if GridTakesKey(event.Get("key").String(), g.ticker != nil) && event.Get("target").Call("getAttribute", "role").String() == "gridcell" {
+			// Keys that move around the board or toggle a cell come before shortcuts while a cell has the focus.
+			event.Call("preventDefault")
+			key, ctrl := event.Get("key").String(), event.Get("ctrlKey").Bool() || event.Get("metaKey").Bool()
+			ctx.Dispatch(func(ctx app.Context) {
+				g.onGridKey(ctx, key, ctrl)
+			})
+			return nil
+		}
+		key, ok := Shortcut(commands, event.Get("key").String(),
+			event.Get("ctrlKey").Bool() || event.Get("metaKey").Bool(), event.Get("altKey").Bool(),
+			event.Get("target").Get("tagName").String())
+		if !ok {
+			return nil
+		}
+		event.Call("preventDefault")
+		ctx.Dispatch(func(ctx app.Context) {
+			g.onShortcut(ctx, key)
+		})
+		return nil
+	})
+	app.Window().Call("addEventListener", "keydown", g.keys)
+}
+
+// OnDismount stops listening for keys and for changes of the system's dark mode.
+func (g *Game) OnDismount() {
+	g.stopFollowingScheme()
+	if g.keys != nil {
+		app.Window().Call("removeEventListener", "keydown", g.keys)
+		g.keys.Release()
+		g.keys = nil
+	}
+}
+
+// Shortcut returns the shortcut a key press stands for, or false for keys left to the browser. Ctrl+K, or ⌘K,
+// opens the command palette and Escape closes it and the help. Other keys are the shortcut of a command, unless
+// they are typed into a form field or used with a modifier, so the browser's own shortcuts keep working;
+// Ctrl+Z and Ctrl+Y undo and redo too.
+func Shortcut(commands []Command, key string, modified, alt bool, tag string) (string, bool) {
+	switch {
+	case modified && strings.EqualFold(key, "k"):
+		return paletteKey, true
+	case key == closeKey:
+		return closeKey, true
+	case tag == "INPUT" || tag == "TEXTAREA" || tag == "SELECT":
+		return "", false
+	case modified && (strings.EqualFold(key, "z") || strings.EqualFold(key, "y")):
+		return strings.ToLower(key), true
+	case modified || alt:
+		return "", false
+	}
+	_, ok := CommandForKey(commands, key)
+	return key, ok
+}
+
+// onShortcut acts on a shortcut.
+func (g *Game) onShortcut(ctx app.Context, key string) {
+	switch {
+	case g.colony == nil:
+	case key == paletteKey:
+		g.openPalette(ctx)
+	case key == closeKey:
+		g.palette, g.help = false, false
+	case !g.palette:
+		if c, ok := CommandForKey(g.commands(), key); ok {
+			g.run(ctx, c)
+		}
+	}
+}
+
+// openPalette shows the command palette with an empty search, ready to type into.
+func (g *Game) openPalette(ctx app.Context) {
+	g.palette, g.help = true, false
+	g.paletteQuery, g.paletteIndex = "", 0
+	ctx.Defer(func(ctx app.Context) {
+		if input := app.Window().GetElementByID("palette-input"); input.Truthy() {
+			input.Call("focus")
+		}
+	})
+}
+
+// paletteCommands returns the commands listed in the palette for the current search.
+func (g *Game) paletteCommands() []Command {
+	commands := FilterCommands(g.commands(), g.paletteQuery)
+	return commands[:min(len(commands), paletteSize)]
+}
+
+// runFromPalette closes the palette and runs a command chosen in it.
+func (g *Game) runFromPalette(ctx app.Context, c Command) {
+	g.palette = false
+	g.run(ctx, c)
+}
+
+// renderPalette renders the command palette: a search field and the commands matching it, which the arrow keys
+// move through and Enter runs.
+func (g *Game) renderPalette() app.UI {
+	l := g.localizer
+	commands := g.paletteCommands()
+	return app.Div().Class("overlay").OnClick(func(ctx app.Context, e app.Event) {
+		g.palette = false
+	}).Body(
+		app.Div().Class("palette").OnClick(func(ctx app.Context, e app.Event) {
+			e.StopImmediatePropagation()
+		}).Body(
+			app.Input().
+				Type("search").
+				ID("palette-input").
+				Placeholder(l.T("palette-placeholder")).
+				AutoComplete(false).
+				Aria("label", l.T("palette-label")).
+				Value(g.paletteQuery).
+				OnInput(func(ctx app.Context, e app.Event) {
+					g.paletteQuery = e.Get("target").Get("value").String()
+					g.paletteIndex = 0
+				}).
+				OnKeyDown(func(ctx app.Context, e app.Event) {
+					switch e.Get("key").String() {
+					case "ArrowDown":
+						e.PreventDefault()
+						g.paletteIndex = min(g.paletteIndex+1, len(commands)-1)
+					case "ArrowUp":
+						e.PreventDefault()
+						g.paletteIndex = max(g.paletteIndex-1, 0)
+					case "Enter":
+						if g.paletteIndex < len(commands) {
+							g.runFromPalette(ctx, commands[g.paletteIndex])
+						}
+					}
+				}),
+			app.Ul().Body(
+				app.Range(commands).Slice(func(i int) app.UI {
+					body := []app.UI{app.Text(commands[i].Name)}
+					if len(commands[i].Keys) > 0 {
+						body = append(body, app.Kbd().Text(keyLabel(l, commands[i].Keys[0])))
+					}
+					item := app.Li().Body(body...).OnClick(func(ctx app.Context, e app.Event) {
+						g.runFromPalette(ctx, commands[i])
+					})
+					if i == g.paletteIndex {
+						item = item.Class("selected")
+					}
+					return item
+				}),
+			),
+			app.If(len(commands) == 0, func() app.UI {
+				return app.P().Text(l.T("palette-empty"))
+			}),
+		),
+	)
+}
+
+// renderHelp renders the list of keyboard shortcuts.
+func (g *Game) renderHelp() app.UI {
+	l := g.localizer
+	var shortcuts []Command
+	for _, c := range g.commands() {
+		if len(c.Keys) > 0 {
+			shortcuts = append(shortcuts, c)
+		}
+	}
+	row := func(keys, name string) app.UI {
+		return app.Tr().Body(app.Td().Body(app.Kbd().Text(keys)), app.Td().Text(name))
+	}
+	return app.Div().Class("overlay").OnClick(func(ctx app.Context, e app.Event) {
+		g.help = false
+	}).Body(
+		app.Div().Class("palette").Body(
+			app.H2().Textf("%s %s", emoji.Keyboard, l.T("shortcuts-heading")),
+			app.Table().Body(
+				app.Range(shortcuts).Slice(func(i int) app.UI {
+					labels := make([]string, len(shortcuts[i].Keys))
+					for j, key := range shortcuts[i].Keys {
+						labels[j] = keyLabel(l, key)
+					}
+					return row(strings.Join(labels, " "), shortcuts[i].Name)
+				}),
+				row("Ctrl+K", l.T("shortcut-palette")),
+				row("Esc", l.T("shortcut-close")),
+			),
+			app.H3().Text(l.T("shortcuts-board")),
+			app.Table().Body(
+				row("← → ↑ ↓", l.T("shortcut-next-cell")),
+				row("Home End", l.T("shortcut-row-ends")),
+				row("PgUp PgDn", l.N("shortcut-page", pageRows, pageRows)),
+				row("Enter "+keyLabel(l, " "), l.T("shortcut-toggle")),
+			),
+		),
+	)
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+)
+
+// localeKey is the local storage key of the language chosen with the language switcher.
+const localeKey = "locale"
+
+// browserLanguages returns the languages the browser asks for, in order of preference.
+func browserLanguages() []string {
+	navigator := app.Window().Get("navigator")
+	var tags []string
+	if languages := navigator.Get("languages"); languages.Truthy() {
+		for i := 0; i < languages.Length(); i++ {
+			tags = append(tags, languages.Index(i).String())
+		}
+	}
+	if language := navigator.Get("language"); len(tags) == 0 && language.Truthy() {
+		tags = append(tags, language.String())
+	}
+	return tags
+}
+
+// loadLocale chooses the language of the interface: the one last chosen with the language switcher, or else the
+// best match for the browser's languages.
+func (g *Game) loadLocale(ctx app.Context) {
+	var locale string
+	if err := ctx.LocalStorage().Get(localeKey, &locale); err != nil {
+		app.Log(err)
+	}
+	c, ok := i18n.Lookup(locale)
+	if !ok {
+		c = i18n.Match(browserLanguages())
+	}
+	g.applyLocale(c)
+}
+
+// applyLocale shows the interface in the language of a catalogue and tells the browser the page is in it.
+func (g *Game) applyLocale(c *i18n.Catalogue) {
+	g.localizer = i18n.For(c)
+	if g.monitor != nil {
+		g.monitor.Localizer = g.localizer
+	}
+	document := app.Window().Get("document")
+	document.Get("documentElement").Set("lang", c.Locale)
+	document.Set("title", g.localizer.T("title"))
+}
+
+// setLocale switches the interface to a language and remembers the choice.
+func (g *Game) setLocale(ctx app.Context, locale string) {
+	c, ok := i18n.Lookup(locale)
+	if !ok {
+		return
+	}
+	if err := ctx.LocalStorage().Set(localeKey, c.Locale); err != nil {
+		app.Log(err)
+	}
+	g.applyLocale(c)
+}
+
+// renderLanguage renders the language switcher, with each language named in itself.
+func (g *Game) renderLanguage() app.UI {
+	return app.Div().Body(
+		app.Label().Text(g.localizer.T("language")).For("language-select"),
+		app.Select().
+			ID("language-select").
+			OnChange(func(ctx app.Context, e app.Event) {
+				g.setLocale(ctx, e.Get("target").Get("value").String())
+			}).
+			Body(app.Range(i18n.Catalogues).Slice(func(i int) app.UI {
+				c := i18n.Catalogues[i]
+				return app.Option().Value(c.Locale).Lang(c.Locale).Selected(c.Locale == g.localizer.Locale()).Text(c.Name)
+			})),
+	)
+}
@@ -0,0 +1,149 @@
+package game
+
+import (
+	"fmt"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"strconv"
+)
+
+// pageRows is the number of rows Page Up and Page Down move the focus by.
+const pageRows = 8
+
+// gridKeys are the keys the focused cell of the board takes before they can be shortcuts.
+var gridKeys = map[string]bool{
+	"ArrowLeft": true, "ArrowRight": true, "ArrowUp": true, "ArrowDown": true,
+	"Home": true, "End": true, "PageUp": true, "PageDown": true,
+	"Enter": true, " ": true,
+}
+
+// GridTakesKey reports whether the focused cell of the board takes a key before it can be a shortcut. Cells
+// can't be edited while the simulation runs, so Enter and Space are left to the shortcuts then, and Space
+// pauses it.
+func GridTakesKey(key string, running bool) bool {
+	if key == "Enter" || key == " " {
+		return !running
+	}
+	return gridKeys[key]
+}
+
+// NavigateGrid returns the cell the focus moves to from (x, y) on a w by h board when a key is pressed, following
+// the keyboard interaction of the ARIA grid pattern: the arrow keys move by a cell, Home and End to the start
+// and end of the row, or with Ctrl to the first and last cell, and Page Up and Page Down by pageRows rows. The
+// focus stops at the edges. Other keys don't move it.
+func NavigateGrid(key string, ctrl bool, x, y, w, h int) (int, int, bool) {
+	switch key {
+	case "ArrowLeft":
+		x--
+	case "ArrowRight":
+		x++
+	case "ArrowUp":
+		y--
+	case "ArrowDown":
+		y++
+	case "Home":
+		x = 0
+		if ctrl {
+			y = 0
+		}
+	case "End":
+		x = w - 1
+		if ctrl {
+			y = h - 1
+		}
+	case "PageUp":
+		y -= pageRows
+	case "PageDown":
+		y += pageRows
+	default:
+		return x, y, false
+	}
+	return max(0, min(x, w-1)), max(0, min(y, h-1)), true
+}
+
+// CellLabel describes the cell at (x, y) for screen readers, with its state first and coordinates counted
+// from 1 as the grid's aria-rowindex and aria-colindex are.
+func CellLabel(l i18n.Localizer, c *model.Colony, x, y int) string {
+	state := l.T("cell-dead")
+	switch s := c.State(x, y); s {
+	case 0:
+	case 1:
+		state = l.T("cell-alive")
+	default:
+		state = l.T("cell-state", s)
+	}
+	return l.T("cell-label", state, y+1, x+1)
+}
+
+// cellID returns the element ID of the cell at (x, y).
+func cellID(x, y int) string {
+	return fmt.Sprintf("cell-%d-%d", x, y)
+}
+
+// gridCell makes a cell of the board a cell of the ARIA grid. Only the focused cell is in the tab order, so
+// Tab moves past the board in one step and the arrow keys move within it.
+func (g *Game) gridCell(div app.HTMLDiv, x, y int) app.HTMLDiv {
+	tabIndex := -1
+	// The focus may be past the edge of a board that has since shrunk.
+	if x == min(g.focusX, g.colony.Width()-1) && y == min(g.focusY, g.colony.Height()-1) {
+		tabIndex = 0
+	}
+	return div.
+		ID(cellID(x, y)).
+		Role("gridcell").
+		Aria("label", CellLabel(g.localizer, g.colony, x, y)).
+		Aria("colindex", x+1).
+		DataSet("x", x).
+		DataSet("y", y).
+		TabIndex(tabIndex)
+}
+
+// gridRow makes a row of cells of the ARIA grid. Rows don't take part in the layout of the board.
+func gridRow(y int, cells ...app.UI) app.UI {
+	return app.Div().Class("grid-row").Role("row").Aria("rowindex", y+1).Body(cells...)
+}
+
+// gridBoard makes the board an ARIA grid, keeping track of which cell has the focus.
+func (g *Game) gridBoard(div app.HTMLDiv) app.HTMLDiv {
+	return div.
+		Role("grid").
+		Aria("label", g.localizer.T("colony")).
+		Aria("rowcount", g.colony.Height()).
+		Aria("colcount", g.colony.Width()).
+		On("focusin", func(ctx app.Context, e app.Event) {
+			dataset := e.Get("target").Get("dataset")
+			x, errX := strconv.Atoi(dataset.Get("x").String())
+			y, errY := strconv.Atoi(dataset.Get("y").String())
+			if errX == nil && errY == nil {
+				g.focusX, g.focusY = x, y
+			}
+		})
+}
+
+// onGridKey acts on a key pressed on the focused cell: moving the focus, or toggling the cell with Enter or
+// Space and announcing its new state.
+func (g *Game) onGridKey(ctx app.Context, key string, ctrl bool) {
+	if g.colony == nil {
+		return
+	}
+	if key == "Enter" || key == " " {
+		if g.ticker != nil {
+			return
+		}
+		g.colony.Toggle(g.focusX, g.focusY)
+		g.saveState(ctx)
+		g.announce(CellLabel(g.localizer, g.colony, g.focusX, g.focusY))
+		return
+	}
+	x, y, ok := NavigateGrid(key, ctrl, g.focusX, g.focusY, g.colony.Width(), g.colony.Height())
+	if !ok {
+		return
+	}
+	g.focusX, g.focusY = x, y
+	ctx.Defer(func(ctx app.Context) {
+		if cell := app.Window().GetElementByID(cellID(x, y)); cell.Truthy() {
+			cell.Call("focus")
+		}
+	})
+}
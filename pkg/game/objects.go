@@ -0,0 +1,107 @@
+package game
+
+import (
+	"fmt"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/pkg/analysis"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"sync"
+)
+
+// Geometry of the grid in pixels, matching .wrapper in web/gameoflife.css.
+const (
+	cellSize  = 17
+	cellGap   = 3
+	cellPitch = cellSize + cellGap
+)
+
+// library names the objects recognised by the analysis after the predefined patterns.
+var library = sync.OnceValue(func() analysis.Library {
+	lib := make(analysis.Library)
+	for _, p := range Patterns {
+		lib.Add(p.name, p.sparse, analysis.DefaultOptions().MaxPeriod)
+	}
+	return lib
+})
+
+// analysable reports whether the objects of the colony can be classified under its rule.
+func (g *Game) analysable() bool {
+	return analysis.Supports(g.colony.Rule())
+}
+
+// analyse splits the colony into objects and classifies them under its rule for the overlay.
+func (g *Game) analyse() {
+	opts := analysis.DefaultOptions()
+	opts.Library = library()
+	opts.Rule = g.colony.Rule()
+	g.objects = analysis.Analyse(g.colony, opts)
+	g.analysed = true
+}
+
+// clearAnalysis hides the overlay; it is out of date as soon as the colony changes.
+func (g *Game) clearAnalysis() {
+	g.objects = nil
+	g.analysed = false
+}
+
+// label returns the text shown for an object: its name, its apgcode, or its kind.
+func label(l i18n.Localizer, r analysis.Result) string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case r.Code != "":
+		return r.Code
+	default:
+		return l.T(kindMessage(r.Kind))
+	}
+}
+
+// describe returns a tooltip describing how an object behaves.
+func describe(l i18n.Localizer, r analysis.Result) string {
+	switch r.Kind {
+	case analysis.StillLife:
+		return l.N("describe-still-life", len(r.Cells), label(l, r), len(r.Cells))
+	case analysis.Oscillator:
+		return l.T("describe-oscillator", label(l, r), r.Period)
+	case analysis.Spaceship:
+		return l.T("describe-spaceship", label(l, r), r.Period, r.Dx, r.Dy)
+	default:
+		maxPeriod := analysis.DefaultOptions().MaxPeriod
+		return l.N("describe-unsettled", len(r.Cells), len(r.Cells), maxPeriod)
+	}
+}
+
+// renderObjects outlines and labels the objects found by the analysis, positioned over the grid.
+func (g *Game) renderObjects() app.UI {
+	l := g.localizer
+	return app.Range(g.objects).Slice(func(i int) app.UI {
+		r := g.objects[i]
+		x, y, w, h := r.Bounds()
+		return app.Div().
+			Class("object", "object-"+kindClass(r.Kind)).
+			Style("left", fmt.Sprintf("%dpx", x*cellPitch-cellGap)).
+			Style("top", fmt.Sprintf("%dpx", y*cellPitch-cellGap)).
+			Style("width", fmt.Sprintf("%dpx", w*cellPitch+cellGap)).
+			Style("height", fmt.Sprintf("%dpx", h*cellPitch+cellGap)).
+			Body(app.Span().Class("object-label").Title(describe(l, r)).Text(label(l, r)))
+	})
+}
+
+// kindMessage returns the key of the message naming a kind of object.
+func kindMessage(k analysis.Kind) string {
+	return "object-" + kindClass(k)
+}
+
+// kindClass returns the CSS class suffix for a kind of object.
+func kindClass(k analysis.Kind) string {
+	switch k {
+	case analysis.StillLife:
+		return "still"
+	case analysis.Oscillator:
+		return "oscillator"
+	case analysis.Spaceship:
+		return "spaceship"
+	default:
+		return "unknown"
+	}
+}
@@ -1,5 +1,12 @@
 package game
 
+import (
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/analysis"
+	"github.com/richardwooding/gameoflife/pkg/apgcode"
+	"strings"
+)
+
 // Pattern represents a named pattern using a sparse list of live cell coordinates.
 type Pattern struct {
 	name   string   // Name of the feature (pattern)
@@ -22,6 +29,84 @@ func (p *Pattern) Stamp(cells *[][]bool, offsetX, offsetY int) {
 	}
 }
 
+// NewPattern creates a named pattern from the live cells of a grid indexed [y][x],
+// trimmed so its bounding box starts at the origin.
+func NewPattern(name string, cells [][]bool) Pattern {
+	minX, minY := -1, -1
+	for y, row := range cells {
+		for x, alive := range row {
+			if alive {
+				if minY < 0 {
+					minY = y
+				}
+				if minX < 0 || x < minX {
+					minX = x
+				}
+			}
+		}
+	}
+	p := Pattern{name: name}
+	for y, row := range cells {
+		for x, alive := range row {
+			if alive {
+				p.sparse = append(p.sparse, [2]int{x - minX, y - minY})
+			}
+		}
+	}
+	return p
+}
+
+// Size returns the width and height of the pattern's bounding box.
+func (p *Pattern) Size() (int, int) {
+	w, h := 0, 0
+	for _, pos := range p.sparse {
+		if pos[0]+1 > w {
+			w = pos[0] + 1
+		}
+		if pos[1]+1 > h {
+			h = pos[1] + 1
+		}
+	}
+	return w, h
+}
+
+// Grid returns the pattern as a grid indexed [y][x] the size of its bounding box.
+func (p *Pattern) Grid() [][]bool {
+	w, h := p.Size()
+	grid := make([][]bool, h)
+	for y := range grid {
+		grid[y] = make([]bool, w)
+	}
+	p.Stamp(&grid, 0, 0)
+	return grid
+}
+
+// PatternFromApgcode creates a named pattern from an apgcode such as "xq4_153".
+func PatternFromApgcode(name string, code string) (Pattern, error) {
+	cells, err := apgcode.Decode(code)
+	if err != nil {
+		return Pattern{}, err
+	}
+	return Pattern{name: name, sparse: cells}, nil
+}
+
+// Apgcode returns the apgcode of the pattern, found by running it to determine whether it is a still life,
+// oscillator or spaceship and choosing its canonical phase and orientation.
+// Patterns that don't repeat within 64 generations have no apgcode and return an empty string.
+func (p *Pattern) Apgcode() string {
+	return analysis.Classify(analysis.Object{Cells: p.sparse}, model.Life, analysis.DefaultOptions().MaxPeriod).Code
+}
+
+// PatternByName returns the predefined pattern with the given name, ignoring case.
+func PatternByName(name string) (*Pattern, bool) {
+	for i := range Patterns {
+		if strings.EqualFold(Patterns[i].name, name) {
+			return &Patterns[i], true
+		}
+	}
+	return nil, false
+}
+
 // Patterns is a list of predefined Game of Life patterns, each using sparse representation.
 var Patterns = []Pattern{
 	// Glider: A small pattern that moves diagonally across the grid.
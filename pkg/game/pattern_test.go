@@ -7,6 +7,8 @@ import (
 )
 
 var (
+	testResult                 string
+	testErr                    error
 	testPattern                Pattern
 	testGrid                   [][]bool
 	stampOffsetX, stampOffsetY int
@@ -19,13 +21,39 @@ func aPatternNamed(name string) error {
 }
 
 func iGetTheNameOfThePattern() error {
-	// nothing to do, just use testPattern.GetName()
+	testResult = testPattern.GetName()
+	return nil
+}
+
+func thePredefinedPattern(name string) error {
+	p, ok := PatternByName(name)
+	if !ok {
+		return fmt.Errorf("no predefined pattern named %q", name)
+	}
+	testPattern = *p
+	return nil
+}
+
+func iComputeTheApgcodeOfThePattern() error {
+	testResult = testPattern.Apgcode()
+	return nil
+}
+
+func aPatternDecodedFromTheApgcode(code string) error {
+	testPattern, testErr = PatternFromApgcode(code, code)
+	return nil
+}
+
+func decodingShouldHaveFailed() error {
+	if testErr == nil {
+		return fmt.Errorf("expected decoding to fail")
+	}
 	return nil
 }
 
 func theResultShouldBe(expected string) error {
-	if testPattern.GetName() != expected {
-		return fmt.Errorf("expected '%s', got '%s'", expected, testPattern.GetName())
+	if testResult != expected {
+		return fmt.Errorf("expected '%s', got '%s'", expected, testResult)
 	}
 	return nil
 }
@@ -96,6 +124,43 @@ func eachPatternShouldHaveValidCoordinates() error {
 	return nil
 }
 
+func theGridCellsAreAlive(x1, y1, x2, y2 int) error {
+	testGrid[y1][x1] = true
+	testGrid[y2][x2] = true
+	return nil
+}
+
+func iCreateAPatternNamedFromTheGrid(name string) error {
+	testPattern = NewPattern(name, testGrid)
+	return nil
+}
+
+func thePatternShouldBeWideAndHigh(w, h int) error {
+	if pw, ph := testPattern.Size(); pw != w || ph != h {
+		return fmt.Errorf("expected %dx%d, got %dx%d", w, h, pw, ph)
+	}
+	return nil
+}
+
+func stampingThePatternOntoAGridShouldMakeAlive(x, y, size, x1, y1, x2, y2 int) error {
+	if err := aNxMGrid(size, size); err != nil {
+		return err
+	}
+	testPattern.Stamp(&testGrid, x, y)
+	alive := 0
+	for _, row := range testGrid {
+		for _, cell := range row {
+			if cell {
+				alive++
+			}
+		}
+	}
+	if alive != 2 || !testGrid[y1][x1] || !testGrid[y2][x2] {
+		return fmt.Errorf("expected only cells (%d,%d) and (%d,%d) to be alive", x1, y1, x2, y2)
+	}
+	return nil
+}
+
 func InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^a pattern named "([^"]*)"$`, aPatternNamed)
 	ctx.Step(`^I get the name of the pattern$`, iGetTheNameOfThePattern)
@@ -116,6 +181,14 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 			return nil
 		},
 	)
+	ctx.Step(`^the grid cells \((\d+),(\d+)\) and \((\d+),(\d+)\) are alive$`, theGridCellsAreAlive)
+	ctx.Step(`^I create a pattern named "([^"]*)" from the grid$`, iCreateAPatternNamedFromTheGrid)
+	ctx.Step(`^the pattern should be (\d+) wide and (\d+) high$`, thePatternShouldBeWideAndHigh)
+	ctx.Step(`^stamping the pattern at offset \((\d+),(\d+)\) onto a (\d+)x\d+ grid should make \((\d+),(\d+)\) and \((\d+),(\d+)\) alive$`, stampingThePatternOntoAGridShouldMakeAlive)
+	ctx.Step(`^the predefined pattern "([^"]*)"$`, thePredefinedPattern)
+	ctx.Step(`^I compute the apgcode of the pattern$`, iComputeTheApgcodeOfThePattern)
+	ctx.Step(`^a pattern decoded from the apgcode "([^"]*)"$`, aPatternDecodedFromTheApgcode)
+	ctx.Step(`^decoding should have failed$`, decodingShouldHaveFailed)
 	ctx.Step(`^the predefined patterns$`, thePredefinedPatterns)
 	ctx.Step(`^each pattern should have a non-empty name$`, eachPatternShouldHaveANonEmptyName)
 	ctx.Step(`^each pattern should have valid coordinates$`, eachPatternShouldHaveValidCoordinates)
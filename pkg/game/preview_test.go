@@ -0,0 +1,128 @@
+package game
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/preview"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	metaTag     = regexp.MustCompile(`<meta [^>]*>`)
+	metaName    = regexp.MustCompile(` (?:property|name)="([^"]+)"`)
+	metaContent = regexp.MustCompile(` content="([^"]*)"`)
+)
+
+type previewFeature struct {
+	handler *app.Handler
+	state   string
+	meta    map[string]string
+}
+
+func (f *previewFeature) theLiveServerFor(domain string) error {
+	// As main does for webmode.Live.
+	app.RouteWithRegexp("/(.*)", func() app.Composer { return &Game{} })
+	f.handler = &app.Handler{Name: "Conway's Game of Life", Title: "Conway's Game of Life", Domain: domain}
+	return nil
+}
+
+func (f *previewFeature) aSharedColonyWithABlinker() error {
+	c := model.NewColony(5, 5)
+	for x := 1; x <= 3; x++ {
+		c.SetState(x, 2, 1)
+	}
+	f.state = EncodeState(c)
+	return nil
+}
+
+func (f *previewFeature) aCrawlerFetches(path string) error {
+	w := httptest.NewRecorder()
+	f.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if w.Code != http.StatusOK {
+		return fmt.Errorf("expected status 200 for %s, got %d", path, w.Code)
+	}
+	b, err := io.ReadAll(w.Body)
+	if err != nil {
+		return err
+	}
+	f.meta = map[string]string{}
+	// The attributes of a tag come in no particular order.
+	for _, tag := range metaTag.FindAllString(string(b), -1) {
+		name, content := metaName.FindStringSubmatch(tag), metaContent.FindStringSubmatch(tag)
+		if name != nil && content != nil {
+			f.meta[name[1]] = html.UnescapeString(content[1])
+		}
+	}
+	return nil
+}
+
+func (f *previewFeature) aCrawlerFetchesTheLinkInForm(form string) error {
+	switch form {
+	case "path":
+		return f.aCrawlerFetches("/" + f.state)
+	case "query":
+		return f.aCrawlerFetches("/?state=" + f.state)
+	}
+	return fmt.Errorf("unknown form %q", form)
+}
+
+func (f *previewFeature) aCrawlerFetchesTheLinkToAColony(w, h int) error {
+	return f.aCrawlerFetches("/" + EncodeState(model.NewColony(w, h)))
+}
+
+func (f *previewFeature) thePageShouldHaveTheMetaTagWith(name, want string) error {
+	got, ok := f.meta[name]
+	if !ok {
+		return fmt.Errorf("expected a %s meta tag", name)
+	}
+	if got != want {
+		return fmt.Errorf("expected %s to be %q, got %q", name, want, got)
+	}
+	return nil
+}
+
+func (f *previewFeature) thePageShouldHaveTheMetaTagForTheThumbnail(name string) error {
+	return f.thePageShouldHaveTheMetaTagWith(name, "https://"+f.handler.Domain+preview.ImageURL(f.state))
+}
+
+func (f *previewFeature) theMetaTagShouldNotPointAtAThumbnail(name string) error {
+	if got := f.meta[name]; strings.Contains(got, preview.Path) {
+		return fmt.Errorf("expected %s not to point at a thumbnail, got %q", name, got)
+	}
+	return nil
+}
+
+func InitializePreviewScenario(ctx *godog.ScenarioContext) {
+	f := &previewFeature{}
+	ctx.Step(`^the live server for "([^"]*)"$`, f.theLiveServerFor)
+	ctx.Step(`^a shared colony with a blinker$`, f.aSharedColonyWithABlinker)
+	ctx.Step(`^a crawler fetches "([^"]*)"$`, f.aCrawlerFetches)
+	ctx.Step(`^a crawler fetches the link in (\w+) form$`, f.aCrawlerFetchesTheLinkInForm)
+	ctx.Step(`^a crawler fetches the link to a (\d+)x(\d+) colony$`, f.aCrawlerFetchesTheLinkToAColony)
+	ctx.Step(`^the page should have the meta tag "([^"]*)" with "([^"]*)"$`, f.thePageShouldHaveTheMetaTagWith)
+	ctx.Step(`^the page should have the meta tag "([^"]*)" for the thumbnail$`, f.thePageShouldHaveTheMetaTagForTheThumbnail)
+	ctx.Step(`^the meta tag "([^"]*)" should not point at a thumbnail$`, f.theMetaTagShouldNotPointAtAThumbnail)
+}
+
+func TestPreviewFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "preview",
+		ScenarioInitializer: InitializePreviewScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/preview.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
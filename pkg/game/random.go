@@ -0,0 +1,115 @@
+package game
+
+import (
+	"fmt"
+	"github.com/enescakir/emoji"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/model"
+	"math/rand/v2"
+	"strconv"
+)
+
+// symmetries are the symmetries offered for random soups.
+var symmetries = []model.Symmetry{model.C1, model.C2, model.C4, model.D2, model.D4, model.D8}
+
+// randomize fills the colony with a soup made with the options in the form, or reports why it couldn't be.
+func (g *Game) randomize(ctx app.Context) {
+	if err := g.colony.Randomize(g.random); err != nil {
+		g.randomError = err.Error()
+		return
+	}
+	g.randomError = ""
+	g.saveState(ctx)
+}
+
+// insertRandom fills the colony with a soup from a new seed, keeping the other options in the form.
+func (g *Game) insertRandom(ctx app.Context) {
+	g.random.Seed = uint64(rand.Uint32())
+	g.randomize(ctx)
+}
+
+// randomInput renders a numeric field of the random soup form with its label, calling set with what was typed.
+func (g *Game) randomInput(id, label, value string, set func(v int)) app.UI {
+	return app.Span().Body(
+		app.Label().Text(label).For(id),
+		app.Input().
+			Type("number").
+			ID(id).
+			Min("0").
+			Style("width", "5em").
+			Value(value).
+			OnChange(func(ctx app.Context, e app.Event) {
+				if v, err := strconv.Atoi(e.Get("target").Get("value").String()); err == nil {
+					set(v)
+				}
+			}),
+	)
+}
+
+// renderRandom renders the options of the random soup, which recreate the same soup whenever they are applied.
+func (g *Game) renderRandom() app.UI {
+	l := g.localizer
+	return app.Details().Body(
+		app.Summary().Text(l.T("random-soup")),
+		app.Div().Body(
+			app.Label().Text(l.T("seed")).For("random-seed"),
+			app.Input().
+				Type("text").
+				ID("random-seed").
+				Size(20).
+				Value(strconv.FormatUint(g.random.Seed, 10)).
+				OnChange(func(ctx app.Context, e app.Event) {
+					seed, err := strconv.ParseUint(e.Get("target").Get("value").String(), 10, 64)
+					if err != nil {
+						g.randomError = l.T("invalid-seed", err)
+						return
+					}
+					g.random.Seed = seed
+				}),
+			app.Label().Text(" "+l.T("density")).For("random-density"),
+			app.Input().
+				Type("range").
+				ID("random-density").
+				Min("0").
+				Max("100").
+				Value(fmt.Sprintf("%.0f", g.random.Density*100)).
+				OnInput(func(ctx app.Context, e app.Event) {
+					if percent, err := strconv.Atoi(e.Get("target").Get("value").String()); err == nil {
+						g.random.Density = float64(percent) / 100
+					}
+				}),
+			app.Span().Style("margin-left", "8px").Textf("%.0f%%", g.random.Density*100),
+			app.Label().Text(" "+l.T("symmetry")).For("random-symmetry"),
+			app.Select().
+				ID("random-symmetry").
+				OnChange(func(ctx app.Context, e app.Event) {
+					if s, err := model.ParseSymmetry(e.Get("target").Get("value").String()); err == nil {
+						g.random.Symmetry = s
+					}
+				}).
+				Body(
+					app.Range(symmetries).Slice(func(i int) app.UI {
+						return app.Option().
+							Value(symmetries[i].String()).
+							Selected(symmetries[i] == g.random.Symmetry).
+							Text(symmetries[i].String())
+					}),
+				),
+		),
+		app.Div().Body(
+			g.randomInput("random-x", l.T("region-left"), strconv.Itoa(g.random.X), func(v int) { g.random.X = v }),
+			g.randomInput("random-y", " "+l.T("region-top"), strconv.Itoa(g.random.Y), func(v int) { g.random.Y = v }),
+			g.randomInput("random-width", " "+l.T("region-width"), strconv.Itoa(g.random.Width), func(v int) { g.random.Width = v }),
+			g.randomInput("random-height", " "+l.T("region-height"), strconv.Itoa(g.random.Height), func(v int) { g.random.Height = v }),
+			app.Span().Text(" "+l.T("region-edge")),
+		),
+		app.Button().Textf("%s %s", emoji.GameDie, l.T("recreate")).Title(l.T("recreate-title")).OnClick(func(ctx app.Context, e app.Event) {
+			if g.ticker == nil {
+				g.randomize(ctx)
+			}
+		}),
+		app.If(g.randomError != "", func() app.UI {
+			return app.Span().Class("error").Text(g.randomError)
+		}),
+	)
+}
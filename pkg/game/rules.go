@@ -0,0 +1,131 @@
+package game
+
+import (
+	"fmt"
+	"github.com/enescakir/emoji"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"image/color"
+	"strings"
+)
+
+// rulePreset is a well known rule offered in the rule picker.
+type rulePreset struct {
+	name string
+	rule string
+}
+
+var rulePresets = []rulePreset{
+	{name: "Life", rule: "B3/S23"},
+	{name: "HighLife", rule: "B36/S23"},
+	{name: "Seeds", rule: "B2/S"},
+	{name: "Day & Night", rule: "B3678/S34678"},
+	{name: "Brian's Brain", rule: "B2/S/C3"},
+	{name: "Star Wars", rule: "B2/S345/C4"},
+	{name: "tlife", rule: "B3/S2-i34q"},
+	{name: "B2-a/S12", rule: "B2-a/S12"},
+	{name: "Hexagonal B2/S34H", rule: "B2/S34H"},
+	{name: "Von Neumann B1/S012V", rule: "B1/S012V"},
+	{name: "Billiard Ball Machine", rule: "MS,D0;8;4;3;2;5;9;7;1;6;10;11;12;13;14;15"},
+	{name: "Critters", rule: "MS,D15;14;13;3;11;5;6;1;7;9;10;2;12;4;8;0"},
+	{name: "Tron", rule: "MS,D15;1;2;3;4;5;6;7;8;9;10;11;12;13;14;0"},
+	{name: "Bosco's Rule", rule: "R5,C0,M1,S34..58,B34..45,NM"},
+	{name: "Majority", rule: "R4,C0,M1,S41..81,B41..81,NM"},
+}
+
+// bundledRules are the names of the rule tables shipped with the program.
+var bundledRules = model.BundledRules()
+
+// setRule parses a rulestring and runs the colony under it, or reports why it couldn't be parsed.
+func (g *Game) setRule(ctx app.Context, s string) {
+	rule, err := model.ParseRule(s)
+	if err != nil {
+		g.ruleError = err.Error()
+		return
+	}
+	g.ruleError = ""
+	g.colony.SetRule(rule)
+	g.saveState(ctx)
+}
+
+// cellStyles returns the inline background colour for cells in states beyond alive, which fade towards dead,
+// for every state of rules that choose their own colours, and for every cell in display modes other than states.
+func (g *Game) cellStyles(x, y int) map[string]string {
+	state, rule := g.colony.State(x, y), g.colony.Rule()
+	if _, ok := rule.(*model.RuleTable); !ok && state < 2 && g.display == render.States {
+		return nil
+	}
+	r, gr, b, _ := render.ShadeColor(g.renderOptions(), g.colony, x, y).RGBA()
+	return map[string]string{
+		"background-color": cssColor(color.RGBA{R: uint8(r >> 8), G: uint8(gr >> 8), B: uint8(b >> 8)}),
+	}
+}
+
+// cssColor formats a colour for a CSS property.
+func cssColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// renderRule renders the rulestring field and the picker of well known rules.
+func (g *Game) renderRule() app.UI {
+	l := g.localizer
+	current := g.colony.Rule().String()
+	return app.Div().Body(
+		app.Label().Text(l.T("rule")).For("rule-input"),
+		app.Input().
+			Type("text").
+			ID("rule-input").
+			Size(32).
+			Value(current).
+			Aria("label", l.T("rule-label")).
+			OnChange(func(ctx app.Context, e app.Event) {
+				if g.ticker == nil {
+					g.setRule(ctx, e.Get("target").Get("value").String())
+				}
+			}),
+		app.Select().
+			Aria("label", l.T("rule-presets-label")).
+			OnChange(func(ctx app.Context, e app.Event) {
+				if value := e.Get("target").Get("value").String(); value != "" && g.ticker == nil {
+					g.setRule(ctx, value)
+				}
+			}).
+			Body(
+				app.Option().Value("").Text(l.T("rule-presets")),
+				app.Range(rulePresets).Slice(func(i int) app.UI {
+					return app.Option().
+						Value(rulePresets[i].rule).
+						Selected(rulePresets[i].rule == current).
+						Textf("%s (%s)", rulePresets[i].name, rulePresets[i].rule)
+				}),
+				app.Range(bundledRules).Slice(func(i int) app.UI {
+					return app.Option().
+						Value(bundledRules[i]).
+						Selected(bundledRules[i] == current).
+						Text(l.T("rule-bundled", bundledRules[i]))
+				}),
+			),
+		app.If(g.ruleError != "", func() app.UI {
+			return app.Span().Class("error").Text(g.ruleError)
+		}),
+		app.Details().Body(
+			app.Summary().Text(l.T("rule-table")),
+			app.Textarea().
+				Class("rule-table").
+				Rows(12).
+				Cols(60).
+				Placeholder(l.T("rule-table-placeholder")).
+				Aria("label", l.T("rule-table-label")).
+				Text(g.ruleSource).
+				OnChange(func(ctx app.Context, e app.Event) {
+					g.ruleSource = e.Get("target").Get("value").String()
+				}),
+			app.Button().Textf("%s %s", emoji.Scroll, l.T("rule-table-load")).OnClick(func(ctx app.Context, e app.Event) {
+				if g.ticker == nil && strings.TrimSpace(g.ruleSource) != "" {
+					g.setRule(ctx, g.ruleSource)
+				}
+			}),
+		),
+	)
+}
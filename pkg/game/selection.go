@@ -0,0 +1,63 @@
+package game
+
+import (
+	"github.com/richardwooding/gameoflife/model"
+)
+
+// Selection is a rectangle of cells picked out with the select tool. A selection with no width is empty.
+type Selection struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Empty reports whether no cells are selected.
+func (s Selection) Empty() bool {
+	return s.Width <= 0 || s.Height <= 0
+}
+
+// Region returns the selected cells in a colony of their own.
+func (s Selection) Region(c *model.Colony) *model.Colony {
+	return c.Region(s.X, s.Y, s.Width, s.Height)
+}
+
+// Copy returns the occupied cells of the selection as a pattern for the paste buffer.
+func (s Selection) Copy(c *model.Colony) Pattern {
+	return NewPattern("Selection", *s.Region(c).Cells())
+}
+
+// Clear kills the selected cells.
+func (s Selection) Clear(c *model.Colony) {
+	c.Clear(s.X, s.Y, s.Width, s.Height)
+}
+
+// ClearOutside kills every cell that isn't selected.
+func (s Selection) ClearOutside(c *model.Colony) {
+	c.ClearOutside(s.X, s.Y, s.Width, s.Height)
+}
+
+// Transform replaces the selected cells with the result of transforming them, such as Rotated, keeping the top
+// left corner where it is. It returns the selection of the transformed cells, clipped to the colony.
+func (s Selection) Transform(c *model.Colony, transform func(*model.Colony) *model.Colony) Selection {
+	t := transform(s.Region(c))
+	s.Clear(c)
+	c.SetRegion(t, s.X, s.Y)
+	return clip(c, Selection{X: s.X, Y: s.Y, Width: t.Width(), Height: t.Height()})
+}
+
+// Paste stamps a pattern onto the colony with its top left corner at (x, y), keeping the cells already alive,
+// and returns the selection of the pasted cells, clipped to the colony.
+func Paste(c *model.Colony, p Pattern, x, y int) Selection {
+	p.Stamp(c.Cells(), x, y)
+	w, h := p.Size()
+	return clip(c, Selection{X: x, Y: y, Width: w, Height: h})
+}
+
+// clip returns the part of the selection inside the colony.
+func clip(c *model.Colony, s Selection) Selection {
+	x0, y0 := max(s.X, 0), max(s.Y, 0)
+	x1, y1 := min(s.X+s.Width, c.Width()), min(s.Y+s.Height, c.Height())
+	if x1 <= x0 || y1 <= y0 {
+		return Selection{}
+	}
+	return Selection{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"github.com/richardwooding/gameoflife/model"
+	"io"
+)
+
+type exported struct {
+	Cells      [][]bool
+	Rule       string               // Rulestring or rule table, empty for Conway's Life
+	States     [][]uint8            // Cell states, only for rules with more than two states
+	Generation int64                // Generation count, which block rules need to know how the colony is partitioned
+	Random     *model.RandomOptions // Options of the soup the colony was last filled with
+}
+
+const (
+	MaxStateSize = 1024    // Largest width or height of a colony a shared state may carry
+	maxInflated  = 8 << 20 // Most bytes a shared state may inflate to
+)
+
+var InvalidState = errors.New("invalid state")
+
+// rectangular reports whether a grid has h rows of w cells.
+func rectangular[T any](grid [][]T, w, h int) bool {
+	if len(grid) != h {
+		return false
+	}
+	for _, row := range grid {
+		if len(row) != w {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeState decodes a colony from the base64-encoded state stored in a shared URL. States come from anyone's
+// links, so colonies larger than MaxStateSize on either side and grids that aren't rectangular are refused.
+func DecodeState(state string) (*model.Colony, error) {
+	exp := &exported{}
+	b, err := base64.RawURLEncoding.DecodeString(state)
+	if err != nil {
+		return nil, err
+	}
+	buff := bytes.NewBuffer(b)
+	reader := io.LimitReader(flate.NewReader(buff), maxInflated)
+
+	dec := gob.NewDecoder(reader)
+	if err := dec.Decode(exp); err != nil {
+		return nil, err
+	}
+	if len(exp.Cells) == 0 || len(exp.Cells[0]) == 0 {
+		return nil, InvalidState
+	}
+	w, h := len(exp.Cells[0]), len(exp.Cells)
+	if w > MaxStateSize || h > MaxStateSize || !rectangular(exp.Cells, w, h) {
+		return nil, InvalidState
+	}
+	if exp.States != nil && !rectangular(exp.States, w, h) {
+		return nil, InvalidState
+	}
+	colony := model.NewColony(w, h)
+	colony.SetCells(exp.Cells)
+	colony.SetGeneration(exp.Generation)
+	if exp.Random != nil {
+		colony.SetRandomOptions(*exp.Random)
+	}
+	if exp.Rule != "" {
+		rule, err := model.ParseRule(exp.Rule)
+		if err != nil {
+			return nil, err
+		}
+		colony.SetRule(rule)
+		if exp.States != nil {
+			colony.SetStateGrid(exp.States)
+		}
+	}
+	return colony, nil
+}
+
+// EncodeState encodes the colony as a compact base64 string suitable for a URL.
+func EncodeState(colony *model.Colony) string {
+	exp := exported{Cells: *colony.Cells(), Generation: colony.GetGeneration()}
+	if random, ok := colony.RandomOptions(); ok {
+		exp.Random = &random
+	}
+	if rule := colony.Rule(); rule.String() != model.Life.String() {
+		exp.Rule = rulestring(rule)
+		if rule.States() > 2 {
+			exp.States = colony.StateGrid()
+		}
+	}
+	var buff bytes.Buffer
+	writer, _ := flate.NewWriter(&buff, flate.BestCompression)
+	enc := gob.NewEncoder(writer)
+	_ = enc.Encode(exp)
+	_ = writer.Flush()
+	return base64.RawURLEncoding.EncodeToString(buff.Bytes())
+}
+
+// rulestring returns the text ParseRule needs to recreate a rule. Rule tables that aren't bundled with the
+// program are kept in full, as are those named like another rule.
+func rulestring(rule model.Rule) string {
+	if table, ok := rule.(*model.RuleTable); ok {
+		parsed, err := model.ParseRule(table.Name)
+		if err != nil {
+			return table.Source
+		}
+		if bundled, ok := parsed.(*model.RuleTable); !ok || bundled.Source != table.Source {
+			return table.Source
+		}
+	}
+	return rule.String()
+}
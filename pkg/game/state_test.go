@@ -0,0 +1,213 @@
+package game
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"testing"
+)
+
+type stateFeature struct {
+	colony  *model.Colony
+	decoded *model.Colony
+	state   string
+	err     error
+}
+
+// grid returns a grid described as "WxH", "ragged" or "empty", or nil for "absent".
+func grid[T any](s string) ([][]T, error) {
+	switch s {
+	case "absent":
+		return nil, nil
+	case "empty":
+		return [][]T{}, nil
+	case "ragged":
+		return [][]T{make([]T, 4), make([]T, 3), make([]T, 4), make([]T, 4)}, nil
+	}
+	var w, h int
+	if _, err := fmt.Sscanf(s, "%dx%d", &w, &h); err != nil {
+		return nil, err
+	}
+	rows := make([][]T, h)
+	for y := range rows {
+		rows[y] = make([]T, w)
+	}
+	return rows, nil
+}
+
+// encode encodes a state the way EncodeState does, without checking it makes sense.
+func encode(exp exported) (string, error) {
+	var buff bytes.Buffer
+	writer, err := flate.NewWriter(&buff, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if err := gob.NewEncoder(writer).Encode(exp); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buff.Bytes()), nil
+}
+
+func (f *stateFeature) aStateWhoseCellsAreAndWhoseStatesAre(cells, states string) error {
+	exp := exported{Rule: "B2/S/C3"}
+	var err error
+	if exp.Cells, err = grid[bool](cells); err != nil {
+		return err
+	}
+	if exp.States, err = grid[uint8](states); err != nil {
+		return err
+	}
+	f.state, err = encode(exp)
+	return err
+}
+
+func (f *stateFeature) theStateIsDecoded() error {
+	f.decoded, f.err = DecodeState(f.state)
+	return nil
+}
+
+func (f *stateFeature) itShouldBeRefusedAsInvalid() error {
+	if !errors.Is(f.err, InvalidState) {
+		return fmt.Errorf("expected the state to be refused as invalid, got %v", f.err)
+	}
+	return nil
+}
+
+func (f *stateFeature) aColonyUnderTheRule(w, h int, s string) error {
+	rule, err := model.ParseRule(s)
+	if err != nil {
+		return err
+	}
+	f.colony = model.NewColony(w, h)
+	f.colony.SetRule(rule)
+	return nil
+}
+
+func (f *stateFeature) aColonyUnderTheRuleTable(w, h int, source *godog.DocString) error {
+	return f.aColonyUnderTheRule(w, h, source.Content)
+}
+
+func (f *stateFeature) theCellAtIsInState(x, y, state int) error {
+	f.colony.SetState(x, y, uint8(state))
+	return nil
+}
+
+func (f *stateFeature) theColonyIsEncodedAndDecoded() error {
+	var err error
+	f.decoded, err = DecodeState(EncodeState(f.colony))
+	return err
+}
+
+func (f *stateFeature) theDecodedColonyShouldRunUnderTheRule(s string) error {
+	if got := f.decoded.Rule().String(); got != s {
+		return fmt.Errorf("expected rule %q, got %q", s, got)
+	}
+	return nil
+}
+
+func (f *stateFeature) theDecodedCellShouldBeInState(x, y, state int) error {
+	if got := f.decoded.State(x, y); int(got) != state {
+		return fmt.Errorf("expected cell (%d,%d) to be in state %d, got %d", x, y, state, got)
+	}
+	return nil
+}
+
+func (f *stateFeature) generationsAreComputed(n int) error {
+	for i := 0; i < n; i++ {
+		f.colony.Generate()
+	}
+	return nil
+}
+
+func (f *stateFeature) theDecodedColonyShouldBeAtGeneration(generation int64) error {
+	if got := f.decoded.GetGeneration(); got != generation {
+		return fmt.Errorf("expected generation %d, got %d", generation, got)
+	}
+	return nil
+}
+
+func (f *stateFeature) theDecodedColonyShouldStepBackToTheCellAt(x, y int) error {
+	for f.decoded.GetGeneration() > 0 {
+		if err := f.decoded.GenerateBackwards(); err != nil {
+			return err
+		}
+	}
+	return f.theDecodedCellShouldBeInState(x, y, 1)
+}
+
+func (f *stateFeature) itIsRandomised(seed int, density float64, symmetry string, w, h, x, y int) error {
+	s, err := model.ParseSymmetry(symmetry)
+	if err != nil {
+		return err
+	}
+	return f.colony.Randomize(model.RandomOptions{Seed: uint64(seed), Density: density, Symmetry: s, X: x, Y: y, Width: w, Height: h})
+}
+
+func (f *stateFeature) theDecodedColonyShouldRemember(seed int, density float64, symmetry string, w, h, x, y int) error {
+	o, ok := f.decoded.RandomOptions()
+	if !ok {
+		return fmt.Errorf("the decoded colony remembers no soup")
+	}
+	if o.Seed != uint64(seed) || o.Density != density || o.Symmetry.String() != symmetry || o.Width != w || o.Height != h || o.X != x || o.Y != y {
+		return fmt.Errorf("unexpected options %+v", o)
+	}
+	return nil
+}
+
+func (f *stateFeature) randomisingABlankColonyWithTheDecodedOptionsShouldGiveTheSameCells() error {
+	o, _ := f.decoded.RandomOptions()
+	blank := model.NewColony(f.decoded.Width(), f.decoded.Height())
+	if err := blank.Randomize(o); err != nil {
+		return err
+	}
+	for y := 0; y < blank.Height(); y++ {
+		for x := 0; x < blank.Width(); x++ {
+			if blank.IsAlive(x, y) != f.colony.IsAlive(x, y) {
+				return fmt.Errorf("cell (%d,%d) differs", x, y)
+			}
+		}
+	}
+	return nil
+}
+
+func InitializeStateScenario(ctx *godog.ScenarioContext) {
+	f := &stateFeature{}
+	ctx.Step(`^a (\d+)x(\d+) colony under the rule "([^"]*)"$`, f.aColonyUnderTheRule)
+	ctx.Step(`^a (\d+)x(\d+) colony under the rule table$`, f.aColonyUnderTheRuleTable)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) is in state (\d+)$`, f.theCellAtIsInState)
+	ctx.Step(`^(\d+) generations are computed$`, f.generationsAreComputed)
+	ctx.Step(`^it is randomised with seed (\d+) at density ([\d.]+) with (\w+) symmetry in an? (\d+)x(\d+) region at \((\d+),(\d+)\)$`, f.itIsRandomised)
+	ctx.Step(`^the decoded colony should remember seed (\d+) at density ([\d.]+) with (\w+) symmetry in an? (\d+)x(\d+) region at \((\d+),(\d+)\)$`, f.theDecodedColonyShouldRemember)
+	ctx.Step(`^randomising a blank colony with the decoded options should give the same cells$`, f.randomisingABlankColonyWithTheDecodedOptionsShouldGiveTheSameCells)
+	ctx.Step(`^the colony is encoded and decoded$`, f.theColonyIsEncodedAndDecoded)
+	ctx.Step(`^the decoded colony should be at generation (\d+)$`, f.theDecodedColonyShouldBeAtGeneration)
+	ctx.Step(`^the decoded colony should step back to the cell at \((\d+),(\d+)\)$`, f.theDecodedColonyShouldStepBackToTheCellAt)
+	ctx.Step(`^the decoded colony should run under the rule "([^"]*)"$`, f.theDecodedColonyShouldRunUnderTheRule)
+	ctx.Step(`^a state whose cells are (\S+) and whose states are (\S+)$`, f.aStateWhoseCellsAreAndWhoseStatesAre)
+	ctx.Step(`^the state is decoded$`, f.theStateIsDecoded)
+	ctx.Step(`^it should be refused as invalid$`, f.itShouldBeRefusedAsInvalid)
+	ctx.Step(`^the decoded cell at \((\d+),(\d+)\) should be in state (\d+)$`, f.theDecodedCellShouldBeInState)
+}
+
+func TestStateFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "state",
+		ScenarioInitializer: InitializeStateScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/state.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/pkg/theme"
+)
+
+const (
+	themeKey   = "theme"                        // Local storage key of the theme settings
+	darkScheme = "(prefers-color-scheme: dark)" // Media query of the system's dark mode
+)
+
+// themeColours are the JSON fields of the colours of the custom theme that can be edited.
+var themeColours = []string{"alive", "dead", "grid", "history"}
+
+// colourMessage returns the key of the message naming a colour of the custom theme.
+func colourMessage(field string) string {
+	return "colour-" + field
+}
+
+// themeMessage returns the key of the message naming a theme, theme.Auto or theme.Custom.
+func themeMessage(name string) string {
+	return "theme-" + name
+}
+
+// currentTheme returns the theme the settings choose under the system's preference.
+func (g *Game) currentTheme() theme.Theme {
+	return g.theme.Resolve(g.prefersDark)
+}
+
+// loadTheme restores the theme settings from local storage, applies them and follows changes of the system's
+// light or dark preference.
+func (g *Game) loadTheme(ctx app.Context) {
+	if err := ctx.LocalStorage().Get(themeKey, &g.theme); err != nil {
+		app.Log(err)
+	}
+	if g.theme.Theme == "" {
+		g.theme.Theme = theme.Auto
+	}
+	g.scheme = app.Window().Call("matchMedia", darkScheme)
+	g.prefersDark = g.scheme.Get("matches").Bool()
+	g.schemeChanged = app.FuncOf(func(this app.Value, args []app.Value) any {
+		dark := args[0].Get("matches").Bool()
+		ctx.Dispatch(func(ctx app.Context) {
+			g.prefersDark = dark
+			g.applyTheme()
+		})
+		return nil
+	})
+	g.scheme.Call("addEventListener", "change", g.schemeChanged)
+	g.applyTheme()
+}
+
+// stopFollowingScheme stops listening for changes of the system's light or dark preference.
+func (g *Game) stopFollowingScheme() {
+	if g.schemeChanged != nil {
+		g.scheme.Call("removeEventListener", "change", g.schemeChanged)
+		g.schemeChanged.Release()
+		g.schemeChanged = nil
+	}
+}
+
+// applyTheme sets the custom properties the style sheet takes its colours from on the document.
+func (g *Game) applyTheme() {
+	style := app.Window().Get("document").Get("documentElement").Get("style")
+	for name, value := range g.currentTheme().Variables() {
+		style.Call("setProperty", name, value)
+	}
+}
+
+// saveTheme applies the theme settings and keeps them in local storage.
+func (g *Game) saveTheme(ctx app.Context) {
+	if err := ctx.LocalStorage().Set(themeKey, g.theme); err != nil {
+		app.Log(err)
+	}
+	g.applyTheme()
+}
+
+// setTheme chooses a built-in theme, theme.Auto or theme.Custom.
+func (g *Game) setTheme(ctx app.Context, name string) {
+	g.theme.Theme = name
+	g.saveTheme(ctx)
+}
+
+// editTheme changes a colour of the custom theme, starting it from the current theme.
+func (g *Game) editTheme(ctx app.Context, field, value string) {
+	if err := g.theme.Edit(g.currentTheme(), field, value); err != nil {
+		app.Log(err)
+		return
+	}
+	g.saveTheme(ctx)
+}
+
+// renderTheme renders the picker of themes and, for the custom theme, its colour editor.
+func (g *Game) renderTheme() app.UI {
+	current := g.currentTheme()
+	option := func(name string) app.UI {
+		return app.Option().Value(name).Selected(name == g.theme.Theme).Text(g.localizer.T(themeMessage(name)))
+	}
+	return app.Div().Body(
+		app.Label().Text(g.localizer.T("theme")).For("theme-select"),
+		app.Select().
+			ID("theme-select").
+			OnChange(func(ctx app.Context, e app.Event) {
+				g.setTheme(ctx, e.Get("target").Get("value").String())
+			}).
+			Body(
+				option(theme.Auto),
+				app.Range(theme.Themes).Slice(func(i int) app.UI {
+					return option(theme.Themes[i].Name)
+				}),
+				option(theme.Custom),
+			),
+		app.If(g.theme.Theme == theme.Custom, func() app.UI {
+			colours := map[string]theme.Colour{
+				"alive": current.Alive, "dead": current.Dead, "grid": current.Grid, "history": current.History,
+			}
+			return app.Span().Class("theme-colours").Body(
+				app.Range(themeColours).Slice(func(i int) app.UI {
+					field := themeColours[i]
+					return app.Label().Body(
+						app.Text(g.localizer.T(colourMessage(field))+" "),
+						app.Input().
+							Type("color").
+							Value(colours[field].String()).
+							OnInput(func(ctx app.Context, e app.Event) {
+								g.editTheme(ctx, field, e.Get("target").Get("value").String())
+							}),
+					)
+				}),
+			)
+		}),
+	)
+}
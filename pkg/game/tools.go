@@ -0,0 +1,105 @@
+package game
+
+import (
+	"github.com/enescakir/emoji"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"strings"
+)
+
+// startStroke starts drawing with the selected tool at (x, y).
+func (g *Game) startStroke(x, y int) {
+	if g.ticker != nil {
+		return
+	}
+	g.clearAnalysis()
+	g.stroke = StartStroke(g.colony, g.tool, x, y)
+}
+
+// continueStroke extends the stroke in progress, if any, to (x, y).
+func (g *Game) continueStroke(x, y int) {
+	if g.stroke != nil {
+		g.stroke.MoveTo(x, y)
+	}
+}
+
+// endStroke finishes the stroke in progress when the mouse is released or leaves the board, saving the colony
+// once for the whole stroke.
+func (g *Game) endStroke(ctx app.Context, e app.Event) {
+	if g.stroke == nil {
+		return
+	}
+	stroke := g.stroke
+	g.stroke = nil
+	if stroke.tool == Select {
+		g.selection = stroke.Selection()
+		return
+	}
+	g.saveState(ctx)
+}
+
+// undo returns the colony to the state before the last edit.
+func (g *Game) undo(ctx app.Context) {
+	if state, ok := g.history.Undo(); ok {
+		g.restoreState(ctx, state)
+	}
+}
+
+// redo reapplies the edit last undone.
+func (g *Game) redo(ctx app.Context) {
+	if state, ok := g.history.Redo(); ok {
+		g.restoreState(ctx, state)
+	}
+}
+
+// restoreState shows a state from the history without recording it again.
+func (g *Game) restoreState(ctx app.Context, state string) {
+	colony, err := DecodeState(state)
+	if err != nil {
+		app.Log(err)
+		return
+	}
+	g.colony = colony
+	g.clearAnalysis()
+	g.replaceURL(ctx, state)
+}
+
+// toolMessage returns the key of the message naming a drawing tool, such as "tool-filled-rectangle".
+func toolMessage(t Tool) string {
+	return "tool-" + strings.ReplaceAll(strings.ToLower(t.String()), " ", "-")
+}
+
+// toolLabel returns the name a drawing tool is offered under.
+func toolLabel(l i18n.Localizer, t Tool) string {
+	return l.T(toolMessage(t))
+}
+
+// renderTools renders the palette of drawing tools with the undo and redo buttons.
+func (g *Game) renderTools() app.UI {
+	return app.Div().Body(
+		app.Range(Tools).Slice(func(i int) app.UI {
+			tool := Tools[i]
+			button := app.Button().Class("tool")
+			if tool == g.tool {
+				button = button.Class("selected")
+			}
+			return button.
+				Title(toolLabel(g.localizer, tool)).
+				Aria("pressed", tool == g.tool).
+				Textf("%s %s", tool.Icon(), toolLabel(g.localizer, tool)).
+				OnClick(func(ctx app.Context, e app.Event) {
+					g.tool = tool
+				})
+		}),
+		app.Button().Text(emoji.RightArrowCurvingLeft).Title(g.localizer.T("undo")).Disabled(!g.history.CanUndo()).OnClick(func(ctx app.Context, e app.Event) {
+			if g.ticker == nil {
+				g.undo(ctx)
+			}
+		}),
+		app.Button().Text(emoji.LeftArrowCurvingRight).Title(g.localizer.T("redo")).Disabled(!g.history.CanRedo()).OnClick(func(ctx app.Context, e app.Event) {
+			if g.ticker == nil {
+				g.redo(ctx)
+			}
+		}),
+	)
+}
@@ -0,0 +1,233 @@
+package game
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/analysis"
+	"github.com/richardwooding/gameoflife/pkg/i18n"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"github.com/richardwooding/gameoflife/pkg/theme"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// messageCalls matches the calls that format a message with a literal key, such as l.T("title") or
+// m.Localizer.N("still-life", pop, ...).
+var messageCalls = regexp.MustCompile(`\.(T|N)\("([^"]+)"`)
+
+// textCalls matches the calls and fields that put a literal into the interface, such as .Text("Discard"),
+// Aria("label", "Command") or a command's Name: "Undo".
+var textCalls = regexp.MustCompile(`(?:\.(?:Text|Textf|Title|Placeholder)\(|Aria\("label", |Name:\s*)("(?:[^"\\]|\\.)*")`)
+
+// verbs matches the fmt verbs of a format string, which are all a literal may hold besides punctuation.
+var verbs = regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z%]`)
+
+// words matches a letter in any script.
+var words = regexp.MustCompile(`\pL`)
+
+type translationFeature struct {
+	keys         []string
+	literals     []string
+	colony       *model.Colony
+	localizer    i18n.Localizer
+	monitor      *Monitor
+	announcement string
+}
+
+func (f *translationFeature) iCollectTheMessagesTheGamesSourceAsksFor() error {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		for _, m := range messageCalls.FindAllStringSubmatch(string(src), -1) {
+			if m[1] == "N" {
+				// Every language has the English forms of a plural message or forms of its own, checked in i18n.
+				m[2] += ".other"
+			}
+			f.keys = append(f.keys, m[2])
+		}
+	}
+	return nil
+}
+
+func (f *translationFeature) iCollectTheTextTheGamesSourceWritesIntoTheInterface() error {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		for _, m := range textCalls.FindAllStringSubmatch(string(src), -1) {
+			text, err := strconv.Unquote(m[1])
+			if err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			if words.MatchString(verbs.ReplaceAllString(text, "")) {
+				f.literals = append(f.literals, fmt.Sprintf("%s: %q", file, text))
+			}
+		}
+	}
+	return nil
+}
+
+func (f *translationFeature) noTextShouldBeWrittenWithoutACatalogue() error {
+	if len(f.literals) > 0 {
+		return fmt.Errorf("text written without a catalogue: %s", strings.Join(f.literals, ", "))
+	}
+	return nil
+}
+
+func (f *translationFeature) thereShouldBeSomeMessages() error {
+	if len(f.keys) == 0 {
+		return fmt.Errorf("expected the source to ask for messages")
+	}
+	return nil
+}
+
+func (f *translationFeature) everyMessageShouldBeInTheEnglishCatalogue() error {
+	var missing []string
+	for _, key := range f.keys {
+		if _, ok := i18n.English.Messages[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the English catalogue is missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (f *translationFeature) iCollectTheNamesOfTheToolsDisplayModesThemesAndKindsOfObject() error {
+	for _, tool := range Tools {
+		f.keys = append(f.keys, toolMessage(tool))
+	}
+	for _, m := range render.Modes {
+		f.keys = append(f.keys, displayMessage(m))
+	}
+	f.keys = append(f.keys, themeMessage(theme.Auto), themeMessage(theme.Custom))
+	for _, t := range theme.Themes {
+		f.keys = append(f.keys, themeMessage(t.Name))
+	}
+	for _, field := range themeColours {
+		f.keys = append(f.keys, colourMessage(field))
+	}
+	for _, k := range []analysis.Kind{analysis.StillLife, analysis.Oscillator, analysis.Spaceship, analysis.Unknown} {
+		f.keys = append(f.keys, kindMessage(k))
+	}
+	return nil
+}
+
+func (f *translationFeature) everyMessageShouldBeInEveryCatalogue() error {
+	for _, c := range i18n.Catalogues {
+		for _, key := range f.keys {
+			if _, ok := c.Messages[key]; !ok {
+				return fmt.Errorf("the %s catalogue is missing %s", c.Locale, key)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *translationFeature) aColonyWithCellsAt(w, h int, cells string) error {
+	f.colony = model.NewColony(w, h)
+	for _, cell := range strings.Fields(cells) {
+		xs, ys, _ := strings.Cut(cell, ",")
+		x, errX := strconv.Atoi(xs)
+		y, errY := strconv.Atoi(ys)
+		if errX != nil || errY != nil {
+			return fmt.Errorf("invalid cell %q", cell)
+		}
+		f.colony.SetState(x, y, 1)
+	}
+	return nil
+}
+
+func (f *translationFeature) theInterfaceIsIn(locale string) error {
+	c, ok := i18n.Lookup(locale)
+	if !ok {
+		return fmt.Errorf("no catalogue for %q", locale)
+	}
+	f.localizer = i18n.For(c)
+	return nil
+}
+
+func (f *translationFeature) theLabelOfShouldRead(x, y int, label string) error {
+	if got := CellLabel(f.localizer, f.colony, x, y); got != label {
+		return fmt.Errorf("expected the label %q, got %q", label, got)
+	}
+	return nil
+}
+
+func (f *translationFeature) aMonitorAnnouncingIn(locale string) error {
+	if err := f.theInterfaceIsIn(locale); err != nil {
+		return err
+	}
+	f.monitor = NewMonitor()
+	f.monitor.Localizer = f.localizer
+	return nil
+}
+
+func (f *translationFeature) aGenerationPasses() error {
+	f.colony.Generate()
+	f.announcement, _ = f.monitor.Observe(f.colony, time.Unix(0, 0))
+	return nil
+}
+
+func (f *translationFeature) shouldBeAnnouncedInTheLanguageOfTheInterface(message string) error {
+	if f.announcement != message {
+		return fmt.Errorf("expected %q to be announced, got %q", message, f.announcement)
+	}
+	return nil
+}
+
+func InitializeTranslationScenario(ctx *godog.ScenarioContext) {
+	f := &translationFeature{}
+	ctx.Step(`^I collect the messages the game's source asks for$`, f.iCollectTheMessagesTheGamesSourceAsksFor)
+	ctx.Step(`^there should be some messages$`, f.thereShouldBeSomeMessages)
+	ctx.Step(`^every message should be in the English catalogue$`, f.everyMessageShouldBeInTheEnglishCatalogue)
+	ctx.Step(`^I collect the text the game's source writes into the interface$`, f.iCollectTheTextTheGamesSourceWritesIntoTheInterface)
+	ctx.Step(`^no text should be written without a catalogue$`, f.noTextShouldBeWrittenWithoutACatalogue)
+	ctx.Step(`^I collect the names of the tools, display modes, themes and kinds of object$`, f.iCollectTheNamesOfTheToolsDisplayModesThemesAndKindsOfObject)
+	ctx.Step(`^every message should be in every catalogue$`, f.everyMessageShouldBeInEveryCatalogue)
+	ctx.Step(`^a (\d+)x(\d+) colony with cells at (.*)$`, f.aColonyWithCellsAt)
+	ctx.Step(`^the interface is in "([^"]*)"$`, f.theInterfaceIsIn)
+	ctx.Step(`^the label of \((\d+),(\d+)\) should read "([^"]*)"$`, f.theLabelOfShouldRead)
+	ctx.Step(`^a monitor announcing in "([^"]*)"$`, f.aMonitorAnnouncingIn)
+	ctx.Step(`^a generation passes$`, f.aGenerationPasses)
+	ctx.Step(`^"([^"]*)" should be announced in the language of the interface$`, f.shouldBeAnnouncedInTheLanguageOfTheInterface)
+}
+
+func TestTranslationFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "translation",
+		ScenarioInitializer: InitializeTranslationScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/translation.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
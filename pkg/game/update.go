@@ -0,0 +1,31 @@
+package game
+
+import (
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+)
+
+// OnAppUpdate offers to reload into a new version of the app once its service worker has cached it.
+func (g *Game) OnAppUpdate(ctx app.Context) {
+	g.updateAvailable = ctx.AppUpdateAvailable()
+}
+
+// reload restarts the page in the new version of the app, keeping the colony, which is in the URL once saved.
+func (g *Game) reload(ctx app.Context) {
+	if g.ticker != nil {
+		g.stopTicking(ctx)
+	}
+	ctx.Reload()
+}
+
+// renderUpdate renders the prompt to reload into a new version of the app.
+func (g *Game) renderUpdate() app.UI {
+	return app.Div().Class("update").Role("status").Body(
+		app.Span().Text(g.localizer.T("update-available")),
+		app.Button().Text(g.localizer.T("update-reload")).OnClick(func(ctx app.Context, e app.Event) {
+			g.reload(ctx)
+		}),
+		app.Button().Text(g.localizer.T("update-later")).OnClick(func(ctx app.Context, e app.Event) {
+			g.updateAvailable = false
+		}),
+	)
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+)
+
+// importImage reads the image file chosen in the upload control and previews it as a pattern.
+func (g *Game) importImage(ctx app.Context, e app.Event) {
+	files := e.Get("target").Get("files")
+	if files.Get("length").Int() == 0 {
+		return
+	}
+	file := files.Index(0)
+	name := file.Get("name").String()
+	var loaded app.Func
+	loaded = app.FuncOf(func(this app.Value, args []app.Value) any {
+		defer loaded.Release()
+		buffer := app.Window().Get("Uint8Array").New(args[0])
+		data := make([]byte, buffer.Get("length").Int())
+		app.CopyBytesToGo(data, buffer)
+		ctx.Dispatch(func(ctx app.Context) {
+			g.previewImage(name, data)
+		})
+		return nil
+	})
+	file.Call("arrayBuffer").Call("then", loaded)
+}
+
+// previewImage converts an uploaded image to a pattern that is shown until it is stamped or discarded.
+func (g *Game) previewImage(name string, data []byte) {
+	g.imported = nil
+	g.importError = ""
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		g.importError = err.Error()
+		return
+	}
+	colony, err := render.Import(img, render.ImportOptions{MaxCells: g.colony.Width()})
+	if err != nil {
+		g.importError = err.Error()
+		return
+	}
+	if i := strings.LastIndex(name, "."); i > 0 {
+		name = name[:i]
+	}
+	p := NewPattern(name, *colony.Cells())
+	g.imported = &p
+}
+
+// stampImported stamps the previewed pattern onto the colony and discards the preview.
+func (g *Game) stampImported(ctx app.Context) {
+	g.imported.Stamp(g.colony.Cells(), 2, 2)
+	g.imported = nil
+	g.saveState(ctx)
+}
+
+// renderImport renders the upload control and the preview of an imported pattern.
+func (g *Game) renderImport() app.UI {
+	l := g.localizer
+	return app.Div().Body(
+		app.Label().Text(l.T("import-image")).For("import-image"),
+		app.Input().
+			Type("file").
+			ID("import-image").
+			Accept("image/*").
+			OnChange(func(ctx app.Context, e app.Event) {
+				if g.colony != nil && g.ticker == nil {
+					g.importImage(ctx, e)
+				}
+			}),
+		app.If(g.importError != "", func() app.UI {
+			return app.Span().Class("error").Text(g.importError)
+		}),
+		app.If(g.imported != nil, func() app.UI {
+			grid := g.imported.Grid()
+			w, h := g.imported.Size()
+			return app.Div().Body(
+				app.Div().
+					Class("preview").
+					Style("grid-template", fmt.Sprintf("repeat(%d, 6px) / repeat(%d, 6px)", h, w)).
+					Body(
+						app.Range(grid).Slice(func(y int) app.UI {
+							return app.Range(grid[y]).Slice(func(x int) app.UI {
+								if grid[y][x] {
+									return app.Div().Class("alive")
+								}
+								return app.Div().Class("dead")
+							})
+						}),
+					),
+				app.Button().Text(l.T("stamp", g.imported.GetName())).OnClick(func(ctx app.Context, e app.Event) {
+					if g.ticker == nil {
+						g.stampImported(ctx)
+					}
+				}),
+				app.Button().Text(l.T("discard")).OnClick(func(ctx app.Context, e app.Event) {
+					g.imported = nil
+				}),
+			)
+		}),
+	)
+}
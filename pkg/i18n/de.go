@@ -0,0 +1,153 @@
+package i18n
+
+// German translates the interface into German.
+var German = &Catalogue{
+	Locale:     "de",
+	Name:       "Deutsch",
+	Categories: []Category{One, Other},
+	Plural:     oneOther,
+	Messages: map[string]string{
+		"title":                     "Conways Spiel des Lebens",
+		"github":                    "Auf GitHub öffnen",
+		"make-cells":                "Zellen erzeugen",
+		"interval":                  "Intervall: ",
+		"interval-label":            "Intervall der Simulation in Millisekunden",
+		"milliseconds":              "%d ms",
+		"shortcuts":                 "Tastenkürzel",
+		"shortcuts-title":           "Tastenkürzel (?), Befehlspalette (Strg+K)",
+		"play":                      "Abspielen",
+		"pause":                     "Pause",
+		"step-back":                 "Einen Schritt zurück",
+		"clear":                     "Leeren",
+		"random":                    "Zufall",
+		"center":                    "Zentrieren",
+		"analyse":                   "Analysieren",
+		"download-image":            "Bild herunterladen",
+		"generation":                "Generation: %d",
+		"live-cells.one":            "%d lebende Zelle",
+		"live-cells.other":          "%d lebende Zellen",
+		"language":                  "Sprache: ",
+		"display":                   "Anzeige: ",
+		"display-states":            "Zustände",
+		"display-age":               "Alter",
+		"display-trail":             "Spur",
+		"display-heat":              "Wärmebild",
+		"restart":                   "Neu beginnen",
+		"restart-title":             "Den Verlauf der Zellen vergessen und neu aufzeichnen",
+		"theme":                     "Design: ",
+		"theme-auto":                "Automatisch",
+		"theme-dark":                "Dunkel",
+		"theme-light":               "Hell",
+		"theme-high-contrast":       "Hoher Kontrast",
+		"theme-golly":               "Golly klassisch",
+		"theme-custom":              "Eigene Farben",
+		"colour-alive":              "Lebend",
+		"colour-dead":               "Tot",
+		"colour-grid":               "Gitter",
+		"colour-history":            "Verlauf",
+		"tool-pencil":               "Stift",
+		"tool-line":                 "Linie",
+		"tool-rectangle":            "Rechteck",
+		"tool-filled-rectangle":     "Gefülltes Rechteck",
+		"tool-fill":                 "Füllen",
+		"tool-eraser":               "Radierer",
+		"tool-select":               "Auswahl",
+		"undo":                      "Rückgängig",
+		"redo":                      "Wiederholen",
+		"colony":                    "Kolonie",
+		"cell-dead":                 "tot",
+		"cell-alive":                "lebend",
+		"cell-state":                "Zustand %d",
+		"cell-label":                "%s, Zeile %d, Spalte %d",
+		"report":                    "Generation %d, Population %d",
+		"died":                      "Generation %d: alle Zellen sind gestorben",
+		"still-life.one":            "Generation %d: zu einem Stillleben aus %d Zelle erstarrt",
+		"still-life.other":          "Generation %d: zu einem Stillleben aus %d Zellen erstarrt",
+		"oscillation":               "Generation %d: zu einer Oszillation mit Periode %d eingependelt",
+		"update-available":          "Eine neue Version des Spiels ist verfügbar.",
+		"update-reload":             "Neu laden",
+		"update-later":              "Später",
+		"command-play-pause":        "Abspielen oder pausieren",
+		"command-step":              "Eine Generation weiter",
+		"command-faster":            "Schneller",
+		"command-slower":            "Langsamer",
+		"command-move-left":         "Zellen nach links verschieben",
+		"command-move-right":        "Zellen nach rechts verschieben",
+		"command-move-up":           "Zellen nach oben verschieben",
+		"command-move-down":         "Zellen nach unten verschieben",
+		"command-shortcuts":         "Tastenkürzel anzeigen",
+		"command-centre":            "Zellen zentrieren",
+		"command-analyse":           "Objekte analysieren",
+		"command-copy":              "Auswahl kopieren",
+		"command-cut":               "Auswahl ausschneiden",
+		"command-rotate":            "Auswahl drehen",
+		"command-flip-horizontal":   "Auswahl von links nach rechts spiegeln",
+		"command-flip-vertical":     "Auswahl von oben nach unten spiegeln",
+		"command-clear-inside":      "Innerhalb der Auswahl leeren",
+		"command-clear-outside":     "Außerhalb der Auswahl leeren",
+		"command-random-fill":       "Auswahl zufällig füllen",
+		"command-tool":              "Werkzeug: %s",
+		"command-display":           "Anzeige: %s",
+		"command-theme":             "Design: %s",
+		"command-rule":              "Regel: %s (%s)",
+		"stamp":                     "%s stempeln",
+		"key-space":                 "Leertaste",
+		"palette-placeholder":       "Befehl oder Muster eingeben…",
+		"palette-label":             "Befehl",
+		"palette-empty":             "Keine passenden Befehle",
+		"shortcuts-heading":         "Tastenkürzel",
+		"shortcut-palette":          "Befehlspalette",
+		"shortcut-close":            "Schließen",
+		"shortcuts-board":           "Auf dem Spielfeld",
+		"shortcut-next-cell":        "Zur nächsten Zelle gehen",
+		"shortcut-row-ends":         "Zum Anfang oder Ende der Zeile gehen, mit Strg zur ersten oder letzten Zelle",
+		"shortcut-page.one":         "%d Zeile nach oben oder unten gehen",
+		"shortcut-page.other":       "%d Zeilen nach oben oder unten gehen",
+		"shortcut-toggle":           "Zelle umschalten",
+		"copy":                      "Kopieren",
+		"cut":                       "Ausschneiden",
+		"paste":                     "Einfügen",
+		"rotate":                    "Drehen",
+		"flip":                      "Spiegeln",
+		"clear-inside":              "Innen leeren",
+		"clear-outside":             "Außen leeren",
+		"random-fill":               "Zufällig füllen",
+		"select-none":               "Auswahl aufheben",
+		"random-soup":               "Zufallssuppe",
+		"seed":                      "Startwert: ",
+		"invalid-seed":              "ungültiger Startwert: %v",
+		"density":                   "Dichte: ",
+		"symmetry":                  "Symmetrie: ",
+		"region-left":               "Links: ",
+		"region-top":                "Oben: ",
+		"region-width":              "Breite: ",
+		"region-height":             "Höhe: ",
+		"region-edge":               "(0 reicht bis zum Rand)",
+		"recreate":                  "Neu erzeugen",
+		"recreate-title":            "Den Bereich mit diesem Startwert füllen",
+		"rule":                      "Regel: ",
+		"rule-label":                "Regelstring",
+		"rule-presets-label":        "Bekannte Regeln",
+		"rule-presets":              "Vorlagen…",
+		"rule-bundled":              "%s (Regeltabelle)",
+		"rule-table":                "Regeltabelle",
+		"rule-table-placeholder":    "Golly-.rule-Datei einfügen",
+		"rule-table-label":          "Golly-Regeldatei",
+		"rule-table-load":           "Regeltabelle laden",
+		"import-image":              "Bild importieren: ",
+		"discard":                   "Verwerfen",
+		"download-svg":              "SVG herunterladen",
+		"download-gif":              "GIF herunterladen",
+		"download-apng":             "APNG herunterladen",
+		"object-still":              "Stillleben",
+		"object-oscillator":         "Oszillator",
+		"object-spaceship":          "Raumschiff",
+		"object-unknown":            "unbekannt",
+		"describe-still-life.one":   "%s: Stillleben aus %d Zelle",
+		"describe-still-life.other": "%s: Stillleben aus %d Zellen",
+		"describe-oscillator":       "%s: Oszillator mit Periode %d",
+		"describe-spaceship":        "%s: Raumschiff mit Periode %d, das sich um (%d,%d) bewegt",
+		"describe-unsettled.one":    "%d Zelle, die sich innerhalb von %d Generationen nicht wiederholt",
+		"describe-unsettled.other":  "%d Zellen, die sich innerhalb von %d Generationen nicht wiederholen",
+	},
+}
@@ -0,0 +1,153 @@
+package i18n
+
+// English is the language the interface is written in, and the fallback for messages other catalogues lack.
+var English = &Catalogue{
+	Locale:     "en",
+	Name:       "English",
+	Categories: []Category{One, Other},
+	Plural:     oneOther,
+	Messages: map[string]string{
+		"title":                     "Conway's Game of life",
+		"github":                    "Open on Github",
+		"make-cells":                "Make Cells",
+		"interval":                  "Interval: ",
+		"interval-label":            "Simulation speed interval in milliseconds",
+		"milliseconds":              "%d ms",
+		"shortcuts":                 "Shortcuts",
+		"shortcuts-title":           "Keyboard shortcuts (?), command palette (Ctrl+K)",
+		"play":                      "Play",
+		"pause":                     "Pause",
+		"step-back":                 "Step back",
+		"clear":                     "Clear",
+		"random":                    "Random",
+		"center":                    "Center",
+		"analyse":                   "Analyse",
+		"download-image":            "Download image",
+		"generation":                "Generation: %d",
+		"live-cells.one":            "%d live cell",
+		"live-cells.other":          "%d live cells",
+		"language":                  "Language: ",
+		"display":                   "Display: ",
+		"display-states":            "States",
+		"display-age":               "Age",
+		"display-trail":             "Trail",
+		"display-heat":              "Heat map",
+		"restart":                   "Restart",
+		"restart-title":             "Forget the history of the cells and start tracking again",
+		"theme":                     "Theme: ",
+		"theme-auto":                "Automatic",
+		"theme-dark":                "Dark",
+		"theme-light":               "Light",
+		"theme-high-contrast":       "High contrast",
+		"theme-golly":               "Golly classic",
+		"theme-custom":              "Custom",
+		"colour-alive":              "Alive",
+		"colour-dead":               "Dead",
+		"colour-grid":               "Grid",
+		"colour-history":            "History",
+		"tool-pencil":               "Pencil",
+		"tool-line":                 "Line",
+		"tool-rectangle":            "Rectangle",
+		"tool-filled-rectangle":     "Filled rectangle",
+		"tool-fill":                 "Fill",
+		"tool-eraser":               "Eraser",
+		"tool-select":               "Select",
+		"undo":                      "Undo",
+		"redo":                      "Redo",
+		"colony":                    "Colony",
+		"cell-dead":                 "dead",
+		"cell-alive":                "alive",
+		"cell-state":                "state %d",
+		"cell-label":                "%s, row %d, column %d",
+		"report":                    "Generation %d, population %d",
+		"died":                      "Generation %d: all cells have died",
+		"still-life.one":            "Generation %d: settled into a still life of %d cell",
+		"still-life.other":          "Generation %d: settled into a still life of %d cells",
+		"oscillation":               "Generation %d: settled into an oscillation with period %d",
+		"update-available":          "A new version of the game is available.",
+		"update-reload":             "Reload",
+		"update-later":              "Later",
+		"command-play-pause":        "Play or pause",
+		"command-step":              "Step one generation",
+		"command-faster":            "Faster",
+		"command-slower":            "Slower",
+		"command-move-left":         "Move cells left",
+		"command-move-right":        "Move cells right",
+		"command-move-up":           "Move cells up",
+		"command-move-down":         "Move cells down",
+		"command-shortcuts":         "Show keyboard shortcuts",
+		"command-centre":            "Centre cells",
+		"command-analyse":           "Analyse objects",
+		"command-copy":              "Copy selection",
+		"command-cut":               "Cut selection",
+		"command-rotate":            "Rotate selection",
+		"command-flip-horizontal":   "Flip selection left to right",
+		"command-flip-vertical":     "Flip selection top to bottom",
+		"command-clear-inside":      "Clear inside selection",
+		"command-clear-outside":     "Clear outside selection",
+		"command-random-fill":       "Random fill selection",
+		"command-tool":              "Tool: %s",
+		"command-display":           "Display: %s",
+		"command-theme":             "Theme: %s",
+		"command-rule":              "Rule: %s (%s)",
+		"stamp":                     "Stamp %s",
+		"key-space":                 "Space",
+		"palette-placeholder":       "Type a command or pattern…",
+		"palette-label":             "Command",
+		"palette-empty":             "No matching commands",
+		"shortcuts-heading":         "Keyboard shortcuts",
+		"shortcut-palette":          "Command palette",
+		"shortcut-close":            "Close",
+		"shortcuts-board":           "On the board",
+		"shortcut-next-cell":        "Move to the next cell",
+		"shortcut-row-ends":         "Move to the start or end of the row, with Ctrl to the first or last cell",
+		"shortcut-page.one":         "Move %d row up or down",
+		"shortcut-page.other":       "Move %d rows up or down",
+		"shortcut-toggle":           "Toggle the cell",
+		"copy":                      "Copy",
+		"cut":                       "Cut",
+		"paste":                     "Paste",
+		"rotate":                    "Rotate",
+		"flip":                      "Flip",
+		"clear-inside":              "Clear inside",
+		"clear-outside":             "Clear outside",
+		"random-fill":               "Random fill",
+		"select-none":               "Select none",
+		"random-soup":               "Random soup",
+		"seed":                      "Seed: ",
+		"invalid-seed":              "invalid seed: %v",
+		"density":                   "Density: ",
+		"symmetry":                  "Symmetry: ",
+		"region-left":               "Left: ",
+		"region-top":                "Top: ",
+		"region-width":              "Width: ",
+		"region-height":             "Height: ",
+		"region-edge":               "(0 reaches the edge)",
+		"recreate":                  "Recreate",
+		"recreate-title":            "Fill the region using this seed",
+		"rule":                      "Rule: ",
+		"rule-label":                "Rulestring",
+		"rule-presets-label":        "Well known rules",
+		"rule-presets":              "Presets…",
+		"rule-bundled":              "%s (rule table)",
+		"rule-table":                "Rule table",
+		"rule-table-placeholder":    "Paste a Golly .rule file",
+		"rule-table-label":          "Golly rule file",
+		"rule-table-load":           "Load rule table",
+		"import-image":              "Import image: ",
+		"discard":                   "Discard",
+		"download-svg":              "Download SVG",
+		"download-gif":              "Download GIF",
+		"download-apng":             "Download APNG",
+		"object-still":              "still life",
+		"object-oscillator":         "oscillator",
+		"object-spaceship":          "spaceship",
+		"object-unknown":            "unknown",
+		"describe-still-life.one":   "%s: still life of %d cell",
+		"describe-still-life.other": "%s: still life of %d cells",
+		"describe-oscillator":       "%s: period %d oscillator",
+		"describe-spaceship":        "%s: period %d spaceship moving (%d,%d)",
+		"describe-unsettled.one":    "%d cell that doesn't repeat within %d generations",
+		"describe-unsettled.other":  "%d cells that don't repeat within %d generations",
+	},
+}
@@ -0,0 +1,153 @@
+package i18n
+
+// Spanish translates the interface into Spanish.
+var Spanish = &Catalogue{
+	Locale:     "es",
+	Name:       "Español",
+	Categories: []Category{One, Other},
+	Plural:     oneOther,
+	Messages: map[string]string{
+		"title":                     "El juego de la vida de Conway",
+		"github":                    "Abrir en GitHub",
+		"make-cells":                "Crear células",
+		"interval":                  "Intervalo: ",
+		"interval-label":            "Intervalo de la simulación en milisegundos",
+		"milliseconds":              "%d ms",
+		"shortcuts":                 "Atajos",
+		"shortcuts-title":           "Atajos de teclado (?), paleta de comandos (Ctrl+K)",
+		"play":                      "Reproducir",
+		"pause":                     "Pausa",
+		"step-back":                 "Retroceder",
+		"clear":                     "Borrar",
+		"random":                    "Aleatorio",
+		"center":                    "Centrar",
+		"analyse":                   "Analizar",
+		"download-image":            "Descargar imagen",
+		"generation":                "Generación: %d",
+		"live-cells.one":            "%d célula viva",
+		"live-cells.other":          "%d células vivas",
+		"language":                  "Idioma: ",
+		"display":                   "Mostrar: ",
+		"display-states":            "Estados",
+		"display-age":               "Edad",
+		"display-trail":             "Estela",
+		"display-heat":              "Mapa de calor",
+		"restart":                   "Reiniciar",
+		"restart-title":             "Olvidar el historial de las células y volver a empezar",
+		"theme":                     "Tema: ",
+		"theme-auto":                "Automático",
+		"theme-dark":                "Oscuro",
+		"theme-light":               "Claro",
+		"theme-high-contrast":       "Alto contraste",
+		"theme-golly":               "Golly clásico",
+		"theme-custom":              "Personalizado",
+		"colour-alive":              "Viva",
+		"colour-dead":               "Muerta",
+		"colour-grid":               "Cuadrícula",
+		"colour-history":            "Historial",
+		"tool-pencil":               "Lápiz",
+		"tool-line":                 "Línea",
+		"tool-rectangle":            "Rectángulo",
+		"tool-filled-rectangle":     "Rectángulo relleno",
+		"tool-fill":                 "Relleno",
+		"tool-eraser":               "Goma",
+		"tool-select":               "Seleccionar",
+		"undo":                      "Deshacer",
+		"redo":                      "Rehacer",
+		"colony":                    "Colonia",
+		"cell-dead":                 "muerta",
+		"cell-alive":                "viva",
+		"cell-state":                "estado %d",
+		"cell-label":                "%s, fila %d, columna %d",
+		"report":                    "Generación %d, población %d",
+		"died":                      "Generación %d: todas las células han muerto",
+		"still-life.one":            "Generación %d: se estabilizó en una vida estática de %d célula",
+		"still-life.other":          "Generación %d: se estabilizó en una vida estática de %d células",
+		"oscillation":               "Generación %d: se estabilizó en una oscilación de periodo %d",
+		"update-available":          "Hay una nueva versión del juego disponible.",
+		"update-reload":             "Recargar",
+		"update-later":              "Más tarde",
+		"command-play-pause":        "Reproducir o pausar",
+		"command-step":              "Avanzar una generación",
+		"command-faster":            "Más rápido",
+		"command-slower":            "Más lento",
+		"command-move-left":         "Mover las células a la izquierda",
+		"command-move-right":        "Mover las células a la derecha",
+		"command-move-up":           "Mover las células hacia arriba",
+		"command-move-down":         "Mover las células hacia abajo",
+		"command-shortcuts":         "Mostrar los atajos de teclado",
+		"command-centre":            "Centrar las células",
+		"command-analyse":           "Analizar objetos",
+		"command-copy":              "Copiar la selección",
+		"command-cut":               "Cortar la selección",
+		"command-rotate":            "Girar la selección",
+		"command-flip-horizontal":   "Voltear la selección de izquierda a derecha",
+		"command-flip-vertical":     "Voltear la selección de arriba abajo",
+		"command-clear-inside":      "Borrar dentro de la selección",
+		"command-clear-outside":     "Borrar fuera de la selección",
+		"command-random-fill":       "Rellenar la selección al azar",
+		"command-tool":              "Herramienta: %s",
+		"command-display":           "Mostrar: %s",
+		"command-theme":             "Tema: %s",
+		"command-rule":              "Regla: %s (%s)",
+		"stamp":                     "Estampar %s",
+		"key-space":                 "Espacio",
+		"palette-placeholder":       "Escribe un comando o un patrón…",
+		"palette-label":             "Comando",
+		"palette-empty":             "Ningún comando coincide",
+		"shortcuts-heading":         "Atajos de teclado",
+		"shortcut-palette":          "Paleta de comandos",
+		"shortcut-close":            "Cerrar",
+		"shortcuts-board":           "En el tablero",
+		"shortcut-next-cell":        "Ir a la célula siguiente",
+		"shortcut-row-ends":         "Ir al principio o al final de la fila, con Ctrl a la primera o la última célula",
+		"shortcut-page.one":         "Subir o bajar %d fila",
+		"shortcut-page.other":       "Subir o bajar %d filas",
+		"shortcut-toggle":           "Cambiar la célula",
+		"copy":                      "Copiar",
+		"cut":                       "Cortar",
+		"paste":                     "Pegar",
+		"rotate":                    "Girar",
+		"flip":                      "Voltear",
+		"clear-inside":              "Borrar dentro",
+		"clear-outside":             "Borrar fuera",
+		"random-fill":               "Relleno aleatorio",
+		"select-none":               "No seleccionar nada",
+		"random-soup":               "Sopa aleatoria",
+		"seed":                      "Semilla: ",
+		"invalid-seed":              "semilla no válida: %v",
+		"density":                   "Densidad: ",
+		"symmetry":                  "Simetría: ",
+		"region-left":               "Izquierda: ",
+		"region-top":                "Arriba: ",
+		"region-width":              "Ancho: ",
+		"region-height":             "Alto: ",
+		"region-edge":               "(0 llega hasta el borde)",
+		"recreate":                  "Recrear",
+		"recreate-title":            "Rellenar la región con esta semilla",
+		"rule":                      "Regla: ",
+		"rule-label":                "Cadena de la regla",
+		"rule-presets-label":        "Reglas conocidas",
+		"rule-presets":              "Predefinidas…",
+		"rule-bundled":              "%s (tabla de reglas)",
+		"rule-table":                "Tabla de reglas",
+		"rule-table-placeholder":    "Pega un archivo .rule de Golly",
+		"rule-table-label":          "Archivo de reglas de Golly",
+		"rule-table-load":           "Cargar la tabla de reglas",
+		"import-image":              "Importar imagen: ",
+		"discard":                   "Descartar",
+		"download-svg":              "Descargar SVG",
+		"download-gif":              "Descargar GIF",
+		"download-apng":             "Descargar APNG",
+		"object-still":              "vida estática",
+		"object-oscillator":         "oscilador",
+		"object-spaceship":          "nave espacial",
+		"object-unknown":            "desconocido",
+		"describe-still-life.one":   "%s: vida estática de %d célula",
+		"describe-still-life.other": "%s: vida estática de %d células",
+		"describe-oscillator":       "%s: oscilador de periodo %d",
+		"describe-spaceship":        "%s: nave espacial de periodo %d que se desplaza (%d,%d)",
+		"describe-unsettled.one":    "%d célula que no se repite en %d generaciones",
+		"describe-unsettled.other":  "%d células que no se repiten en %d generaciones",
+	},
+}
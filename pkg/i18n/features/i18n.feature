@@ -0,0 +1,56 @@
+Feature: Translations
+
+  Scenario Outline: Every catalogue has every message
+    When I check the "<locale>" catalogue against English
+    Then no message should be missing
+    And no message should be left over
+    And every message should take the same arguments as in English
+
+    Examples:
+      | locale |
+      | en     |
+      | es     |
+      | fr     |
+      | de     |
+      | pl     |
+
+  Scenario Outline: The browser's languages choose the catalogue
+    When the browser prefers the languages "<languages>"
+    Then the interface should be in "<locale>"
+
+    Examples:
+      | languages   | locale |
+      | fr-FR,en    | fr     |
+      | de-AT       | de     |
+      | PL          | pl     |
+      | ja,es-MX,fr | es     |
+      | ja,zh-CN    | en     |
+      |             | en     |
+
+  Scenario Outline: Counts choose the plural form
+    Given the interface is in "<locale>"
+    When I count <n> live cells
+    Then it should read "<text>"
+
+    Examples:
+      | locale | n  | text                |
+      | en     | 1  | 1 live cell         |
+      | en     | 0  | 0 live cells        |
+      | fr     | 0  | 0 cellule vivante   |
+      | fr     | 2  | 2 cellules vivantes |
+      | de     | 5  | 5 lebende Zellen    |
+      | pl     | 1  | 1 żywa komórka      |
+      | pl     | 3  | 3 żywe komórki      |
+      | pl     | 12 | 12 żywych komórek   |
+      | pl     | 22 | 22 żywe komórki     |
+      | pl     | 25 | 25 żywych komórek   |
+
+  Scenario: Messages a catalogue lacks fall back to English
+    Given a catalogue without the message "clear"
+    When I translate "clear"
+    Then it should read "Clear"
+
+  Scenario: Unknown messages show their key
+    Given the interface is in "de"
+    When I translate "no-such-message"
+    Then it should read "no-such-message"
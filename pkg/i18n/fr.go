@@ -0,0 +1,158 @@
+package i18n
+
+// French translates the interface into French, where 0 and 1 are both singular.
+var French = &Catalogue{
+	Locale:     "fr",
+	Name:       "Français",
+	Categories: []Category{One, Other},
+	Plural: func(n int) Category {
+		if n == 0 || n == 1 {
+			return One
+		}
+		return Other
+	},
+	Messages: map[string]string{
+		"title":                     "Le jeu de la vie de Conway",
+		"github":                    "Ouvrir sur GitHub",
+		"make-cells":                "Créer les cellules",
+		"interval":                  "Intervalle : ",
+		"interval-label":            "Intervalle de la simulation en millisecondes",
+		"milliseconds":              "%d ms",
+		"shortcuts":                 "Raccourcis",
+		"shortcuts-title":           "Raccourcis clavier (?), palette de commandes (Ctrl+K)",
+		"play":                      "Lecture",
+		"pause":                     "Pause",
+		"step-back":                 "Reculer d'une génération",
+		"clear":                     "Effacer",
+		"random":                    "Aléatoire",
+		"center":                    "Centrer",
+		"analyse":                   "Analyser",
+		"download-image":            "Télécharger l'image",
+		"generation":                "Génération : %d",
+		"live-cells.one":            "%d cellule vivante",
+		"live-cells.other":          "%d cellules vivantes",
+		"language":                  "Langue : ",
+		"display":                   "Affichage : ",
+		"display-states":            "États",
+		"display-age":               "Âge",
+		"display-trail":             "Traînée",
+		"display-heat":              "Carte de chaleur",
+		"restart":                   "Recommencer",
+		"restart-title":             "Oublier l'historique des cellules et recommencer le suivi",
+		"theme":                     "Thème : ",
+		"theme-auto":                "Automatique",
+		"theme-dark":                "Sombre",
+		"theme-light":               "Clair",
+		"theme-high-contrast":       "Contraste élevé",
+		"theme-golly":               "Golly classique",
+		"theme-custom":              "Personnalisé",
+		"colour-alive":              "Vivante",
+		"colour-dead":               "Morte",
+		"colour-grid":               "Grille",
+		"colour-history":            "Historique",
+		"tool-pencil":               "Crayon",
+		"tool-line":                 "Ligne",
+		"tool-rectangle":            "Rectangle",
+		"tool-filled-rectangle":     "Rectangle plein",
+		"tool-fill":                 "Remplissage",
+		"tool-eraser":               "Gomme",
+		"tool-select":               "Sélection",
+		"undo":                      "Annuler",
+		"redo":                      "Rétablir",
+		"colony":                    "Colonie",
+		"cell-dead":                 "morte",
+		"cell-alive":                "vivante",
+		"cell-state":                "état %d",
+		"cell-label":                "%s, ligne %d, colonne %d",
+		"report":                    "Génération %d, population %d",
+		"died":                      "Génération %d : toutes les cellules sont mortes",
+		"still-life.one":            "Génération %d : stabilisée en une structure stable de %d cellule",
+		"still-life.other":          "Génération %d : stabilisée en une structure stable de %d cellules",
+		"oscillation":               "Génération %d : stabilisée en une oscillation de période %d",
+		"update-available":          "Une nouvelle version du jeu est disponible.",
+		"update-reload":             "Recharger",
+		"update-later":              "Plus tard",
+		"command-play-pause":        "Lecture ou pause",
+		"command-step":              "Avancer d'une génération",
+		"command-faster":            "Plus vite",
+		"command-slower":            "Plus lent",
+		"command-move-left":         "Déplacer les cellules à gauche",
+		"command-move-right":        "Déplacer les cellules à droite",
+		"command-move-up":           "Déplacer les cellules vers le haut",
+		"command-move-down":         "Déplacer les cellules vers le bas",
+		"command-shortcuts":         "Afficher les raccourcis clavier",
+		"command-centre":            "Centrer les cellules",
+		"command-analyse":           "Analyser les objets",
+		"command-copy":              "Copier la sélection",
+		"command-cut":               "Couper la sélection",
+		"command-rotate":            "Faire pivoter la sélection",
+		"command-flip-horizontal":   "Retourner la sélection de gauche à droite",
+		"command-flip-vertical":     "Retourner la sélection de haut en bas",
+		"command-clear-inside":      "Effacer l'intérieur de la sélection",
+		"command-clear-outside":     "Effacer l'extérieur de la sélection",
+		"command-random-fill":       "Remplir la sélection au hasard",
+		"command-tool":              "Outil : %s",
+		"command-display":           "Affichage : %s",
+		"command-theme":             "Thème : %s",
+		"command-rule":              "Règle : %s (%s)",
+		"stamp":                     "Tamponner %s",
+		"key-space":                 "Espace",
+		"palette-placeholder":       "Tapez une commande ou un motif…",
+		"palette-label":             "Commande",
+		"palette-empty":             "Aucune commande correspondante",
+		"shortcuts-heading":         "Raccourcis clavier",
+		"shortcut-palette":          "Palette de commandes",
+		"shortcut-close":            "Fermer",
+		"shortcuts-board":           "Sur le plateau",
+		"shortcut-next-cell":        "Aller à la cellule suivante",
+		"shortcut-row-ends":         "Aller au début ou à la fin de la ligne, avec Ctrl à la première ou la dernière cellule",
+		"shortcut-page.one":         "Monter ou descendre de %d ligne",
+		"shortcut-page.other":       "Monter ou descendre de %d lignes",
+		"shortcut-toggle":           "Basculer la cellule",
+		"copy":                      "Copier",
+		"cut":                       "Couper",
+		"paste":                     "Coller",
+		"rotate":                    "Pivoter",
+		"flip":                      "Retourner",
+		"clear-inside":              "Effacer l'intérieur",
+		"clear-outside":             "Effacer l'extérieur",
+		"random-fill":               "Remplissage aléatoire",
+		"select-none":               "Tout désélectionner",
+		"random-soup":               "Soupe aléatoire",
+		"seed":                      "Graine : ",
+		"invalid-seed":              "graine invalide : %v",
+		"density":                   "Densité : ",
+		"symmetry":                  "Symétrie : ",
+		"region-left":               "Gauche : ",
+		"region-top":                "Haut : ",
+		"region-width":              "Largeur : ",
+		"region-height":             "Hauteur : ",
+		"region-edge":               "(0 va jusqu'au bord)",
+		"recreate":                  "Recréer",
+		"recreate-title":            "Remplir la région avec cette graine",
+		"rule":                      "Règle : ",
+		"rule-label":                "Chaîne de la règle",
+		"rule-presets-label":        "Règles connues",
+		"rule-presets":              "Préréglages…",
+		"rule-bundled":              "%s (table de règles)",
+		"rule-table":                "Table de règles",
+		"rule-table-placeholder":    "Collez un fichier .rule de Golly",
+		"rule-table-label":          "Fichier de règles Golly",
+		"rule-table-load":           "Charger la table de règles",
+		"import-image":              "Importer une image : ",
+		"discard":                   "Abandonner",
+		"download-svg":              "Télécharger en SVG",
+		"download-gif":              "Télécharger en GIF",
+		"download-apng":             "Télécharger en APNG",
+		"object-still":              "structure stable",
+		"object-oscillator":         "oscillateur",
+		"object-spaceship":          "vaisseau",
+		"object-unknown":            "inconnu",
+		"describe-still-life.one":   "%s : structure stable de %d cellule",
+		"describe-still-life.other": "%s : structure stable de %d cellules",
+		"describe-oscillator":       "%s : oscillateur de période %d",
+		"describe-spaceship":        "%s : vaisseau de période %d qui se déplace de (%d,%d)",
+		"describe-unsettled.one":    "%d cellule qui ne se répète pas en %d générations",
+		"describe-unsettled.other":  "%d cellules qui ne se répètent pas en %d générations",
+	},
+}
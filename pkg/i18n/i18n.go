@@ -0,0 +1,121 @@
+// Package i18n translates the text of the user interface: catalogues of messages for each language, the plural
+// rules that choose between forms of a message for a count, and matching the languages a browser asks for.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Category is a plural category as named by the Unicode CLDR.
+type Category string
+
+const (
+	One   Category = "one"
+	Few   Category = "few"
+	Many  Category = "many"
+	Other Category = "other"
+)
+
+// Catalogue holds the messages of one language by key. A message that depends on a count has a form for each
+// plural category the language uses, under the key followed by "." and the category, such as "cells.one".
+// Messages are fmt formats.
+type Catalogue struct {
+	Locale     string               // BCP 47 language tag
+	Name       string               // Name of the language in itself, for the language switcher
+	Categories []Category           // Plural categories the language uses for whole numbers
+	Plural     func(n int) Category // Plural category of a whole number
+	Messages   map[string]string
+}
+
+// Catalogues lists the languages the interface is translated into, English first.
+var Catalogues = []*Catalogue{English, Spanish, French, German, Polish}
+
+// oneOther is the plural rule of languages where only 1 is singular.
+func oneOther(n int) Category {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+// Lookup returns the catalogue for a language tag, ignoring case.
+func Lookup(locale string) (*Catalogue, bool) {
+	for _, c := range Catalogues {
+		if strings.EqualFold(c.Locale, locale) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Match returns the catalogue that best suits a list of language tags in order of preference, as in
+// navigator.languages: the first tag with a catalogue of its own, or else whose language has one, so "de-AT"
+// is served German. English is the fallback.
+func Match(preferred []string) *Catalogue {
+	for _, tag := range preferred {
+		if c, ok := Lookup(tag); ok {
+			return c
+		}
+		base, _, _ := strings.Cut(tag, "-")
+		if c, ok := Lookup(base); ok {
+			return c
+		}
+	}
+	return English
+}
+
+// Localizer formats messages from a catalogue, falling back to English for messages it lacks. The zero
+// Localizer speaks English.
+type Localizer struct {
+	Catalogue *Catalogue
+}
+
+// For returns a localizer for a catalogue.
+func For(c *Catalogue) Localizer {
+	return Localizer{Catalogue: c}
+}
+
+// catalogue returns the catalogue messages are taken from.
+func (l Localizer) catalogue() *Catalogue {
+	if l.Catalogue == nil {
+		return English
+	}
+	return l.Catalogue
+}
+
+// Locale returns the language tag of the localizer.
+func (l Localizer) Locale() string {
+	return l.catalogue().Locale
+}
+
+// message returns the message for a key, from English if the catalogue lacks it, or the key itself if English
+// does too, so a missing message shows up without breaking the page.
+func (l Localizer) message(key string) string {
+	if m, ok := l.catalogue().Messages[key]; ok {
+		return m
+	}
+	if m, ok := English.Messages[key]; ok {
+		return m
+	}
+	return key
+}
+
+// T formats the message for a key with the arguments.
+func (l Localizer) T(key string, args ...any) string {
+	if len(args) == 0 {
+		return l.message(key)
+	}
+	return fmt.Sprintf(l.message(key), args...)
+}
+
+// N formats the form of the message for a key that suits the count n, with the arguments, which usually
+// include n itself.
+func (l Localizer) N(key string, n int, args ...any) string {
+	c := l.catalogue()
+	form := key + "." + string(c.Plural(n))
+	if m, ok := c.Messages[form]; ok {
+		return fmt.Sprintf(m, args...)
+	}
+	return fmt.Sprintf(l.message(key+"."+string(English.Plural(n))), args...)
+}
@@ -0,0 +1,174 @@
+package i18n
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// verbs matches the fmt verbs of a message.
+var verbs = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// wanted returns the keys a catalogue should have: each English message, with a form for each of the
+// catalogue's plural categories in place of the English forms of plural messages.
+func wanted(c *Catalogue) map[string]string {
+	keys := map[string]string{}
+	for key := range English.Messages {
+		base, category, ok := strings.Cut(key, ".")
+		if !ok {
+			keys[key] = key
+			continue
+		}
+		if Category(category) != Other {
+			continue
+		}
+		for _, cat := range c.Categories {
+			keys[base+"."+string(cat)] = key
+		}
+	}
+	return keys
+}
+
+type i18nFeature struct {
+	catalogue *Catalogue
+	localizer Localizer
+	text      string
+}
+
+func (f *i18nFeature) iCheckTheCatalogueAgainstEnglish(locale string) error {
+	c, ok := Lookup(locale)
+	if !ok {
+		return fmt.Errorf("no catalogue for %q", locale)
+	}
+	f.catalogue = c
+	return nil
+}
+
+func (f *i18nFeature) noMessageShouldBeMissing() error {
+	var missing []string
+	for key := range wanted(f.catalogue) {
+		if _, ok := f.catalogue.Messages[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("the %s catalogue is missing %s", f.catalogue.Locale, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (f *i18nFeature) noMessageShouldBeLeftOver() error {
+	keys := wanted(f.catalogue)
+	var extra []string
+	for key := range f.catalogue.Messages {
+		if _, ok := keys[key]; !ok {
+			extra = append(extra, key)
+		}
+	}
+	if len(extra) > 0 {
+		slices.Sort(extra)
+		return fmt.Errorf("the %s catalogue has messages English lacks: %s", f.catalogue.Locale, strings.Join(extra, ", "))
+	}
+	return nil
+}
+
+func (f *i18nFeature) everyMessageShouldTakeTheSameArgumentsAsInEnglish() error {
+	for key, english := range wanted(f.catalogue) {
+		message, ok := f.catalogue.Messages[key]
+		if !ok {
+			continue
+		}
+		want := verbs.FindAllString(English.Messages[english], -1)
+		if got := verbs.FindAllString(message, -1); !slices.Equal(got, want) {
+			return fmt.Errorf("%s message %q takes %v, English takes %v", f.catalogue.Locale, key, got, want)
+		}
+	}
+	return nil
+}
+
+func (f *i18nFeature) theBrowserPrefersTheLanguages(languages string) error {
+	var tags []string
+	if languages != "" {
+		tags = strings.Split(languages, ",")
+	}
+	f.localizer = For(Match(tags))
+	return nil
+}
+
+func (f *i18nFeature) theInterfaceShouldBeIn(locale string) error {
+	if got := f.localizer.Locale(); got != locale {
+		return fmt.Errorf("expected the interface in %q, got %q", locale, got)
+	}
+	return nil
+}
+
+func (f *i18nFeature) theInterfaceIsIn(locale string) error {
+	c, ok := Lookup(locale)
+	if !ok {
+		return fmt.Errorf("no catalogue for %q", locale)
+	}
+	f.localizer = For(c)
+	return nil
+}
+
+func (f *i18nFeature) iCountLiveCells(n int) error {
+	f.text = f.localizer.N("live-cells", n, n)
+	return nil
+}
+
+func (f *i18nFeature) aCatalogueWithoutTheMessage(key string) error {
+	messages := map[string]string{}
+	for k, m := range German.Messages {
+		if k != key {
+			messages[k] = m
+		}
+	}
+	f.localizer = For(&Catalogue{Locale: "xx", Categories: German.Categories, Plural: German.Plural, Messages: messages})
+	return nil
+}
+
+func (f *i18nFeature) iTranslate(key string) error {
+	f.text = f.localizer.T(key)
+	return nil
+}
+
+func (f *i18nFeature) itShouldRead(text string) error {
+	if f.text != text {
+		return fmt.Errorf("expected %q, got %q", text, f.text)
+	}
+	return nil
+}
+
+func InitializeI18nScenario(ctx *godog.ScenarioContext) {
+	f := &i18nFeature{}
+	ctx.Step(`^I check the "([^"]*)" catalogue against English$`, f.iCheckTheCatalogueAgainstEnglish)
+	ctx.Step(`^no message should be missing$`, f.noMessageShouldBeMissing)
+	ctx.Step(`^no message should be left over$`, f.noMessageShouldBeLeftOver)
+	ctx.Step(`^every message should take the same arguments as in English$`, f.everyMessageShouldTakeTheSameArgumentsAsInEnglish)
+	ctx.Step(`^the browser prefers the languages "([^"]*)"$`, f.theBrowserPrefersTheLanguages)
+	ctx.Step(`^the interface should be in "([^"]*)"$`, f.theInterfaceShouldBeIn)
+	ctx.Step(`^the interface is in "([^"]*)"$`, f.theInterfaceIsIn)
+	ctx.Step(`^I count (\d+) live cells$`, f.iCountLiveCells)
+	ctx.Step(`^a catalogue without the message "([^"]*)"$`, f.aCatalogueWithoutTheMessage)
+	ctx.Step(`^I translate "([^"]*)"$`, f.iTranslate)
+	ctx.Step(`^it should read "([^"]*)"$`, f.itShouldRead)
+}
+
+func TestI18nFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "i18n",
+		ScenarioInitializer: InitializeI18nScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,167 @@
+package i18n
+
+// Polish translates the interface into Polish, which has separate plural forms for numbers ending in 2 to 4.
+var Polish = &Catalogue{
+	Locale:     "pl",
+	Name:       "Polski",
+	Categories: []Category{One, Few, Many},
+	Plural: func(n int) Category {
+		switch {
+		case n == 1:
+			return One
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return Few
+		default:
+			return Many
+		}
+	},
+	Messages: map[string]string{
+		"title":                    "Gra w życie Conwaya",
+		"github":                   "Otwórz w GitHubie",
+		"make-cells":               "Utwórz komórki",
+		"interval":                 "Odstęp: ",
+		"interval-label":           "Odstęp symulacji w milisekundach",
+		"milliseconds":             "%d ms",
+		"shortcuts":                "Skróty",
+		"shortcuts-title":          "Skróty klawiszowe (?), paleta poleceń (Ctrl+K)",
+		"play":                     "Odtwórz",
+		"pause":                    "Wstrzymaj",
+		"step-back":                "Cofnij o pokolenie",
+		"clear":                    "Wyczyść",
+		"random":                   "Losuj",
+		"center":                   "Wyśrodkuj",
+		"analyse":                  "Analizuj",
+		"download-image":           "Pobierz obraz",
+		"generation":               "Pokolenie: %d",
+		"live-cells.one":           "%d żywa komórka",
+		"live-cells.few":           "%d żywe komórki",
+		"live-cells.many":          "%d żywych komórek",
+		"language":                 "Język: ",
+		"display":                  "Widok: ",
+		"display-states":           "Stany",
+		"display-age":              "Wiek",
+		"display-trail":            "Ślad",
+		"display-heat":             "Mapa cieplna",
+		"restart":                  "Zacznij od nowa",
+		"restart-title":            "Zapomnij historię komórek i zacznij śledzić od nowa",
+		"theme":                    "Motyw: ",
+		"theme-auto":               "Automatyczny",
+		"theme-dark":               "Ciemny",
+		"theme-light":              "Jasny",
+		"theme-high-contrast":      "Wysoki kontrast",
+		"theme-golly":              "Klasyczny Golly",
+		"theme-custom":             "Własny",
+		"colour-alive":             "Żywe",
+		"colour-dead":              "Martwe",
+		"colour-grid":              "Siatka",
+		"colour-history":           "Historia",
+		"tool-pencil":              "Ołówek",
+		"tool-line":                "Linia",
+		"tool-rectangle":           "Prostokąt",
+		"tool-filled-rectangle":    "Wypełniony prostokąt",
+		"tool-fill":                "Wypełnienie",
+		"tool-eraser":              "Gumka",
+		"tool-select":              "Zaznaczenie",
+		"undo":                     "Cofnij",
+		"redo":                     "Ponów",
+		"colony":                   "Kolonia",
+		"cell-dead":                "martwa",
+		"cell-alive":               "żywa",
+		"cell-state":               "stan %d",
+		"cell-label":               "%s, wiersz %d, kolumna %d",
+		"report":                   "Pokolenie %d, populacja %d",
+		"died":                     "Pokolenie %d: wszystkie komórki wymarły",
+		"still-life.one":           "Pokolenie %d: ustabilizowało się w martwą naturę z %d komórki",
+		"still-life.few":           "Pokolenie %d: ustabilizowało się w martwą naturę z %d komórek",
+		"still-life.many":          "Pokolenie %d: ustabilizowało się w martwą naturę z %d komórek",
+		"oscillation":              "Pokolenie %d: ustabilizowało się w oscylację o okresie %d",
+		"update-available":         "Dostępna jest nowa wersja gry.",
+		"update-reload":            "Odśwież",
+		"update-later":             "Później",
+		"command-play-pause":       "Odtwórz lub wstrzymaj",
+		"command-step":             "Przejdź o jedno pokolenie",
+		"command-faster":           "Szybciej",
+		"command-slower":           "Wolniej",
+		"command-move-left":        "Przesuń komórki w lewo",
+		"command-move-right":       "Przesuń komórki w prawo",
+		"command-move-up":          "Przesuń komórki w górę",
+		"command-move-down":        "Przesuń komórki w dół",
+		"command-shortcuts":        "Pokaż skróty klawiszowe",
+		"command-centre":           "Wyśrodkuj komórki",
+		"command-analyse":          "Analizuj obiekty",
+		"command-copy":             "Kopiuj zaznaczenie",
+		"command-cut":              "Wytnij zaznaczenie",
+		"command-rotate":           "Obróć zaznaczenie",
+		"command-flip-horizontal":  "Odbij zaznaczenie w poziomie",
+		"command-flip-vertical":    "Odbij zaznaczenie w pionie",
+		"command-clear-inside":     "Wyczyść wnętrze zaznaczenia",
+		"command-clear-outside":    "Wyczyść poza zaznaczeniem",
+		"command-random-fill":      "Wypełnij zaznaczenie losowo",
+		"command-tool":             "Narzędzie: %s",
+		"command-display":          "Widok: %s",
+		"command-theme":            "Motyw: %s",
+		"command-rule":             "Reguła: %s (%s)",
+		"stamp":                    "Wstaw %s",
+		"key-space":                "Spacja",
+		"palette-placeholder":      "Wpisz polecenie lub wzór…",
+		"palette-label":            "Polecenie",
+		"palette-empty":            "Brak pasujących poleceń",
+		"shortcuts-heading":        "Skróty klawiszowe",
+		"shortcut-palette":         "Paleta poleceń",
+		"shortcut-close":           "Zamknij",
+		"shortcuts-board":          "Na planszy",
+		"shortcut-next-cell":       "Przejdź do następnej komórki",
+		"shortcut-row-ends":        "Przejdź na początek lub koniec wiersza, z Ctrl do pierwszej lub ostatniej komórki",
+		"shortcut-page.one":        "Przejdź o %d wiersz w górę lub w dół",
+		"shortcut-page.few":        "Przejdź o %d wiersze w górę lub w dół",
+		"shortcut-page.many":       "Przejdź o %d wierszy w górę lub w dół",
+		"shortcut-toggle":          "Przełącz komórkę",
+		"copy":                     "Kopiuj",
+		"cut":                      "Wytnij",
+		"paste":                    "Wklej",
+		"rotate":                   "Obróć",
+		"flip":                     "Odbij",
+		"clear-inside":             "Wyczyść wewnątrz",
+		"clear-outside":            "Wyczyść na zewnątrz",
+		"random-fill":              "Wypełnij losowo",
+		"select-none":              "Odznacz wszystko",
+		"random-soup":              "Losowa zupa",
+		"seed":                     "Ziarno: ",
+		"invalid-seed":             "nieprawidłowe ziarno: %v",
+		"density":                  "Gęstość: ",
+		"symmetry":                 "Symetria: ",
+		"region-left":              "Od lewej: ",
+		"region-top":               "Od góry: ",
+		"region-width":             "Szerokość: ",
+		"region-height":            "Wysokość: ",
+		"region-edge":              "(0 sięga do krawędzi)",
+		"recreate":                 "Odtwórz",
+		"recreate-title":           "Wypełnij obszar przy użyciu tego ziarna",
+		"rule":                     "Reguła: ",
+		"rule-label":               "Zapis reguły",
+		"rule-presets-label":       "Znane reguły",
+		"rule-presets":             "Gotowe reguły…",
+		"rule-bundled":             "%s (tablica reguł)",
+		"rule-table":               "Tablica reguł",
+		"rule-table-placeholder":   "Wklej plik .rule z Golly",
+		"rule-table-label":         "Plik reguł Golly",
+		"rule-table-load":          "Wczytaj tablicę reguł",
+		"import-image":             "Importuj obraz: ",
+		"discard":                  "Odrzuć",
+		"download-svg":             "Pobierz SVG",
+		"download-gif":             "Pobierz GIF",
+		"download-apng":            "Pobierz APNG",
+		"object-still":             "martwa natura",
+		"object-oscillator":        "oscylator",
+		"object-spaceship":         "statek",
+		"object-unknown":           "nieznany",
+		"describe-still-life.one":  "%s: martwa natura z %d komórki",
+		"describe-still-life.few":  "%s: martwa natura z %d komórek",
+		"describe-still-life.many": "%s: martwa natura z %d komórek",
+		"describe-oscillator":      "%s: oscylator o okresie %d",
+		"describe-spaceship":       "%s: statek o okresie %d przesuwający się o (%d,%d)",
+		"describe-unsettled.one":   "%d komórka, która nie powtarza się w ciągu %d pokoleń",
+		"describe-unsettled.few":   "%d komórki, które nie powtarzają się w ciągu %d pokoleń",
+		"describe-unsettled.many":  "%d komórek, które nie powtarzają się w ciągu %d pokoleń",
+	},
+}
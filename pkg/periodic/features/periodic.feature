@@ -0,0 +1,84 @@
+Feature: Oscillator and spaceship search
+
+  Scenario Outline: Velocities are parsed from LifeWiki notation
+    When I parse the velocity "<velocity>"
+    Then the displacement should be (<dx>,<dy>) over <period> generations
+    And it should be described as "<description>"
+
+    Examples:
+      | velocity     | dx | dy | period | description                               |
+      | c/4d         | -1 | -1 | 4      | c/4 diagonal spaceship with period 4      |
+      | c/4 diagonal | -1 | -1 | 4      | c/4 diagonal spaceship with period 4      |
+      | 2c/4         | 0  | -2 | 4      | c/2 orthogonal spaceship with period 4    |
+      | c/3          | 0  | -1 | 3      | c/3 orthogonal spaceship with period 3    |
+      | 2c/5o        | 0  | -2 | 5      | 2c/5 orthogonal spaceship with period 5   |
+      | (2,1)c/6     | -1 | -2 | 6      | (2,1)c/6 oblique spaceship with period 6  |
+      | c            | 0  | -1 | 1      | c/1 orthogonal spaceship with period 1    |
+
+  Scenario Outline: Impossible velocities are rejected
+    When I parse the velocity "<velocity>"
+    Then the velocity should be invalid
+
+    Examples:
+      | velocity  |
+      | 3c/2      |
+      | fast      |
+      | (0,0)c/2  |
+      | (1,1)c/2d |
+
+  Scenario Outline: Oscillators are found and verified
+    When I search for period <period> oscillators in a <width>x<height> box with <symmetry> symmetry under "<rule>"
+    Then <n> patterns should be found
+    And every pattern should be a "p<period> oscillator"
+
+    Examples:
+      | period | width | height | symmetry | rule    | n |
+      | 2      | 3     | 3      | C1       | B3/S23  | 1 |
+      | 2      | 3     | 3      | D4       | B3/S23  | 1 |
+      | 2      | 4     | 4      | C2       | B3/S23  | 1 |
+      | 3      | 4     | 4      | C1       | B3/S23  | 0 |
+      | 2      | 3     | 3      | D8       | B3/S23  | 0 |
+      | 2      | 3     | 3      | C1       | B36/S23 | 1 |
+
+  Scenario Outline: Spaceships are found and verified
+    When I search for <velocity> spaceships in a <width>x<height> box under "B3/S23"
+    Then 1 pattern should be found
+    And every pattern should be a "<description>"
+
+    Examples:
+      | velocity | width | height | description                            |
+      | c/4d     | 5     | 5      | c/4 diagonal spaceship with period 4   |
+      | 2c/4     | 7     | 6      | c/2 orthogonal spaceship with period 4 |
+
+  Scenario: The glider is the only c/4 diagonal spaceship in a 5x5 box
+    When I search for up to 10 c/4d spaceships in a 5x5 box under "B3/S23"
+    Then 1 pattern should be found
+    And the first pattern should have 5 live cells
+
+  Scenario: A search finds different patterns until there are no more
+    When I search for up to 20 period 2 oscillators in a 4x4 box under "B3/S23"
+    Then 9 patterns should be found
+    And every pattern should be a "p2 oscillator"
+    And no pattern should be a phase or translation of another
+
+  Scenario: There are no spaceships in a box too small for them
+    When I search for c/4d spaceships in a 3x3 box under "B3/S23"
+    Then 0 patterns should be found
+
+  Scenario Outline: Symmetries must fit the box and the direction of travel
+    When I search for <velocity> spaceships in a <width>x<height> box with <symmetry> symmetry under "B3/S23"
+    Then the search should fail because the symmetry doesn't fit
+
+    Examples:
+      | velocity | width | height | symmetry |
+      | c/4d     | 5     | 5      | D2       |
+      | 2c/4     | 7     | 7      | C2       |
+      | 2c/4     | 7     | 6      | D8       |
+
+  Scenario: Spaceships can be searched for with a symmetry that keeps their direction
+    When I search for 2c/4 spaceships in a 5x5 box with D2 symmetry under "B3/S23"
+    Then 0 patterns should be found
+
+  Scenario: Rules without a lookup table can't be searched
+    When I search for period 2 oscillators in a 3x3 box with C1 symmetry under "B2/S/C3"
+    Then the search should fail because the rule is unsupported
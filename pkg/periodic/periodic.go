@@ -0,0 +1,338 @@
+package periodic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/sat"
+	"sync"
+)
+
+// Options configures a search for oscillators and spaceships.
+type Options struct {
+	Period   int            // Generations after which the pattern repeats
+	DX, DY   int            // Displacement over a period, zero for oscillators
+	Width    int            // Columns of the box every phase must fit in
+	Height   int            // Rows of the box every phase must fit in
+	Symmetry model.Symmetry // Symmetry of every phase
+	Results  int            // Number of different patterns to find
+}
+
+// DefaultOptions returns options for a single period 2 oscillator in an 8×8 box.
+func DefaultOptions() Options {
+	return Options{Period: 2, Width: 8, Height: 8, Symmetry: model.C1, Results: 1}
+}
+
+var (
+	UnsupportedRule      = errors.New("search needs a two-state rule on adjacent cells without B0")
+	IncompatibleSymmetry = errors.New("symmetry doesn't fit the box or the direction of travel")
+)
+
+// Result is a pattern found by a search.
+type Result struct {
+	Pattern *model.Colony // First phase, cropped to its live cells
+	Period  int
+	DX, DY  int // Displacement over a period
+}
+
+// String describes the pattern, such as "p2 oscillator".
+func (r Result) String() string {
+	return Describe(r.DX, r.DY, r.Period)
+}
+
+// Search looks for patterns under the rule that repeat after exactly Period generations, displaced by (DX, DY),
+// with every phase inside a Width×Height box and having the symmetry. It encodes all the phases as a SAT problem,
+// so a search that returns fewer results than asked for has proved there are no more in the box.
+// Every result is checked by running it in a colony before it is returned.
+//
+// If the context is done first, Search returns the results found so far with the context's error.
+func Search(ctx context.Context, rule model.Rule, o Options) ([]Result, error) {
+	table, ok := model.LookupTable(rule)
+	if !ok || table[0] {
+		return nil, UnsupportedRule
+	}
+	if o.Period < 1 || o.Width < 1 || o.Height < 1 {
+		return nil, fmt.Errorf("invalid search box %dx%d with period %d", o.Width, o.Height, o.Period)
+	}
+	images, err := symmetryImages(o)
+	if err != nil {
+		return nil, err
+	}
+	e := newEncoder(o)
+	e.transitions(table)
+	e.symmetric(images)
+	e.nonEmpty()
+	e.exactPeriod()
+
+	var results []Result
+	for len(results) < o.Results {
+		satisfiable, err := e.solver.Solve(ctx)
+		if err != nil {
+			return results, err
+		}
+		if !satisfiable {
+			break
+		}
+		result, err := verify(rule, e.phase(0), o)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+		e.exclude()
+	}
+	return results, nil
+}
+
+// encoder turns the search into a SAT problem with one variable for each cell of the box in each phase.
+type encoder struct {
+	solver *sat.Solver
+	o      Options
+	cells  [][]int // Variables indexed [phase][y*Width+x]
+}
+
+func newEncoder(o Options) *encoder {
+	e := &encoder{solver: sat.New(), o: o, cells: make([][]int, o.Period)}
+	for t := range e.cells {
+		e.cells[t] = make([]int, o.Width*o.Height)
+		for i := range e.cells[t] {
+			e.cells[t][i] = e.solver.NewVariable()
+		}
+	}
+	return e
+}
+
+// cell returns the variable of the cell at (x, y) in phase t, or 0 outside the box, where cells are dead.
+// Phase Period is the first phase displaced by (DX, DY).
+func (e *encoder) cell(t, x, y int) int {
+	if t == e.o.Period {
+		t, x, y = 0, x-e.o.DX, y-e.o.DY
+	}
+	if x < 0 || y < 0 || x >= e.o.Width || y >= e.o.Height {
+		return 0
+	}
+	return e.cells[t][y*e.o.Width+x]
+}
+
+// clauses caches, for each lookup table, the clauses over a 3×3 block and the next state of its centre.
+var clauses = struct {
+	sync.Mutex
+	tables map[[512]bool][][]int
+}{tables: make(map[[512]bool][][]int)}
+
+func transitionClauses(table *[512]bool) [][]int {
+	clauses.Lock()
+	defer clauses.Unlock()
+	if c, ok := clauses.tables[*table]; ok {
+		return c
+	}
+	c := sat.Function(10, func(v uint32) bool { return table[v&511] == (v>>9 == 1) })
+	clauses.tables[*table] = c
+	return c
+}
+
+// transitions requires each phase to follow from the one before under the rule, including the cells around the
+// box, which must stay dead.
+func (e *encoder) transitions(table *[512]bool) {
+	c := transitionClauses(table)
+	mx, my := abs(e.o.DX)+1, abs(e.o.DY)+1
+	for t := 0; t < e.o.Period; t++ {
+		for y := -my; y < e.o.Height+my; y++ {
+			for x := -mx; x < e.o.Width+mx; x++ {
+				var inputs [10]int
+				constant := true
+				for i := 0; i < 9; i++ {
+					inputs[i] = e.cell(t, x+i%3-1, y+i/3-1)
+					constant = constant && inputs[i] == 0
+				}
+				inputs[9] = e.cell(t+1, x, y)
+				if constant && inputs[9] == 0 {
+					continue // Dead stays dead without B0
+				}
+				e.add(c, inputs[:])
+			}
+		}
+	}
+}
+
+// add adds clauses written over numbered inputs, substituting their variables, where 0 is a dead cell.
+func (e *encoder) add(clauses [][]int, inputs []int) {
+	for _, c := range clauses {
+		clause := make([]int, 0, len(c))
+		satisfied := false
+		for _, l := range c {
+			v := inputs[abs(l)-1]
+			switch {
+			case v == 0 && l < 0:
+				satisfied = true
+			case v == 0:
+			case l < 0:
+				clause = append(clause, -v)
+			default:
+				clause = append(clause, v)
+			}
+		}
+		if !satisfied {
+			e.solver.AddClause(clause...)
+		}
+	}
+}
+
+// symmetric makes every cell of every phase equal to its images under the symmetry.
+func (e *encoder) symmetric(images func(x, y int) [][2]int) {
+	for t := 0; t < e.o.Period; t++ {
+		for y := 0; y < e.o.Height; y++ {
+			for x := 0; x < e.o.Width; x++ {
+				a := e.cell(t, x, y)
+				for _, p := range images(x, y) {
+					if b := e.cell(t, p[0], p[1]); b != a {
+						e.solver.AddClause(-a, b)
+					}
+				}
+			}
+		}
+	}
+}
+
+// nonEmpty requires a live cell in the first phase.
+func (e *encoder) nonEmpty() {
+	e.solver.AddClause(e.cells[0]...)
+}
+
+// exactPeriod rules out patterns that repeat sooner: for each shorter period that could divide the displacement,
+// some cell must differ from the first phase moved on by the matching fraction of the displacement.
+func (e *encoder) exactPeriod() {
+	p := e.o.Period
+	for d := 1; d < p; d++ {
+		if p%d != 0 || e.o.DX*d%p != 0 || e.o.DY*d%p != 0 {
+			continue
+		}
+		sx, sy := e.o.DX*d/p, e.o.DY*d/p
+		var witnesses []int
+		for y := min(0, sy); y < e.o.Height+max(0, sy); y++ {
+			for x := min(0, sx); x < e.o.Width+max(0, sx); x++ {
+				a, b := e.cell(d, x, y), e.cell(0, x-sx, y-sy)
+				if a == 0 && b == 0 {
+					continue
+				}
+				z := e.solver.NewVariable()
+				witnesses = append(witnesses, z)
+				switch {
+				case a == 0:
+					e.solver.AddClause(-z, b)
+				case b == 0:
+					e.solver.AddClause(-z, a)
+				default:
+					e.solver.AddClause(-z, a, b)
+					e.solver.AddClause(-z, -a, -b)
+				}
+			}
+		}
+		e.solver.AddClause(witnesses...)
+	}
+}
+
+// phase returns the cells of a phase of the solution, indexed [y][x].
+func (e *encoder) phase(t int) [][]bool {
+	cells := make([][]bool, e.o.Height)
+	for y := range cells {
+		cells[y] = make([]bool, e.o.Width)
+		for x := range cells[y] {
+			cells[y][x] = e.solver.Value(e.cell(t, x, y))
+		}
+	}
+	return cells
+}
+
+// exclude rules out every phase of the solution, wherever it fits in the box, as the first phase of another,
+// so the next solution is a different pattern rather than the same one moved or started at a later phase.
+func (e *encoder) exclude() {
+	phases := make([][][2]int, e.o.Period)
+	for t := range phases {
+		for y, row := range e.phase(t) {
+			for x, alive := range row {
+				if alive {
+					phases[t] = append(phases[t], [2]int{x, y})
+				}
+			}
+		}
+	}
+	for _, cells := range phases {
+		minX, minY, maxX, maxY := e.o.Width, e.o.Height, -1, -1
+		for _, c := range cells {
+			minX, minY = min(minX, c[0]), min(minY, c[1])
+			maxX, maxY = max(maxX, c[0]), max(maxY, c[1])
+		}
+		if maxX < 0 {
+			continue
+		}
+		for dy := -minY; maxY+dy < e.o.Height; dy++ {
+			for dx := -minX; maxX+dx < e.o.Width; dx++ {
+				live := make(map[int]bool, len(cells))
+				for _, c := range cells {
+					live[e.cell(0, c[0]+dx, c[1]+dy)] = true
+				}
+				clause := make([]int, 0, len(e.cells[0]))
+				for _, v := range e.cells[0] {
+					if live[v] {
+						clause = append(clause, -v)
+					} else {
+						clause = append(clause, v)
+					}
+				}
+				e.solver.AddClause(clause...)
+			}
+		}
+	}
+}
+
+// symmetryImages returns a function giving the images of a cell under the symmetry, after checking that the
+// symmetry maps the box onto itself and keeps the direction of travel.
+func symmetryImages(o Options) (func(x, y int) [][2]int, error) {
+	mx, my := o.Width-1, o.Height-1
+	var transforms []func(x, y int) (int, int)
+	rotations := []func(x, y int) (int, int){
+		func(x, y int) (int, int) { return mx - y, x },
+		func(x, y int) (int, int) { return mx - x, my - y },
+		func(x, y int) (int, int) { return y, my - x },
+	}
+	mirrors := []func(x, y int) (int, int){
+		func(x, y int) (int, int) { return mx - x, y },
+		func(x, y int) (int, int) { return x, my - y },
+	}
+	diagonals := []func(x, y int) (int, int){
+		func(x, y int) (int, int) { return y, x },
+		func(x, y int) (int, int) { return my - y, mx - x },
+	}
+	switch o.Symmetry {
+	case model.C1:
+	case model.C2:
+		transforms = rotations[1:2]
+	case model.C4:
+		transforms = rotations
+	case model.D2:
+		transforms = mirrors[:1]
+	case model.D4:
+		transforms = append(mirrors, rotations[1])
+	case model.D8:
+		transforms = append(append(append(transforms, rotations...), mirrors...), diagonals...)
+	}
+	square := o.Symmetry == model.C4 || o.Symmetry == model.D8
+	if square && o.Width != o.Height {
+		return nil, IncompatibleSymmetry
+	}
+	for _, t := range transforms {
+		x0, y0 := t(0, 0)
+		x1, y1 := t(o.DX, o.DY)
+		if x1-x0 != o.DX || y1-y0 != o.DY {
+			return nil, IncompatibleSymmetry
+		}
+	}
+	return func(x, y int) [][2]int {
+		images := make([][2]int, len(transforms))
+		for i, t := range transforms {
+			images[i][0], images[i][1] = t(x, y)
+		}
+		return images
+	}, nil
+}
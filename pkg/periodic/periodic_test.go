@@ -0,0 +1,213 @@
+package periodic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"testing"
+)
+
+type periodicFeature struct {
+	dx, dy, period int
+	err            error
+	results        []Result
+}
+
+func (f *periodicFeature) iParseTheVelocity(s string) error {
+	f.dx, f.dy, f.period, f.err = ParseVelocity(s)
+	return nil
+}
+
+func (f *periodicFeature) theDisplacementShouldBe(dx, dy, period int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.dx != dx || f.dy != dy || f.period != period {
+		return fmt.Errorf("expected (%d,%d) over %d generations, got (%d,%d) over %d", dx, dy, period, f.dx, f.dy, f.period)
+	}
+	return nil
+}
+
+func (f *periodicFeature) itShouldBeDescribedAs(description string) error {
+	if got := Describe(f.dx, f.dy, f.period); got != description {
+		return fmt.Errorf("expected %q, got %q", description, got)
+	}
+	return nil
+}
+
+func (f *periodicFeature) theVelocityShouldBeInvalid() error {
+	if !errors.Is(f.err, InvalidVelocity) {
+		return fmt.Errorf("expected an invalid velocity, got %v", f.err)
+	}
+	return nil
+}
+
+func (f *periodicFeature) search(rulestring string, o Options) error {
+	rule, err := model.ParseRule(rulestring)
+	if err != nil {
+		return err
+	}
+	f.results, f.err = Search(context.Background(), rule, o)
+	return nil
+}
+
+func options(results, width, height int, symmetry string) (Options, error) {
+	o := DefaultOptions()
+	o.Results, o.Width, o.Height = results, width, height
+	var err error
+	o.Symmetry, err = model.ParseSymmetry(symmetry)
+	return o, err
+}
+
+func (f *periodicFeature) iSearchForOscillators(period, width, height int, symmetry, rule string) error {
+	o, err := options(1, width, height, symmetry)
+	if err != nil {
+		return err
+	}
+	o.Period = period
+	return f.search(rule, o)
+}
+
+func (f *periodicFeature) iSearchForUpToOscillators(n, period, width, height int, rule string) error {
+	o, err := options(n, width, height, "C1")
+	if err != nil {
+		return err
+	}
+	o.Period = period
+	return f.search(rule, o)
+}
+
+func (f *periodicFeature) iSearchForSpaceships(velocity string, width, height int, rule string) error {
+	return f.iSearchForUpToSpaceshipsWithSymmetry(1, velocity, width, height, "C1", rule)
+}
+
+func (f *periodicFeature) iSearchForUpToSpaceships(n int, velocity string, width, height int, rule string) error {
+	return f.iSearchForUpToSpaceshipsWithSymmetry(n, velocity, width, height, "C1", rule)
+}
+
+func (f *periodicFeature) iSearchForSpaceshipsWithSymmetry(velocity string, width, height int, symmetry, rule string) error {
+	return f.iSearchForUpToSpaceshipsWithSymmetry(1, velocity, width, height, symmetry, rule)
+}
+
+func (f *periodicFeature) iSearchForUpToSpaceshipsWithSymmetry(n int, velocity string, width, height int, symmetry, rule string) error {
+	o, err := options(n, width, height, symmetry)
+	if err != nil {
+		return err
+	}
+	if o.DX, o.DY, o.Period, err = ParseVelocity(velocity); err != nil {
+		return err
+	}
+	return f.search(rule, o)
+}
+
+func (f *periodicFeature) patternsShouldBeFound(n int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if len(f.results) != n {
+		return fmt.Errorf("expected %d patterns, found %d", n, len(f.results))
+	}
+	return nil
+}
+
+func (f *periodicFeature) everyPatternShouldBeA(description string) error {
+	for _, r := range f.results {
+		if r.String() != description {
+			return fmt.Errorf("expected a %s, found a %s", description, r)
+		}
+	}
+	return nil
+}
+
+func (f *periodicFeature) theFirstPatternShouldHaveLiveCells(n int) error {
+	count := 0
+	for _, row := range *f.results[0].Pattern.Cells() {
+		for _, alive := range row {
+			if alive {
+				count++
+			}
+		}
+	}
+	if count != n {
+		return fmt.Errorf("expected %d live cells, found %d", n, count)
+	}
+	return nil
+}
+
+func (f *periodicFeature) noPatternShouldBeAPhaseOrTranslationOfAnother() error {
+	for i, r := range f.results {
+		phase := r.Pattern.Clone()
+		for t := 0; t < r.Period; t++ {
+			for j, other := range f.results {
+				if cropped, _, _ := crop(phase); j != i && same(cropped, other.Pattern) {
+					return fmt.Errorf("pattern %d is phase %d of pattern %d", j, t, i)
+				}
+			}
+			phase = grown(phase)
+		}
+	}
+	return nil
+}
+
+// grown returns the next generation of a pattern in a colony with room for it to grow.
+func grown(c *model.Colony) *model.Colony {
+	room := model.NewColony(c.Width()+4, c.Height()+4)
+	room.SetRule(c.Rule())
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			(*room.Cells())[y+2][x+2] = c.IsAlive(x, y)
+		}
+	}
+	room.Generate()
+	return room
+}
+
+func (f *periodicFeature) theSearchShouldFailBecauseTheSymmetryDoesntFit() error {
+	if !errors.Is(f.err, IncompatibleSymmetry) {
+		return fmt.Errorf("expected an incompatible symmetry, got %v", f.err)
+	}
+	return nil
+}
+
+func (f *periodicFeature) theSearchShouldFailBecauseTheRuleIsUnsupported() error {
+	if !errors.Is(f.err, UnsupportedRule) {
+		return fmt.Errorf("expected an unsupported rule, got %v", f.err)
+	}
+	return nil
+}
+
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	f := &periodicFeature{}
+	ctx.Step(`^I parse the velocity "([^"]*)"$`, f.iParseTheVelocity)
+	ctx.Step(`^the displacement should be \((-?\d+),(-?\d+)\) over (\d+) generations$`, f.theDisplacementShouldBe)
+	ctx.Step(`^it should be described as "([^"]*)"$`, f.itShouldBeDescribedAs)
+	ctx.Step(`^the velocity should be invalid$`, f.theVelocityShouldBeInvalid)
+	ctx.Step(`^I search for period (\d+) oscillators in a (\d+)x(\d+) box with (\w+) symmetry under "([^"]*)"$`, f.iSearchForOscillators)
+	ctx.Step(`^I search for up to (\d+) period (\d+) oscillators in a (\d+)x(\d+) box under "([^"]*)"$`, f.iSearchForUpToOscillators)
+	ctx.Step(`^I search for (\S+) spaceships in a (\d+)x(\d+) box under "([^"]*)"$`, f.iSearchForSpaceships)
+	ctx.Step(`^I search for up to (\d+) (\S+) spaceships in a (\d+)x(\d+) box under "([^"]*)"$`, f.iSearchForUpToSpaceships)
+	ctx.Step(`^I search for (\S+) spaceships in a (\d+)x(\d+) box with (\w+) symmetry under "([^"]*)"$`, f.iSearchForSpaceshipsWithSymmetry)
+	ctx.Step(`^(\d+) patterns? should be found$`, f.patternsShouldBeFound)
+	ctx.Step(`^every pattern should be a "([^"]*)"$`, f.everyPatternShouldBeA)
+	ctx.Step(`^the first pattern should have (\d+) live cells$`, f.theFirstPatternShouldHaveLiveCells)
+	ctx.Step(`^no pattern should be a phase or translation of another$`, f.noPatternShouldBeAPhaseOrTranslationOfAnother)
+	ctx.Step(`^the search should fail because the symmetry doesn't fit$`, f.theSearchShouldFailBecauseTheSymmetryDoesntFit)
+	ctx.Step(`^the search should fail because the rule is unsupported$`, f.theSearchShouldFailBecauseTheRuleIsUnsupported)
+}
+
+func TestPeriodicFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "periodic",
+		ScenarioInitializer: InitializeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/periodic.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,90 @@
+package periodic
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var InvalidVelocity = errors.New("invalid velocity")
+
+// velocityPattern matches speeds such as c/4, 2c/5, c/4d, c/3 orthogonal or (2,1)c/6.
+var velocityPattern = regexp.MustCompile(`^(?:\((\d+),(\d+)\))?(\d*)c(?:/(\d+))?\s*(d|diagonal|o|orthogonal)?$`)
+
+// ParseVelocity parses a spaceship speed in LifeWiki notation into the displacement over a period and the period.
+// Orthogonal ships move up, diagonal ships up and to the left, and (a,b)c/p ships a cells up and b cells left.
+func ParseVelocity(s string) (dx, dy, period int, err error) {
+	m := velocityPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(s)))
+	if m == nil {
+		return 0, 0, 0, fmt.Errorf("%w %q", InvalidVelocity, s)
+	}
+	number := func(s string, fallback int) int {
+		if s == "" {
+			return fallback
+		}
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	period = number(m[4], 1)
+	if m[1] != "" {
+		if m[3] != "" || m[5] != "" {
+			return 0, 0, 0, fmt.Errorf("%w %q", InvalidVelocity, s)
+		}
+		dx, dy = -number(m[2], 0), -number(m[1], 0)
+	} else {
+		k := number(m[3], 1)
+		dy = -k
+		if m[5] == "d" || m[5] == "diagonal" {
+			dx = -k
+		}
+	}
+	if period < 1 || max(-dx, -dy) > period || (dx == 0 && dy == 0) {
+		return 0, 0, 0, fmt.Errorf("%w %q", InvalidVelocity, s)
+	}
+	return dx, dy, period, nil
+}
+
+// Describe names a pattern that repeats after period generations displaced by (dx, dy), such as "p3 oscillator"
+// or "c/2 orthogonal spaceship with period 4".
+func Describe(dx, dy, period int) string {
+	a, b := max(abs(dx), abs(dy)), min(abs(dx), abs(dy))
+	if a == 0 {
+		if period == 1 {
+			return "still life"
+		}
+		return fmt.Sprintf("p%d oscillator", period)
+	}
+	var speed string
+	switch {
+	case b == 0 || a == b:
+		g := gcd(a, period)
+		speed = fmt.Sprintf("c/%d", period/g)
+		if a/g > 1 {
+			speed = fmt.Sprint(a/g) + speed
+		}
+		if b == 0 {
+			speed += " orthogonal"
+		} else {
+			speed += " diagonal"
+		}
+	default:
+		speed = fmt.Sprintf("(%d,%d)c/%d oblique", a, b, period)
+	}
+	return fmt.Sprintf("%s spaceship with period %d", speed, period)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,71 @@
+package periodic
+
+import (
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+)
+
+// verify runs the first phase of a solution in a colony and checks that it repeats after exactly the period,
+// displaced as asked, returning it as a result cropped to its live cells.
+func verify(rule model.Rule, phase [][]bool, o Options) (Result, error) {
+	pad := o.Period + abs(o.DX) + abs(o.DY) + 2
+	colony := model.NewColony(o.Width+2*pad, o.Height+2*pad)
+	colony.SetRule(rule)
+	for y, row := range phase {
+		for x, alive := range row {
+			(*colony.Cells())[y+pad][x+pad] = alive
+		}
+	}
+	start, x0, y0 := crop(colony)
+	for t := 1; t <= o.Period; t++ {
+		colony.Generate()
+		current, x, y := crop(colony)
+		if !same(current, start) {
+			continue
+		}
+		if t != o.Period || x-x0 != o.DX || y-y0 != o.DY {
+			return Result{}, fmt.Errorf("pattern found repeats after %d generations displaced by (%d,%d)", t, x-x0, y-y0)
+		}
+		return Result{Pattern: start, Period: o.Period, DX: o.DX, DY: o.DY}, nil
+	}
+	return Result{}, fmt.Errorf("pattern found doesn't repeat after %d generations", o.Period)
+}
+
+// crop returns the live cells of the colony in a colony of their own, with the position they were cropped from.
+func crop(c *model.Colony) (*model.Colony, int, int) {
+	minX, minY, maxX, maxY := c.Width(), c.Height(), -1, -1
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			if c.IsAlive(x, y) {
+				minX, minY = min(minX, x), min(minY, y)
+				maxX, maxY = max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	if maxX < 0 {
+		minX, minY, maxX, maxY = 0, 0, 0, 0
+	}
+	cropped := model.NewColony(maxX-minX+1, maxY-minY+1)
+	cropped.SetRule(c.Rule())
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			(*cropped.Cells())[y-minY][x-minX] = c.IsAlive(x, y)
+		}
+	}
+	return cropped, minX, minY
+}
+
+// same reports whether two colonies have the same size and live cells.
+func same(a, b *model.Colony) bool {
+	if a.Width() != b.Width() || a.Height() != b.Height() {
+		return false
+	}
+	for y := 0; y < a.Height(); y++ {
+		for x := 0; x < a.Width(); x++ {
+			if a.IsAlive(x, y) != b.IsAlive(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package predecessor
+
+import (
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/sat"
+	"sync"
+)
+
+// encoder turns the search into a SAT problem with one variable per cell of the predecessor.
+type encoder struct {
+	solver        *sat.Solver
+	margin        int
+	width, height int // Size of the predecessor
+	variables     []int
+}
+
+// transitions caches, for each lookup table, the clauses forcing a cell to end up dead and alive.
+var transitions = struct {
+	sync.Mutex
+	clauses map[[512]bool]*[2][][]int
+}{clauses: make(map[[512]bool]*[2][][]int)}
+
+func newEncoder(r region, margin int) *encoder {
+	e := &encoder{
+		solver: sat.New(),
+		margin: margin,
+		width:  r.width + 2*margin,
+		height: r.height + 2*margin,
+	}
+	e.variables = make([]int, e.width*e.height)
+	for i := range e.variables {
+		e.variables[i] = e.solver.NewVariable()
+	}
+	return e
+}
+
+// variable returns the variable of the predecessor cell at (x, y) in target coordinates, or 0 outside the
+// predecessor, where cells are dead.
+func (e *encoder) variable(x, y int) int {
+	x, y = x+e.margin, y+e.margin
+	if x < 0 || y < 0 || x >= e.width || y >= e.height {
+		return 0
+	}
+	return e.variables[y*e.width+x]
+}
+
+// constrain requires the cell at (x, y) in target coordinates to be alive or dead in the next generation.
+func (e *encoder) constrain(x, y int, alive bool, table *[512]bool) {
+	var inputs [9]int
+	for i := range inputs {
+		inputs[i] = e.variable(x+i%3-1, y+i/3-1)
+	}
+	outcome := 0
+	if alive {
+		outcome = 1
+	}
+	for _, c := range clausesFor(table)[outcome] {
+		clause := make([]int, 0, len(c))
+		satisfied := false
+		for _, l := range c {
+			v := inputs[max(l, -l)-1]
+			switch {
+			case v == 0 && l < 0:
+				satisfied = true // A dead cell satisfies "not alive"
+			case v == 0:
+			case l < 0:
+				clause = append(clause, -v)
+			default:
+				clause = append(clause, v)
+			}
+		}
+		if !satisfied {
+			e.solver.AddClause(clause...)
+		}
+	}
+}
+
+// clausesFor returns the clauses over the nine cells of a 3×3 block that force its centre to be dead and alive in
+// the next generation.
+func clausesFor(table *[512]bool) *[2][][]int {
+	transitions.Lock()
+	defer transitions.Unlock()
+	if c, ok := transitions.clauses[*table]; ok {
+		return c
+	}
+	c := &[2][][]int{
+		sat.Function(9, func(mask uint32) bool { return !table[mask] }),
+		sat.Function(9, func(mask uint32) bool { return table[mask] }),
+	}
+	transitions.clauses[*table] = c
+	return c
+}
+
+// colony returns the predecessor found by the solver.
+func (e *encoder) colony(rule model.Rule) *model.Colony {
+	c := model.NewColony(e.width, e.height)
+	c.SetRule(rule)
+	for y := 0; y < e.height; y++ {
+		for x := 0; x < e.width; x++ {
+			(*c.Cells())[y][x] = e.solver.Value(e.variables[y*e.width+x])
+		}
+	}
+	return c
+}
@@ -0,0 +1,96 @@
+Feature: Predecessor search
+
+  Scenario Outline: Patterns that can be produced have a predecessor
+    Given the target
+      """
+      <target>
+      """
+    When a predecessor is searched for with a margin of <margin>
+    Then a predecessor should be found
+    And the predecessor should become the target in one generation
+
+    Examples:
+      | target            | margin |
+      | OOO               | 1      |
+      | OO\nOO            | 0      |
+      | .O.\n..O\nOOO     | 1      |
+      | .OO.\nO..O\n.OO.  | 1      |
+      | O                 | 1      |
+      | O...O             | 1      |
+
+  Scenario: A cell on its own can't be produced by a single cell
+    Given the target
+      """
+      O
+      """
+    When a predecessor is searched for with a margin of 0
+    Then there should be no predecessor within the bounds
+
+  Scenario: An empty target has an empty predecessor
+    Given the target
+      """
+      ...
+      """
+    When a predecessor is searched for with a margin of 1
+    Then a predecessor should be found
+    And the predecessor should have 0 live cells
+
+  Scenario: With a free border only the target rectangle has to match
+    Given the target
+      """
+      OOO
+      OOO
+      OOO
+      """
+    When a predecessor is searched for with a margin of 1 and a free border
+    Then a predecessor should be found
+    And the predecessor should become the target in one generation inside the rectangle
+
+  Scenario: The predecessor follows the target's rule
+    Given the target under the rule "B36/S23"
+      """
+      O.O
+      .O.
+      """
+    When a predecessor is searched for with a margin of 1
+    Then a predecessor should be found
+    And the predecessor should become the target in one generation
+
+  Scenario Outline: Rules without a lookup table or with B0 can't be searched
+    Given the target under the rule "<rule>"
+      """
+      OO
+      """
+    When a predecessor is searched for with a margin of 1
+    Then the search should fail because the rule is unsupported
+
+    Examples:
+      | rule                    |
+      | B3/S23/C3               |
+      | B03/S23                 |
+      | R2,C0,M1,S3..5,B3..4,NM |
+
+  Scenario Outline: Margins out of proportion to the target are refused
+    Given the target
+      """
+      <target>
+      """
+    When a predecessor is searched for with a margin of <margin>
+    Then the search should fail because the margin is invalid
+
+    Examples:
+      | target       | margin |
+      | OOO          | -1     |
+      | OOO          | 9      |
+      | O            | 100000 |
+      | O..........O | 13     |
+
+  Scenario: A search that runs out of time says so
+    Given the target
+      """
+      .O.
+      ..O
+      OOO
+      """
+    When a predecessor is searched for with a cancelled context
+    Then the search should have been cancelled
@@ -0,0 +1,136 @@
+package predecessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+)
+
+// Options configures a predecessor search.
+type Options struct {
+	Margin     int  // Cells the predecessor may extend beyond the target on each side
+	FreeBorder bool // Cells around the target may end up in any state, so only the target rectangle has to match
+}
+
+// DefaultOptions returns options for a predecessor that may extend one cell beyond the target, in an otherwise
+// empty plane.
+func DefaultOptions() Options {
+	return Options{Margin: 1}
+}
+
+// minMaxMargin is the widest margin allowed around the smallest targets.
+const minMaxMargin = 8
+
+var (
+	NoPredecessor   = errors.New("no predecessor within the bounds")
+	UnsupportedRule = errors.New("predecessor search needs a two-state rule on adjacent cells without B0")
+	InvalidMargin   = errors.New("invalid margin")
+)
+
+// Result is a predecessor of a target colony.
+type Result struct {
+	Predecessor *model.Colony // Colony that becomes the target in one generation
+	X, Y        int           // Position in the target of the predecessor's top left cell, negative in the margin
+}
+
+// region is the rectangle of the target that the search has to reproduce.
+type region struct {
+	x, y, width, height int
+}
+
+// Find searches for a colony that becomes the target in the next generation under the target's rule.
+//
+// The target is set in an infinite plane of dead cells. Unless FreeBorder is set, only the live cells matter: the
+// predecessor is looked for in their bounding box widened by Margin, and every cell outside the target must stay
+// dead. With FreeBorder the whole target rectangle must match and cells outside it may do anything, so with a
+// Margin of at least 1 a failed search proves the target is an orphan that no pattern can produce.
+//
+// The encoding grows with the square of the margin, so margins wider than the larger side of the target, or
+// than minMaxMargin for small targets, are refused with InvalidMargin. Find returns NoPredecessor if there is no
+// predecessor within the bounds, and the context's error if it is done before the search is.
+func Find(ctx context.Context, target *model.Colony, o Options) (*Result, error) {
+	table, ok := model.LookupTable(target.Rule())
+	if !ok || table[0] {
+		return nil, UnsupportedRule
+	}
+	r := bounds(target, o.FreeBorder)
+	if limit := max(r.width, r.height, minMaxMargin); o.Margin < 0 || o.Margin > limit {
+		return nil, fmt.Errorf("%w: %d is not between 0 and %d", InvalidMargin, o.Margin, limit)
+	}
+	e := newEncoder(r, o.Margin)
+	for y := -o.Margin - 1; y < r.height+o.Margin+1; y++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for x := -o.Margin - 1; x < r.width+o.Margin+1; x++ {
+			inside := x >= 0 && y >= 0 && x < r.width && y < r.height
+			if !inside && o.FreeBorder {
+				continue
+			}
+			e.constrain(x, y, inside && target.IsAlive(r.x+x, r.y+y), table)
+		}
+	}
+	satisfiable, err := e.solver.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !satisfiable {
+		return nil, NoPredecessor
+	}
+	predecessor := e.colony(target.Rule())
+	if err := verify(predecessor, target, r, o); err != nil {
+		return nil, err
+	}
+	return &Result{Predecessor: predecessor, X: r.x - o.Margin, Y: r.y - o.Margin}, nil
+}
+
+// bounds returns the part of the target to reproduce: the whole colony with a free border, and otherwise the
+// bounding box of its live cells.
+func bounds(target *model.Colony, freeBorder bool) region {
+	if freeBorder {
+		return region{width: target.Width(), height: target.Height()}
+	}
+	minX, minY, maxX, maxY := target.Width(), target.Height(), -1, -1
+	for y := 0; y < target.Height(); y++ {
+		for x := 0; x < target.Width(); x++ {
+			if target.IsAlive(x, y) {
+				minX, minY = min(minX, x), min(minY, y)
+				maxX, maxY = max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	if maxX < 0 {
+		return region{width: 1, height: 1}
+	}
+	return region{x: minX, y: minY, width: maxX - minX + 1, height: maxY - minY + 1}
+}
+
+// verify checks that the predecessor, set in an empty plane, runs to the target.
+func verify(predecessor, target *model.Colony, r region, o Options) error {
+	// One more ring of cells lets births just outside the predecessor show.
+	w, h := predecessor.Width()+2, predecessor.Height()+2
+	run := model.NewColony(w, h)
+	run.SetRule(target.Rule())
+	for y := 0; y < predecessor.Height(); y++ {
+		for x := 0; x < predecessor.Width(); x++ {
+			(*run.Cells())[y+1][x+1] = predecessor.IsAlive(x, y)
+		}
+	}
+	run.Generate()
+	offset := o.Margin + 1
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			tx, ty := x-offset, y-offset
+			inside := tx >= 0 && ty >= 0 && tx < r.width && ty < r.height
+			if !inside && o.FreeBorder {
+				continue
+			}
+			want := inside && target.IsAlive(r.x+tx, r.y+ty)
+			if run.IsAlive(x, y) != want {
+				return fmt.Errorf("predecessor doesn't produce the target at (%d,%d)", r.x+tx, r.y+ty)
+			}
+		}
+	}
+	return nil
+}
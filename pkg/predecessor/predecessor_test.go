@@ -0,0 +1,182 @@
+package predecessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"strings"
+	"testing"
+)
+
+type predecessorFeature struct {
+	target *model.Colony
+	result *Result
+	err    error
+}
+
+func (f *predecessorFeature) theTarget(doc *godog.DocString) error {
+	return f.theTargetUnderTheRule("B3/S23", doc)
+}
+
+// theTargetUnderTheRule reads a target drawn with O for live cells and . for dead ones.
+func (f *predecessorFeature) theTargetUnderTheRule(rulestring string, doc *godog.DocString) error {
+	rows := strings.Split(strings.ReplaceAll(strings.TrimSpace(doc.Content), `\n`, "\n"), "\n")
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(strings.TrimSpace(row)))
+	}
+	f.target = model.NewColony(width, len(rows))
+	rule, err := model.ParseRule(rulestring)
+	if err != nil {
+		return err
+	}
+	f.target.SetRule(rule)
+	for y, row := range rows {
+		for x, c := range strings.TrimSpace(row) {
+			(*f.target.Cells())[y][x] = c == 'O'
+		}
+	}
+	return nil
+}
+
+func (f *predecessorFeature) search(ctx context.Context, margin int, freeBorder bool) {
+	f.result, f.err = Find(ctx, f.target, Options{Margin: margin, FreeBorder: freeBorder})
+}
+
+func (f *predecessorFeature) aPredecessorIsSearchedFor(margin int) error {
+	f.search(context.Background(), margin, false)
+	return nil
+}
+
+func (f *predecessorFeature) aPredecessorIsSearchedForWithAFreeBorder(margin int) error {
+	f.search(context.Background(), margin, true)
+	return nil
+}
+
+func (f *predecessorFeature) aPredecessorIsSearchedForWithACancelledContext() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f.search(ctx, 1, false)
+	return nil
+}
+
+func (f *predecessorFeature) aPredecessorShouldBeFound() error {
+	return f.err
+}
+
+// evolved places the predecessor in a colony large enough for everything it can reach and runs it one
+// generation, returning the colony and the position of the target's top left cell in it.
+func (f *predecessorFeature) evolved() (*model.Colony, int, int) {
+	p := f.result.Predecessor
+	pad := 2 + max(-f.result.X, -f.result.Y, f.result.X+p.Width()-f.target.Width(), f.result.Y+p.Height()-f.target.Height(), 0)
+	run := model.NewColony(f.target.Width()+2*pad, f.target.Height()+2*pad)
+	run.SetRule(f.target.Rule())
+	for y := 0; y < p.Height(); y++ {
+		for x := 0; x < p.Width(); x++ {
+			(*run.Cells())[pad+f.result.Y+y][pad+f.result.X+x] = p.IsAlive(x, y)
+		}
+	}
+	run.Generate()
+	return run, pad, pad
+}
+
+func (f *predecessorFeature) thePredecessorShouldBecomeTheTarget() error {
+	run, ox, oy := f.evolved()
+	for y := 0; y < run.Height(); y++ {
+		for x := 0; x < run.Width(); x++ {
+			if run.IsAlive(x, y) != f.target.IsAlive(x-ox, y-oy) {
+				return fmt.Errorf("cell (%d,%d) of the target differs", x-ox, y-oy)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *predecessorFeature) thePredecessorShouldBecomeTheTargetInsideTheRectangle() error {
+	run, ox, oy := f.evolved()
+	for y := 0; y < f.target.Height(); y++ {
+		for x := 0; x < f.target.Width(); x++ {
+			if run.IsAlive(x+ox, y+oy) != f.target.IsAlive(x, y) {
+				return fmt.Errorf("cell (%d,%d) of the target differs", x, y)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *predecessorFeature) thePredecessorShouldHaveLiveCells(n int) error {
+	count := 0
+	for _, row := range *f.result.Predecessor.Cells() {
+		for _, alive := range row {
+			if alive {
+				count++
+			}
+		}
+	}
+	if count != n {
+		return fmt.Errorf("expected %d live cells, got %d", n, count)
+	}
+	return nil
+}
+
+func (f *predecessorFeature) thereShouldBeNoPredecessorWithinTheBounds() error {
+	if !errors.Is(f.err, NoPredecessor) {
+		return fmt.Errorf("expected no predecessor, got %v", f.err)
+	}
+	return nil
+}
+
+func (f *predecessorFeature) theSearchShouldFailBecauseTheRuleIsUnsupported() error {
+	if !errors.Is(f.err, UnsupportedRule) {
+		return fmt.Errorf("expected an unsupported rule, got %v", f.err)
+	}
+	return nil
+}
+
+func (f *predecessorFeature) theSearchShouldFailBecauseTheMarginIsInvalid() error {
+	if !errors.Is(f.err, InvalidMargin) {
+		return fmt.Errorf("expected an invalid margin, got %v", f.err)
+	}
+	return nil
+}
+
+func (f *predecessorFeature) theSearchShouldHaveBeenCancelled() error {
+	if !errors.Is(f.err, context.Canceled) {
+		return fmt.Errorf("expected the search to be cancelled, got %v", f.err)
+	}
+	return nil
+}
+
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	f := &predecessorFeature{}
+	ctx.Step(`^the target$`, f.theTarget)
+	ctx.Step(`^the target under the rule "([^"]*)"$`, f.theTargetUnderTheRule)
+	ctx.Step(`^a predecessor is searched for with a margin of (-?\d+)$`, f.aPredecessorIsSearchedFor)
+	ctx.Step(`^a predecessor is searched for with a margin of (\d+) and a free border$`, f.aPredecessorIsSearchedForWithAFreeBorder)
+	ctx.Step(`^a predecessor is searched for with a cancelled context$`, f.aPredecessorIsSearchedForWithACancelledContext)
+	ctx.Step(`^a predecessor should be found$`, f.aPredecessorShouldBeFound)
+	ctx.Step(`^the predecessor should become the target in one generation$`, f.thePredecessorShouldBecomeTheTarget)
+	ctx.Step(`^the predecessor should become the target in one generation inside the rectangle$`, f.thePredecessorShouldBecomeTheTargetInsideTheRectangle)
+	ctx.Step(`^the predecessor should have (\d+) live cells$`, f.thePredecessorShouldHaveLiveCells)
+	ctx.Step(`^there should be no predecessor within the bounds$`, f.thereShouldBeNoPredecessorWithinTheBounds)
+	ctx.Step(`^the search should fail because the rule is unsupported$`, f.theSearchShouldFailBecauseTheRuleIsUnsupported)
+	ctx.Step(`^the search should fail because the margin is invalid$`, f.theSearchShouldFailBecauseTheMarginIsInvalid)
+	ctx.Step(`^the search should have been cancelled$`, f.theSearchShouldHaveBeenCancelled)
+}
+
+func TestPredecessorFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "predecessor",
+		ScenarioInitializer: InitializeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/predecessor.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,45 @@
+Feature: Thumbnails of shared colonies
+
+  Scenario: A thumbnail shows the colony in the theme's colours
+    Given a 3x3 colony with the centre cell alive
+    When its thumbnail is drawn
+    Then it should be 1200x630 pixels
+    And the centre should be the alive colour
+    And the corners should be the background colour
+
+  Scenario: A thumbnail is drawn once and then served from the cache
+    Given a preview handler
+    When the thumbnail of "blinker" is requested
+    And the thumbnail of "blinker" is requested
+    Then both responses should be PNG images with status 200
+    And the colony should have been decoded 1 time
+    And 1 thumbnail should be cached
+
+  Scenario: A browser that has the thumbnail is told it has not changed
+    Given a preview handler
+    When the thumbnail of "blinker" is requested
+    And the thumbnail of "blinker" is requested again with its ETag
+    Then the response should have status 304
+
+  Scenario: An invalid state is refused
+    Given a preview handler
+    When the thumbnail of "nonsense" is requested
+    Then the response should have status 400
+    And 0 thumbnails should be cached
+
+  Scenario: A query too long to be a shared state is refused before decoding
+    Given a preview handler
+    When the thumbnail of a state 70000 characters long is requested
+    Then the response should have status 414
+    And the colony should have been decoded 0 times
+
+  Scenario: The least recently used thumbnail is forgotten first
+    Given a cache of 2 thumbnails
+    When the thumbnails of "a", "b", "a" and "c" are cached in turn
+    Then "a" should be cached
+    And "c" should be cached
+    But "b" should not be cached
+
+  Scenario: Colonies are described for the text of a preview
+    Given a 3x3 colony with the centre cell alive
+    Then it should be described as "1 cell alive in a 3x3 colony at generation 0, under the rule B3/S23."
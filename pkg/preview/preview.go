@@ -0,0 +1,183 @@
+// Package preview draws the thumbnails chat apps and social networks show for a shared link to a colony, and
+// serves them, caching each by a hash of the colony's state.
+package preview
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"github.com/richardwooding/gameoflife/pkg/theme"
+	"image"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const (
+	Width  = 1200 // Width of a thumbnail, as Open Graph recommends
+	Height = 630  // Height of a thumbnail
+	margin = 32   // Least space around the colony in a thumbnail
+
+	// Path thumbnails are served from, with the state of the colony in the query.
+	Path = "/preview.png"
+
+	// CacheSize is the number of thumbnails a handler keeps by default.
+	CacheSize = 256
+
+	// MaxQuery is the longest query a handler decodes a state from.
+	MaxQuery = 64 << 10
+)
+
+// ImageURL returns the path of the thumbnail of the colony with an encoded state.
+func ImageURL(state string) string {
+	return Path + "?" + url.Values{"state": {state}}.Encode()
+}
+
+// Hash returns the hash thumbnails of the colony with an encoded state are cached by.
+func Hash(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Describe summarises a colony for the text of a preview.
+func Describe(c *model.Colony) string {
+	population := 0
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			if c.IsAlive(x, y) {
+				population++
+			}
+		}
+	}
+	cells := "cells"
+	if population == 1 {
+		cells = "cell"
+	}
+	return fmt.Sprintf("%d %s alive in a %dx%d colony at generation %d, under the rule %s.",
+		population, cells, c.Width(), c.Height(), c.GetGeneration(), c.Rule())
+}
+
+// Thumbnail draws a colony in the colours of the theme, as large as fits a Width by Height image.
+func Thumbnail(c *model.Colony, t theme.Theme) image.Image {
+	opts := t.Options()
+	opts.CellSize = 1
+	// The colony is drawn at one pixel a cell first, to learn how large a board of its rule is.
+	small := render.Render(c, opts).Bounds()
+	scale := max(1, min((Width-2*margin)/small.Dx(), (Height-2*margin)/small.Dy()))
+	opts.CellSize = scale
+	board := render.Render(c, opts)
+	dst := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(t.Background.RGBA()), image.Point{}, draw.Src)
+	b := board.Bounds()
+	// Colonies too large to fit even at one pixel a cell are cropped around their centre.
+	offset := image.Pt((Width-b.Dx())/2, (Height-b.Dy())/2)
+	draw.Draw(dst, b.Add(offset), board, b.Min, draw.Src)
+	return dst
+}
+
+// Cache keeps the latest thumbnails by hash, forgetting the least recently used.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Hashes, most recently used first
+	entries map[string]*list.Element
+}
+
+// entry is a cached thumbnail.
+type entry struct {
+	hash string
+	png  []byte
+}
+
+// NewCache returns a cache of up to size thumbnails.
+func NewCache(size int) *Cache {
+	return &Cache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get returns the thumbnail with a hash, if it is cached.
+func (c *Cache) Get(hash string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[hash]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(entry).png, true
+}
+
+// Put caches a thumbnail by its hash.
+func (c *Cache) Put(hash string, png []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[hash]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[hash] = c.order.PushFront(entry{hash, png})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(entry).hash)
+	}
+}
+
+// Len returns the number of thumbnails cached.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Handler serves the thumbnails of colonies at Path.
+type Handler struct {
+	Decode func(state string) (*model.Colony, error) // Decodes the state of a colony from a shared link
+	Theme  theme.Theme                               // Colours of the thumbnails
+	Cache  *Cache
+}
+
+// NewHandler returns a handler drawing thumbnails in the dark theme, keeping CacheSize of them.
+func NewHandler(decode func(string) (*model.Colony, error)) *Handler {
+	return &Handler{Decode: decode, Theme: theme.Dark, Cache: NewCache(CacheSize)}
+}
+
+// ServeHTTP serves the thumbnail of the colony whose state is in the query. A thumbnail never changes, so
+// browsers and crawlers may keep it for good.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(r.URL.RawQuery) > MaxQuery {
+		http.Error(w, "state too long", http.StatusRequestURITooLong)
+		return
+	}
+	state := r.URL.Query().Get("state")
+	hash := Hash(state)
+	etag := `"` + hash + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	b, ok := h.Cache.Get(hash)
+	if !ok {
+		c, err := h.Decode(state)
+		if err != nil {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, Thumbnail(c, h.Theme)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		b = buf.Bytes()
+		h.Cache.Put(hash, b)
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", etag)
+	w.Write(b)
+}
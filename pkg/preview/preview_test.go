@@ -0,0 +1,227 @@
+package preview
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/theme"
+	"image"
+	"image/color"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type previewFeature struct {
+	colony    *model.Colony
+	thumbnail image.Image
+	handler   *Handler
+	decoded   int
+	responses []*httptest.ResponseRecorder
+	cache     *Cache
+}
+
+// decode stands in for the game's decoding of shared state, knowing a blinker by name and nothing else.
+func (f *previewFeature) decode(state string) (*model.Colony, error) {
+	f.decoded++
+	if state != "blinker" {
+		return nil, errors.New("unknown colony")
+	}
+	c := model.NewColony(5, 5)
+	for x := 1; x <= 3; x++ {
+		c.SetState(x, 2, 1)
+	}
+	return c, nil
+}
+
+func (f *previewFeature) aColonyWithTheCentreCellAlive(w, h int) error {
+	f.colony = model.NewColony(w, h)
+	f.colony.SetState(w/2, h/2, 1)
+	return nil
+}
+
+func (f *previewFeature) itsThumbnailIsDrawn() error {
+	f.thumbnail = Thumbnail(f.colony, theme.Dark)
+	return nil
+}
+
+func (f *previewFeature) itShouldBePixels(w, h int) error {
+	if got := f.thumbnail.Bounds().Size(); got != image.Pt(w, h) {
+		return fmt.Errorf("expected a %dx%d thumbnail, got %dx%d", w, h, got.X, got.Y)
+	}
+	return nil
+}
+
+// pixelShouldBe checks the colour of a pixel of the thumbnail.
+func (f *previewFeature) pixelShouldBe(x, y int, want theme.Colour) error {
+	if got := color.RGBAModel.Convert(f.thumbnail.At(x, y)); got != want.RGBA() {
+		return fmt.Errorf("expected %v at (%d,%d), got %v", want, x, y, got)
+	}
+	return nil
+}
+
+func (f *previewFeature) theCentreShouldBeTheAliveColour() error {
+	return f.pixelShouldBe(Width/2, Height/2, theme.Dark.Alive)
+}
+
+func (f *previewFeature) theCornersShouldBeTheBackgroundColour() error {
+	for _, p := range []image.Point{{0, 0}, {Width - 1, 0}, {0, Height - 1}, {Width - 1, Height - 1}} {
+		if err := f.pixelShouldBe(p.X, p.Y, theme.Dark.Background); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *previewFeature) aPreviewHandler() error {
+	f.handler = NewHandler(f.decode)
+	return nil
+}
+
+// request fetches the thumbnail of a state, with any extra headers.
+func (f *previewFeature) request(state string, header http.Header) {
+	r := httptest.NewRequest(http.MethodGet, ImageURL(state), nil)
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	f.handler.ServeHTTP(w, r)
+	f.responses = append(f.responses, w)
+}
+
+func (f *previewFeature) theThumbnailOfIsRequested(state string) error {
+	f.request(state, nil)
+	return nil
+}
+
+func (f *previewFeature) theThumbnailOfAStateCharactersLongIsRequested(n int) error {
+	f.request(strings.Repeat("A", n), nil)
+	return nil
+}
+
+func (f *previewFeature) theThumbnailOfIsRequestedAgainWithItsETag(state string) error {
+	etag := f.responses[len(f.responses)-1].Header().Get("ETag")
+	if etag == "" {
+		return errors.New("expected an ETag")
+	}
+	f.request(state, http.Header{"If-None-Match": {etag}})
+	return nil
+}
+
+func (f *previewFeature) bothResponsesShouldBePNGImagesWithStatus(status int) error {
+	if len(f.responses) != 2 {
+		return fmt.Errorf("expected 2 responses, got %d", len(f.responses))
+	}
+	for _, w := range f.responses {
+		if w.Code != status {
+			return fmt.Errorf("expected status %d, got %d", status, w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); got != "image/png" {
+			return fmt.Errorf("expected an image/png, got %q", got)
+		}
+		img, _, err := image.Decode(w.Body)
+		if err != nil {
+			return err
+		}
+		if got := img.Bounds().Size(); got != image.Pt(Width, Height) {
+			return fmt.Errorf("expected a %dx%d image, got %dx%d", Width, Height, got.X, got.Y)
+		}
+	}
+	return nil
+}
+
+func (f *previewFeature) theColonyShouldHaveBeenDecodedTime(n int) error {
+	if f.decoded != n {
+		return fmt.Errorf("expected the colony to be decoded %d time(s), got %d", n, f.decoded)
+	}
+	return nil
+}
+
+func (f *previewFeature) thumbnailsShouldBeCached(n int) error {
+	if got := f.handler.Cache.Len(); got != n {
+		return fmt.Errorf("expected %d thumbnail(s) cached, got %d", n, got)
+	}
+	return nil
+}
+
+func (f *previewFeature) theResponseShouldHaveStatus(status int) error {
+	if got := f.responses[len(f.responses)-1].Code; got != status {
+		return fmt.Errorf("expected status %d, got %d", status, got)
+	}
+	return nil
+}
+
+func (f *previewFeature) aCacheOfThumbnails(n int) error {
+	f.cache = NewCache(n)
+	return nil
+}
+
+func (f *previewFeature) theThumbnailsOfAreCachedInTurn(a, b, c, d string) error {
+	for _, state := range []string{a, b, c, d} {
+		hash := Hash(state)
+		if _, ok := f.cache.Get(hash); !ok {
+			f.cache.Put(hash, []byte(state))
+		}
+	}
+	return nil
+}
+
+func (f *previewFeature) shouldBeCached(state string) error {
+	if b, ok := f.cache.Get(Hash(state)); !ok || string(b) != state {
+		return fmt.Errorf("expected %q to be cached", state)
+	}
+	return nil
+}
+
+func (f *previewFeature) shouldNotBeCached(state string) error {
+	if _, ok := f.cache.Get(Hash(state)); ok {
+		return fmt.Errorf("expected %q not to be cached", state)
+	}
+	return nil
+}
+
+func (f *previewFeature) itShouldBeDescribedAs(s string) error {
+	if got := Describe(f.colony); got != s {
+		return fmt.Errorf("expected %q, got %q", s, got)
+	}
+	return nil
+}
+
+func InitializePreviewScenario(ctx *godog.ScenarioContext) {
+	f := &previewFeature{}
+	ctx.Step(`^a (\d+)x(\d+) colony with the centre cell alive$`, f.aColonyWithTheCentreCellAlive)
+	ctx.Step(`^its thumbnail is drawn$`, f.itsThumbnailIsDrawn)
+	ctx.Step(`^it should be (\d+)x(\d+) pixels$`, f.itShouldBePixels)
+	ctx.Step(`^the centre should be the alive colour$`, f.theCentreShouldBeTheAliveColour)
+	ctx.Step(`^the corners should be the background colour$`, f.theCornersShouldBeTheBackgroundColour)
+	ctx.Step(`^a preview handler$`, f.aPreviewHandler)
+	ctx.Step(`^the thumbnail of "([^"]*)" is requested$`, f.theThumbnailOfIsRequested)
+	ctx.Step(`^the thumbnail of a state (\d+) characters long is requested$`, f.theThumbnailOfAStateCharactersLongIsRequested)
+	ctx.Step(`^the thumbnail of "([^"]*)" is requested again with its ETag$`, f.theThumbnailOfIsRequestedAgainWithItsETag)
+	ctx.Step(`^both responses should be PNG images with status (\d+)$`, f.bothResponsesShouldBePNGImagesWithStatus)
+	ctx.Step(`^the colony should have been decoded (\d+) times?$`, f.theColonyShouldHaveBeenDecodedTime)
+	ctx.Step(`^(\d+) thumbnails? should be cached$`, f.thumbnailsShouldBeCached)
+	ctx.Step(`^the response should have status (\d+)$`, f.theResponseShouldHaveStatus)
+	ctx.Step(`^a cache of (\d+) thumbnails$`, f.aCacheOfThumbnails)
+	ctx.Step(`^the thumbnails of "([^"]*)", "([^"]*)", "([^"]*)" and "([^"]*)" are cached in turn$`, f.theThumbnailsOfAreCachedInTurn)
+	ctx.Step(`^"([^"]*)" should be cached$`, f.shouldBeCached)
+	ctx.Step(`^"([^"]*)" should not be cached$`, f.shouldNotBeCached)
+	ctx.Step(`^it should be described as "([^"]*)"$`, f.itShouldBeDescribedAs)
+}
+
+func TestPreviewFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "preview",
+		ScenarioInitializer: InitializePreviewScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
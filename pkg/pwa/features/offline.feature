@@ -0,0 +1,28 @@
+Feature: Installable app that runs offline
+
+  Background:
+    Given the static website is generated for GitHub Pages
+    And it is served locally
+
+  Scenario Outline: The manifest lists the icons in every size
+    When I fetch the manifest
+    Then it should list a <size> <purpose> icon
+    And every icon it lists should be served at its size
+
+    Examples:
+      | size | purpose  |
+      | 48   | any      |
+      | 96   | any      |
+      | 192  | any      |
+      | 512  | any      |
+      | 192  | maskable |
+      | 512  | maskable |
+
+  Scenario: The app is installed under its path on GitHub Pages
+    When I fetch the manifest
+    Then the app should start at "/gameoflife/" in scope "/gameoflife/"
+    And its theme colour should be "#374651"
+
+  Scenario: The icons in the repository are made from the logo
+    When I generate the icons from the logo
+    Then they should match the icons in the repository
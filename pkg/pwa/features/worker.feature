@@ -0,0 +1,29 @@
+Feature: Service worker that answers offline
+
+  The service worker the website serves runs in Node, with Node's fetch for the network and stand-ins for the
+  Cache Storage and the rest of the service worker's globals. It is not a browser: these scenarios check how
+  the worker's own code installs and answers offline, not how a browser runs it. They are skipped where Node
+  isn't installed.
+
+  Background:
+    Given the static website is generated for GitHub Pages
+    And it is served locally
+
+  Scenario: The service worker caches everything the page loads
+    When the service worker installs
+    Then it should cache "/gameoflife/web/app.wasm"
+    And it should cache "/gameoflife/web/gameoflife.css"
+    And it should cache "/gameoflife/web/icons/icon-512.png"
+    And everything the page loads from the site should be cached
+
+  Scenario Outline: Pages of the app open offline
+    Given the service worker has installed
+    When the server goes offline
+    And I open "<path>"
+    Then the game's page should be shown
+
+    Examples:
+      | path              |
+      | /gameoflife       |
+      | /gameoflife/      |
+      | /gameoflife/AQID  |
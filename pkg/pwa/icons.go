@@ -0,0 +1,115 @@
+package pwa
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// IconSizes are the sizes in pixels of the square icons the app is installed with.
+var IconSizes = []int{48, 72, 96, 128, 144, 192, 256, 384, 512}
+
+// MaskableSizes are the sizes of the icons the system may crop to a shape of its own.
+var MaskableSizes = []int{192, 512}
+
+// maskableSafeZone is the share of a maskable icon the system never crops: a centred circle of this diameter.
+const maskableSafeZone = 0.8
+
+// IconName returns the file name of the icon of a size.
+func IconName(size int) string {
+	return fmt.Sprintf("icon-%d.png", size)
+}
+
+// MaskableName returns the file name of the maskable icon of a size.
+func MaskableName(size int) string {
+	return fmt.Sprintf("maskable-%d.png", size)
+}
+
+// Resize scales an image to a size by size square. Each pixel is the average of the area of the source it
+// covers, which smooths images scaled down and keeps the edges of images scaled up crisp.
+func Resize(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	sx, sy := float64(b.Dx())/float64(size), float64(b.Dy())/float64(size)
+	for y := 0; y < size; y++ {
+		y0, y1 := float64(y)*sy, float64(y+1)*sy
+		for x := 0; x < size; x++ {
+			x0, x1 := float64(x)*sx, float64(x+1)*sx
+			var r, g, bl, a, area float64
+			for py := int(y0); float64(py) < y1; py++ {
+				h := math.Min(y1, float64(py+1)) - math.Max(y0, float64(py))
+				for px := int(x0); float64(px) < x1; px++ {
+					w := math.Min(x1, float64(px+1)) - math.Max(x0, float64(px))
+					cr, cg, cb, ca := src.At(b.Min.X+px, b.Min.Y+py).RGBA()
+					r += float64(cr) * w * h
+					g += float64(cg) * w * h
+					bl += float64(cb) * w * h
+					a += float64(ca) * w * h
+					area += w * h
+				}
+			}
+			channel := func(v float64) uint8 {
+				return uint8(math.Round(v / area / 0x101))
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: channel(r), G: channel(g), B: channel(bl), A: channel(a)})
+		}
+	}
+	return dst
+}
+
+// Maskable returns a maskable icon of a size: the image scaled into the safe zone, on the colour of its top left
+// corner so the system can crop the icon to any shape without cutting into it.
+func Maskable(src image.Image, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	b := src.Bounds()
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(src.At(b.Min.X, b.Min.Y)), image.Point{}, draw.Src)
+	inner := int(math.Round(float64(size) * maskableSafeZone))
+	offset := (size - inner) / 2
+	draw.Draw(dst, image.Rect(offset, offset, offset+inner, offset+inner), Resize(src, inner), image.Point{}, draw.Over)
+	return dst
+}
+
+// GenerateIcons writes the icons of every size made from the PNG image at src into the directory dir.
+func GenerateIcons(src, dir string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	logo, err := png.Decode(f)
+	if err != nil {
+		return fmt.Errorf("decoding %s: %w", src, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, size := range IconSizes {
+		if err := writePNG(filepath.Join(dir, IconName(size)), Resize(logo, size)); err != nil {
+			return err
+		}
+	}
+	for _, size := range MaskableSizes {
+		if err := writePNG(filepath.Join(dir, MaskableName(size)), Maskable(logo, size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePNG writes an image to a PNG file.
+func writePNG(name string, img image.Image) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,142 @@
+// Package pwa makes the game an installable Progressive Web App that runs offline: its icons, its manifest and
+// the service worker that caches it.
+package pwa
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	IconsDir = "/web/icons"    // Path the icons are served from
+	Logo     = "/web/logo.svg" // The logo drawn as vectors, for browser tabs
+)
+
+const (
+	ThemeColor      = "#374651" // The tile of the logo, for the title bar of the installed app
+	BackgroundColor = "#1e1e1e" // The page in the dark theme, for the splash screen
+)
+
+// workerJS is the template of the service worker, with go-app's {{.Version}} and {{.ResourcesToCache}}, and
+// {{.StartPage}} for the page served for any page of the app while offline.
+//
+//go:embed worker.js
+var workerJS string
+
+// resolve returns the URL of a path under the handler's resources, which are only set for static websites
+// before the handler first serves.
+func resolve(h *app.Handler, path string) string {
+	if h.Resources == nil {
+		return path
+	}
+	return h.Resources.Resolve(path)
+}
+
+// scope returns the path of the app with a trailing slash, such as "/gameoflife/" on GitHub Pages.
+func scope(h *app.Handler) string {
+	return strings.TrimRight(resolve(h, "/"), "/") + "/"
+}
+
+// Configure sets up the handler to serve the app as a PWA: its colours, icons and service worker, caching the
+// icons for offline use. It must be called after the handler's resources are chosen.
+func Configure(h *app.Handler) {
+	h.ThemeColor = ThemeColor
+	h.BackgroundColor = BackgroundColor
+	h.Icon.SVG = Logo
+	h.Icon.Default = IconsDir + "/" + IconName(192)
+	h.Icon.Large = IconsDir + "/" + IconName(512)
+	h.Icon.Maskable = IconsDir + "/" + MaskableName(512)
+	h.CacheableResources = append(h.CacheableResources, Logo)
+	for _, size := range IconSizes {
+		h.CacheableResources = append(h.CacheableResources, IconsDir+"/"+IconName(size))
+	}
+	for _, size := range MaskableSizes {
+		h.CacheableResources = append(h.CacheableResources, IconsDir+"/"+MaskableName(size))
+	}
+	h.ServiceWorkerTemplate = strings.ReplaceAll(workerJS, "{{.StartPage}}", resolve(h, "/"))
+}
+
+// manifestIcon is an icon of the manifest.
+type manifestIcon struct {
+	Src     string `json:"src"`
+	Type    string `json:"type"`
+	Sizes   string `json:"sizes"`
+	Purpose string `json:"purpose,omitempty"`
+}
+
+// Manifest returns the web app manifest of the handler. Unlike go-app's own, it lists the icons of every size.
+func Manifest(h *app.Handler) ([]byte, error) {
+	icons := []manifestIcon{{Src: resolve(h, Logo), Type: "image/svg+xml", Sizes: "any"}}
+	for _, size := range IconSizes {
+		icons = append(icons, manifestIcon{
+			Src:   resolve(h, IconsDir+"/"+IconName(size)),
+			Type:  "image/png",
+			Sizes: fmt.Sprintf("%dx%d", size, size),
+		})
+	}
+	for _, size := range MaskableSizes {
+		icons = append(icons, manifestIcon{
+			Src:     resolve(h, IconsDir+"/"+MaskableName(size)),
+			Type:    "image/png",
+			Sizes:   fmt.Sprintf("%dx%d", size, size),
+			Purpose: "maskable",
+		})
+	}
+	name := h.Name
+	short := h.ShortName
+	if short == "" {
+		short = name
+	}
+	return json.MarshalIndent(struct {
+		ID              string         `json:"id"`
+		ShortName       string         `json:"short_name"`
+		Name            string         `json:"name"`
+		Description     string         `json:"description"`
+		Scope           string         `json:"scope"`
+		StartURL        string         `json:"start_url"`
+		BackgroundColor string         `json:"background_color"`
+		ThemeColor      string         `json:"theme_color"`
+		Display         string         `json:"display"`
+		Icons           []manifestIcon `json:"icons"`
+	}{
+		ID:              scope(h),
+		ShortName:       short,
+		Name:            name,
+		Description:     h.Description,
+		Scope:           scope(h),
+		StartURL:        scope(h),
+		BackgroundColor: h.BackgroundColor,
+		ThemeColor:      h.ThemeColor,
+		Display:         "standalone",
+		Icons:           icons,
+	}, "", "  ")
+}
+
+// ManifestHandler serves the manifest of the handler in place of go-app's.
+func ManifestHandler(h *app.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		manifest, err := Manifest(h)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/manifest+json")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write(manifest)
+	})
+}
+
+// WriteManifest replaces the manifest go-app wrote into a static website in the directory dir.
+func WriteManifest(dir string, h *app.Handler) error {
+	manifest, err := Manifest(h)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "manifest.webmanifest"), manifest, 0644)
+}
@@ -0,0 +1,434 @@
+package pwa
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/maxence-charriere/go-app/v10/pkg/app"
+	"github.com/richardwooding/gameoflife/pkg/game"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const (
+	webDir = "../../web" // The web directory of the repository
+	root   = "/gameoflife"
+)
+
+// workerHost runs the service worker in Node, as there is no browser to run it in tests.
+const workerHost = "testdata/worker_host.js"
+
+var pageLinks = regexp.MustCompile(`(?:src|href)="([^"]+)"`)
+
+// worker is the service worker of the served website, running in Node.
+type worker struct {
+	cmd    *exec.Cmd
+	in     io.WriteCloser
+	out    *bufio.Scanner
+	cached []string // Paths cached when it installed
+}
+
+// startWorker runs the service worker served at url.
+func startWorker(url string) (*worker, error) {
+	cmd := exec.Command("node", workerHost, url)
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("running the service worker in Node: %w", err)
+	}
+	w := &worker{cmd: cmd, in: in, out: bufio.NewScanner(out)}
+	w.out.Buffer(nil, 16<<20)
+	if err := w.read(&struct{}{}); err != nil {
+		w.stop()
+		return nil, err
+	}
+	return w, nil
+}
+
+// read decodes the next result of the worker into v.
+func (w *worker) read(v any) error {
+	if !w.out.Scan() {
+		if err := w.out.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("the service worker exited")
+	}
+	var failed struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(w.out.Bytes(), &failed); err != nil {
+		return err
+	}
+	if failed.Error != "" {
+		return fmt.Errorf("service worker: %s", failed.Error)
+	}
+	return json.Unmarshal(w.out.Bytes(), v)
+}
+
+// send dispatches an event to the worker and decodes its result into v.
+func (w *worker) send(v any, command ...string) error {
+	if _, err := fmt.Fprintln(w.in, strings.Join(command, " ")); err != nil {
+		return err
+	}
+	return w.read(v)
+}
+
+// navigate opens a page as the browser does with the worker installed, returning what the worker answers.
+func (w *worker) navigate(path string) ([]byte, error) {
+	var res struct {
+		Status int    `json:"status"`
+		Body   string `json:"body"`
+	}
+	if err := w.send(&res, "navigate", path); err != nil {
+		return nil, err
+	}
+	if res.Status != http.StatusOK {
+		return nil, fmt.Errorf("opening %s: %d", path, res.Status)
+	}
+	return []byte(res.Body), nil
+}
+
+func (w *worker) stop() {
+	w.in.Close()
+	w.cmd.Process.Kill()
+	w.cmd.Wait()
+}
+
+type offlineFeature struct {
+	dir      string
+	server   *httptest.Server
+	manifest struct {
+		Scope      string `json:"scope"`
+		StartURL   string `json:"start_url"`
+		ThemeColor string `json:"theme_color"`
+		Icons      []struct {
+			Src     string `json:"src"`
+			Type    string `json:"type"`
+			Sizes   string `json:"sizes"`
+			Purpose string `json:"purpose"`
+		} `json:"icons"`
+	}
+	worker    *worker
+	page      []byte
+	generated string
+}
+
+// copyDir copies the files of the directory src into dst.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, rel), b, 0644)
+	})
+}
+
+func (f *offlineFeature) theStaticWebsiteIsGeneratedForGitHubPages() error {
+	dir, err := os.MkdirTemp("", "dist")
+	if err != nil {
+		return err
+	}
+	f.dir = dir
+	// As main does for webmode.Static.
+	app.RouteWithRegexp("/(.*)", func() app.Composer { return &game.Game{} })
+	h := &app.Handler{
+		Name:        "Conway's Game of Life",
+		Description: "A live demo of Conway's Game of Life.",
+		Styles:      []string{"/web/gameoflife.css"},
+		Resources:   app.GitHubPages("gameoflife"),
+	}
+	Configure(h)
+	if err := app.GenerateStaticWebsite(dir, h); err != nil {
+		return err
+	}
+	if err := WriteManifest(dir, h); err != nil {
+		return err
+	}
+	// As the workflow that deploys the website does, with a stand-in for app.wasm, which isn't built for tests.
+	if err := copyDir(webDir, filepath.Join(dir, "web")); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "web", "app.wasm")); os.IsNotExist(err) {
+		return os.WriteFile(filepath.Join(dir, "web", "app.wasm"), []byte("\x00asm"), 0644)
+	}
+	return nil
+}
+
+func (f *offlineFeature) itIsServedLocally() error {
+	// GitHub Pages serves the website under the name of the repository, redirecting its path to the path with a
+	// trailing slash, as the mux does.
+	mux := http.NewServeMux()
+	mux.Handle(root+"/", http.StripPrefix(root, http.FileServer(http.Dir(f.dir))))
+	f.server = httptest.NewServer(mux)
+	return nil
+}
+
+// get fetches a path from the server, following redirects.
+func (f *offlineFeature) get(path string) ([]byte, error) {
+	res, err := http.Get(f.server.URL + path)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", path, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+func (f *offlineFeature) iFetchTheManifest() error {
+	b, err := f.get(root + "/manifest.webmanifest")
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &f.manifest)
+}
+
+func (f *offlineFeature) itShouldListAnIcon(size int, purpose string) error {
+	sizes := fmt.Sprintf("%dx%d", size, size)
+	for _, icon := range f.manifest.Icons {
+		if icon.Sizes == sizes && (icon.Purpose == purpose || icon.Purpose == "" && purpose == "any") {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected a %s %s icon in %+v", sizes, purpose, f.manifest.Icons)
+}
+
+func (f *offlineFeature) everyIconItListsShouldBeServedAtItsSize() error {
+	for _, icon := range f.manifest.Icons {
+		b, err := f.get(icon.Src)
+		if err != nil {
+			return err
+		}
+		if icon.Type != "image/png" {
+			continue
+		}
+		img, err := png.DecodeConfig(bytes.NewReader(b))
+		if err != nil {
+			return fmt.Errorf("decoding %s: %w", icon.Src, err)
+		}
+		if got := fmt.Sprintf("%dx%d", img.Width, img.Height); got != icon.Sizes {
+			return fmt.Errorf("expected %s to be %s, got %s", icon.Src, icon.Sizes, got)
+		}
+	}
+	return nil
+}
+
+func (f *offlineFeature) theAppShouldStartAtInScope(start, scope string) error {
+	if f.manifest.StartURL != start || f.manifest.Scope != scope {
+		return fmt.Errorf("expected to start at %s in scope %s, got %s in %s", start, scope, f.manifest.StartURL, f.manifest.Scope)
+	}
+	return nil
+}
+
+func (f *offlineFeature) itsThemeColourShouldBe(colour string) error {
+	if f.manifest.ThemeColor != colour {
+		return fmt.Errorf("expected the theme colour %s, got %s", colour, f.manifest.ThemeColor)
+	}
+	return nil
+}
+
+// theServiceWorkerInstalls runs the service worker the website serves and installs and activates it, failing as
+// its installation does if anything it caches can't be fetched.
+func (f *offlineFeature) theServiceWorkerInstalls() error {
+	w, err := startWorker(f.server.URL + root + "/app-worker.js")
+	if err != nil {
+		return err
+	}
+	f.worker = w
+	var installed struct {
+		Cached []string `json:"cached"`
+	}
+	if err := w.send(&installed, "install"); err != nil {
+		return fmt.Errorf("installing the service worker: %w", err)
+	}
+	w.cached = installed.Cached
+	return w.send(&struct{}{}, "activate")
+}
+
+func (f *offlineFeature) itShouldCache(path string) error {
+	if !slices.Contains(f.worker.cached, path) {
+		return fmt.Errorf("expected %s to be cached, got %v", path, f.worker.cached)
+	}
+	return nil
+}
+
+func (f *offlineFeature) everythingThePageLoadsFromTheSiteShouldBeCached() error {
+	page, err := f.worker.navigate(root + "/")
+	if err != nil {
+		return err
+	}
+	for _, m := range pageLinks.FindAllSubmatch(page, -1) {
+		link := string(m[1])
+		if !strings.HasPrefix(link, root+"/") {
+			continue
+		}
+		if !slices.Contains(f.worker.cached, link) {
+			return fmt.Errorf("the page loads %s, which isn't cached", link)
+		}
+	}
+	return nil
+}
+
+func (f *offlineFeature) theServerGoesOffline() error {
+	f.server.Close()
+	return nil
+}
+
+// iOpen navigates to a path through the service worker, which answers from its cache while offline.
+func (f *offlineFeature) iOpen(path string) error {
+	if _, err := http.Get(f.server.URL + path); err == nil {
+		return fmt.Errorf("expected the server to be offline")
+	}
+	page, err := f.worker.navigate(path)
+	if err != nil {
+		return err
+	}
+	f.page = page
+	return nil
+}
+
+func (f *offlineFeature) theGamesPageShouldBeShown() error {
+	for _, want := range []string{"<title>", root + "/app.js", root + "/manifest.webmanifest"} {
+		if !bytes.Contains(f.page, []byte(want)) {
+			return fmt.Errorf("expected the page to contain %q", want)
+		}
+	}
+	return nil
+}
+
+func (f *offlineFeature) iGenerateTheIconsFromTheLogo() error {
+	dir, err := os.MkdirTemp("", "icons")
+	if err != nil {
+		return err
+	}
+	f.generated = dir
+	return GenerateIcons(filepath.Join(webDir, "logo.png"), dir)
+}
+
+func (f *offlineFeature) theyShouldMatchTheIconsInTheRepository() error {
+	var names []string
+	for _, size := range IconSizes {
+		names = append(names, IconName(size))
+	}
+	for _, size := range MaskableSizes {
+		names = append(names, MaskableName(size))
+	}
+	committed, err := filepath.Glob(filepath.Join(webDir, "icons", "*.png"))
+	if err != nil {
+		return err
+	}
+	if len(committed) != len(names) {
+		return fmt.Errorf("expected %d icons in the repository, got %d", len(names), len(committed))
+	}
+	for _, name := range names {
+		want, err := os.ReadFile(filepath.Join(f.generated, name))
+		if err != nil {
+			return err
+		}
+		got, err := os.ReadFile(filepath.Join(webDir, "icons", name))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(got, want) {
+			return fmt.Errorf("%s is out of date: run gameoflife icons", name)
+		}
+	}
+	return nil
+}
+
+func InitializeOfflineScenario(ctx *godog.ScenarioContext) {
+	f := &offlineFeature{}
+	ctx.After(func(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
+		if f.worker != nil {
+			f.worker.stop()
+		}
+		if f.server != nil {
+			f.server.Close()
+		}
+		for _, dir := range []string{f.dir, f.generated} {
+			if dir != "" {
+				os.RemoveAll(dir)
+			}
+		}
+		return ctx, nil
+	})
+	ctx.Step(`^the static website is generated for GitHub Pages$`, f.theStaticWebsiteIsGeneratedForGitHubPages)
+	ctx.Step(`^it is served locally$`, f.itIsServedLocally)
+	ctx.Step(`^I fetch the manifest$`, f.iFetchTheManifest)
+	ctx.Step(`^it should list a (\d+) (any|maskable) icon$`, f.itShouldListAnIcon)
+	ctx.Step(`^every icon it lists should be served at its size$`, f.everyIconItListsShouldBeServedAtItsSize)
+	ctx.Step(`^the app should start at "([^"]*)" in scope "([^"]*)"$`, f.theAppShouldStartAtInScope)
+	ctx.Step(`^its theme colour should be "([^"]*)"$`, f.itsThemeColourShouldBe)
+	ctx.Step(`^the service worker (?:installs|has installed)$`, f.theServiceWorkerInstalls)
+	ctx.Step(`^it should cache "([^"]*)"$`, f.itShouldCache)
+	ctx.Step(`^everything the page loads from the site should be cached$`, f.everythingThePageLoadsFromTheSiteShouldBeCached)
+	ctx.Step(`^the server goes offline$`, f.theServerGoesOffline)
+	ctx.Step(`^I open "([^"]*)"$`, f.iOpen)
+	ctx.Step(`^the game's page should be shown$`, f.theGamesPageShouldBeShown)
+	ctx.Step(`^I generate the icons from the logo$`, f.iGenerateTheIconsFromTheLogo)
+	ctx.Step(`^they should match the icons in the repository$`, f.theyShouldMatchTheIconsInTheRepository)
+}
+
+func TestOfflineFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "offline",
+		ScenarioInitializer: InitializeOfflineScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/offline.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
+
+func TestServiceWorkerFeatures(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("the service worker runs in Node, which isn't installed")
+	}
+	suite := godog.TestSuite{
+		Name:                "service worker",
+		ScenarioInitializer: InitializeOfflineScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/worker.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,158 @@
+// Host that runs a service worker in Node for the offline tests: the worker served at the URL given as argument
+// is evaluated as a browser would, with Node's fetch for the network and an in-memory Cache Storage. The test
+// drives it with one command per line on stdin and reads one JSON result per line on stdout:
+//
+//	install           dispatches the install event; answers {cached: [paths]} once it completes
+//	activate          dispatches the activate event
+//	navigate <path>   dispatches the fetch event of a navigation; answers {status, body}
+"use strict";
+
+const readline = require("node:readline");
+const vm = require("node:vm");
+
+const workerURL = new URL(process.argv[2]);
+const origin = workerURL.origin;
+
+// key returns the URL a request or path is cached under.
+function key(request) {
+  return new URL(typeof request === "string" ? request : request.url, origin).href;
+}
+
+// MemoryCache is a Cache that keeps the bodies of its responses.
+class MemoryCache {
+  constructor() {
+    this.entries = new Map();
+  }
+
+  async put(request, response) {
+    const body = await response.arrayBuffer();
+    this.entries.set(key(request), {
+      body,
+      status: response.status,
+      statusText: response.statusText,
+      headers: [...response.headers],
+    });
+  }
+
+  async match(request) {
+    const entry = this.entries.get(key(request));
+    if (!entry) {
+      return undefined;
+    }
+    return new Response(entry.body.slice(0), entry);
+  }
+}
+
+const caches = {
+  stores: new Map(),
+  async open(name) {
+    if (!this.stores.has(name)) {
+      this.stores.set(name, new MemoryCache());
+    }
+    return this.stores.get(name);
+  },
+  async keys() {
+    return [...this.stores.keys()];
+  },
+  async delete(name) {
+    return this.stores.delete(name);
+  },
+  async match(request) {
+    for (const cache of this.stores.values()) {
+      const response = await cache.match(request);
+      if (response) {
+        return response;
+      }
+    }
+    return undefined;
+  },
+};
+
+const listeners = {};
+
+const self = {
+  location: workerURL,
+  addEventListener(type, listener) {
+    (listeners[type] ||= []).push(listener);
+  },
+  skipWaiting: async () => {},
+  clients: { claim: async () => {} },
+};
+
+// dispatch calls the listeners of an event, returning the promises they wait for and the response they give.
+function dispatch(type, fields) {
+  const pending = [];
+  let response;
+  const event = {
+    ...fields,
+    waitUntil(promise) {
+      pending.push(promise);
+    },
+    respondWith(promise) {
+      response = Promise.resolve(promise);
+    },
+  };
+  for (const listener of listeners[type] || []) {
+    listener(event);
+  }
+  return { done: Promise.all(pending), response };
+}
+
+const context = vm.createContext({
+  self,
+  caches,
+  console: { log() {}, error: console.error },
+  fetch: (request, init) => fetch(typeof request === "string" ? new URL(request, origin) : request, init),
+  Response,
+  Request,
+  Headers,
+  URL,
+  Promise,
+  Error,
+});
+
+const commands = {
+  async install() {
+    await dispatch("install").done;
+    const cached = [];
+    for (const cache of caches.stores.values()) {
+      for (const url of cache.entries.keys()) {
+        cached.push(new URL(url).pathname);
+      }
+    }
+    return { cached };
+  },
+  async activate() {
+    await dispatch("activate").done;
+    return {};
+  },
+  async navigate(path) {
+    const request = new Request(new URL(path, origin));
+    Object.defineProperty(request, "mode", { value: "navigate" });
+    const { response } = dispatch("fetch", { request });
+    const res = await (response || fetch(request));
+    return { status: res.status, body: await res.text() };
+  },
+};
+
+async function main() {
+  const res = await fetch(workerURL);
+  if (!res.ok) {
+    throw new Error("fetching the worker: " + res.status);
+  }
+  vm.runInContext(await res.text(), context, { filename: workerURL.pathname });
+  console.log(JSON.stringify({}));
+  for await (const line of readline.createInterface({ input: process.stdin })) {
+    const [name, ...args] = line.split(" ");
+    try {
+      console.log(JSON.stringify(await commands[name](...args)));
+    } catch (err) {
+      console.log(JSON.stringify({ error: String(err && err.message ? err.message : err) }));
+    }
+  }
+}
+
+main().catch((err) => {
+  console.error(err);
+  process.exit(1);
+});
@@ -0,0 +1,75 @@
+// Service worker of Conway's Game of Life. It caches the app when it installs, so the game runs offline, and
+// answers for any page of the app with the start page when offline, as every page is the game with the colony
+// in its path or fragment.
+const cacheName = "app-" + "{{.Version}}";
+const resourcesToCache = {{.ResourcesToCache}};
+const startPage = "{{.StartPage}}";
+
+self.addEventListener("install", (event) => {
+  // The worker only installs once everything is cached, so a new version never replaces one that works offline
+  // with one that doesn't.
+  console.log("installing app worker {{.Version}}");
+  event.waitUntil(installWorker().then(() => self.skipWaiting()));
+});
+
+async function installWorker() {
+  const cache = await caches.open(cacheName);
+  await Promise.all(
+    resourcesToCache.map(async (url) => {
+      const response = await fetch(url, { cache: "reload" });
+      if (!response.ok) {
+        throw new Error("caching " + url + " failed: " + response.status);
+      }
+      await cache.put(url, response.redirected ? await unredirected(response) : response);
+    })
+  );
+}
+
+// unredirected copies a response that followed a redirect, such as GitHub Pages' from the app's path to the path
+// with a trailing slash, as browsers refuse redirected responses to navigations.
+async function unredirected(response) {
+  return new Response(await response.blob(), {
+    status: response.status,
+    statusText: response.statusText,
+    headers: response.headers,
+  });
+}
+
+self.addEventListener("activate", (event) => {
+  event.waitUntil(
+    caches
+      .keys()
+      .then((keys) =>
+        Promise.all(
+          keys.filter((key) => key !== cacheName).map((key) => caches.delete(key))
+        )
+      )
+      .then(() => self.clients.claim())
+  );
+  console.log("app worker {{.Version}} is activated");
+});
+
+self.addEventListener("fetch", (event) => {
+  if (event.request.method !== "GET") {
+    return;
+  }
+  event.respondWith(fetchWithCache(event.request));
+});
+
+async function fetchWithCache(request) {
+  const cached = await caches.match(request);
+  if (cached) {
+    return cached;
+  }
+  try {
+    return await fetch(request);
+  } catch (err) {
+    if (request.mode === "navigate") {
+      const page = await caches.match(startPage);
+      if (page) {
+        return page;
+      }
+    }
+    throw err;
+  }
+}
@@ -0,0 +1,275 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"hash/crc32"
+	"image"
+	"image/gif"
+	"image/png"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format identifies an animated image format.
+type Format uint8
+
+const (
+	GIF Format = iota
+	APNG
+)
+
+func (f Format) String() string {
+	switch f {
+	case GIF:
+		return "gif"
+	case APNG:
+		return "apng"
+	default:
+		return "unknown"
+	}
+}
+
+// MimeType returns the media type of the format.
+func (f Format) MimeType() string {
+	if f == APNG {
+		return "image/apng"
+	}
+	return "image/gif"
+}
+
+// Extension returns the file name extension used for the format.
+func (f Format) Extension() string {
+	if f == APNG {
+		return "png"
+	}
+	return "gif"
+}
+
+var InvalidFormat = errors.New("invalid animation format")
+
+// ParseFormat parses "gif", "apng" or "png" (an alias for apng).
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "gif":
+		return GIF, nil
+	case "apng", "png":
+		return APNG, nil
+	default:
+		return GIF, InvalidFormat
+	}
+}
+
+// AnimationOptions controls how a run of a colony is animated.
+type AnimationOptions struct {
+	Options
+	Generations int           // Number of generations to run after the first frame
+	Delay       time.Duration // Time each frame is shown for
+}
+
+// Limits of animations.
+const (
+	MinDelay    = 10 * time.Millisecond    // Shortest delay, a hundredth of a second as GIF delays are counted in
+	MaxDelay    = 65535 * time.Millisecond // Longest delay, the most APNG frames can be shown for in milliseconds
+	MaxCellSize = 1024                     // Widest cell in pixels
+	MaxPixels   = 1 << 28                  // Most pixels over all frames, which are all held in memory at once
+	maxColours  = 256                      // Most colours a GIF or paletted PNG holds
+)
+
+var InvalidOptions = errors.New("invalid animation options")
+
+// validate checks the options describe an animation of the colony that can be drawn and encoded: cells at least
+// a pixel wide, no negative generation count, a delay both formats can represent, a palette that fits in 256
+// colours, and frames that fit in memory.
+func (o AnimationOptions) validate(c *model.Colony) error {
+	switch {
+	case o.CellSize < 1 || o.CellSize > MaxCellSize:
+		return fmt.Errorf("%w: cell size %d is not between 1 and %d", InvalidOptions, o.CellSize, MaxCellSize)
+	case o.Generations < 0:
+		return fmt.Errorf("%w: %d generations", InvalidOptions, o.Generations)
+	case o.Delay < MinDelay || o.Delay > MaxDelay:
+		return fmt.Errorf("%w: delay %s is not between %s and %s", InvalidOptions, o.Delay, MinDelay, MaxDelay)
+	}
+	if n := len(o.palette(c.Rule())); n > maxColours {
+		return fmt.Errorf("%w: %d colours don't fit in a palette of %d", InvalidOptions, n, maxColours)
+	}
+	bounds := o.frameBounds(c)
+	if pixels := float64(bounds.Dx()) * float64(bounds.Dy()) * float64(o.Generations+1); pixels > MaxPixels {
+		return fmt.Errorf("%w: %d frames of %dx%d pixels are more than %d pixels", InvalidOptions, o.Generations+1, bounds.Dx(), bounds.Dy(), MaxPixels)
+	}
+	return nil
+}
+
+// DefaultAnimationOptions returns options for a 100 generation animation at 10 frames per second.
+func DefaultAnimationOptions() AnimationOptions {
+	return AnimationOptions{
+		Options:     DefaultOptions(),
+		Generations: 100,
+		Delay:       100 * time.Millisecond,
+	}
+}
+
+// frames runs a copy of the colony and draws every generation, leaving the colony untouched. Display modes
+// that need it track the run, carrying on from the colony's own tracking if it has any.
+func frames(c *model.Colony, o AnimationOptions) []*image.Paletted {
+	run := c.Clone()
+	if o.Mode.Tracked() {
+		run.Track(true)
+	}
+	images := make([]*image.Paletted, 0, o.Generations+1)
+	images = append(images, frame(run, o.Options))
+	for i := 0; i < o.Generations; i++ {
+		run.Generate()
+		images = append(images, frame(run, o.Options))
+	}
+	return images
+}
+
+// Animate writes the run of the colony in the given format.
+func Animate(w io.Writer, c *model.Colony, f Format, o AnimationOptions) error {
+	switch f {
+	case GIF:
+		return WriteGIF(w, c, o)
+	case APNG:
+		return WriteAPNG(w, c, o)
+	default:
+		return InvalidFormat
+	}
+}
+
+// WriteGIF writes the run of the colony as a looping animated GIF.
+func WriteGIF(w io.Writer, c *model.Colony, o AnimationOptions) error {
+	if err := o.validate(c); err != nil {
+		return err
+	}
+	images := frames(c, o)
+	// GIF delays are in hundredths of a second, rounded to the nearest.
+	delay := int((o.Delay + MinDelay/2) / MinDelay)
+	anim := &gif.GIF{
+		Image: images,
+		Delay: make([]int, len(images)),
+	}
+	for i := range anim.Delay {
+		anim.Delay[i] = delay
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngChunk is a single chunk of a PNG stream.
+type pngChunk struct {
+	kind string
+	data []byte
+}
+
+// readChunks splits an encoded PNG into its chunks.
+func readChunks(b []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(b, pngSignature) {
+		return nil, errors.New("missing PNG signature")
+	}
+	b = b[len(pngSignature):]
+	var chunks []pngChunk
+	for len(b) >= 12 {
+		n := int(binary.BigEndian.Uint32(b[:4]))
+		if len(b) < 12+n {
+			return nil, errors.New("truncated PNG chunk")
+		}
+		chunks = append(chunks, pngChunk{kind: string(b[4:8]), data: b[8 : 8+n]})
+		b = b[12+n:]
+	}
+	return chunks, nil
+}
+
+// writeChunk writes a PNG chunk with its length and CRC.
+func writeChunk(w io.Writer, kind string, data []byte) error {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+	copy(header[4:], kind)
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data)
+	var footer [4]byte
+	binary.BigEndian.PutUint32(footer[:], crc.Sum32())
+	for _, b := range [][]byte{header[:], data, footer[:]} {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteAPNG writes the run of the colony as a looping animated PNG.
+// Each frame is encoded with image/png and its image data is repackaged into APNG frame chunks.
+func WriteAPNG(w io.Writer, c *model.Colony, o AnimationOptions) error {
+	if err := o.validate(c); err != nil {
+		return err
+	}
+	images := frames(c, o)
+	if _, err := w.Write(pngSignature); err != nil {
+		return err
+	}
+	var sequence uint32
+	for i, img := range images {
+		var buff bytes.Buffer
+		if err := png.Encode(&buff, img); err != nil {
+			return err
+		}
+		chunks, err := readChunks(buff.Bytes())
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			// The header and palette of the first frame are shared by the whole animation.
+			for _, chunk := range chunks {
+				if chunk.kind == "IDAT" || chunk.kind == "IEND" {
+					break
+				}
+				if err := writeChunk(w, chunk.kind, chunk.data); err != nil {
+					return err
+				}
+				if chunk.kind == "IHDR" {
+					actl := make([]byte, 8)
+					binary.BigEndian.PutUint32(actl[0:], uint32(len(images)))
+					binary.BigEndian.PutUint32(actl[4:], 0) // loop forever
+					if err := writeChunk(w, "acTL", actl); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		bounds := img.Bounds()
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], sequence)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(bounds.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(bounds.Dy()))
+		binary.BigEndian.PutUint16(fctl[20:], uint16(o.Delay.Milliseconds()))
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		sequence++
+		if err := writeChunk(w, "fcTL", fctl); err != nil {
+			return err
+		}
+		for _, chunk := range chunks {
+			if chunk.kind != "IDAT" {
+				continue
+			}
+			if i == 0 {
+				err = writeChunk(w, "IDAT", chunk.data)
+			} else {
+				fdat := make([]byte, 4+len(chunk.data))
+				binary.BigEndian.PutUint32(fdat, sequence)
+				copy(fdat[4:], chunk.data)
+				sequence++
+				err = writeChunk(w, "fdAT", fdat)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return writeChunk(w, "IEND", nil)
+}
@@ -0,0 +1,78 @@
+Feature: Animated export of a run
+
+  Scenario: A GIF has one frame per generation plus the starting frame
+    Given a 5x5 colony with a blinker
+    When the colony is exported as a GIF for 4 generations with a cell size of 3
+    Then the GIF should have 5 frames of 15x15 pixels
+    And the colony should still be at generation 0
+
+  Scenario: Grid lines add a one pixel border around every cell
+    Given a 5x5 colony with a blinker
+    And grid lines are drawn
+    When the colony is exported as a GIF for 1 generations with a cell size of 3
+    Then the GIF should have 2 frames of 21x21 pixels
+
+  Scenario: An APNG declares every frame and decodes as a PNG
+    Given a 5x5 colony with a blinker
+    When the colony is exported as an APNG for 3 generations with a cell size of 2
+    Then the APNG should declare 4 frames
+    And the APNG should decode to a 10x10 image
+    And the pixel at (3,5) of the decoded image should be alive
+
+  Scenario Outline: An animation that can't be drawn is refused
+    Given a 5x5 colony with a blinker
+    Then exporting it as a <format> for <generations> generations with a cell size of <cell> should fail
+
+    Examples:
+      | format | generations | cell |
+      | GIF    | -5          | 3    |
+      | APNG   | -1          | 3    |
+      | GIF    | 4           | 0    |
+      | APNG   | 4           | -2   |
+
+  Scenario Outline: Delays are kept within what both formats can show
+    Given a 5x5 colony with a blinker
+    And frames are shown for <delay>
+    Then exporting it as a <format> for 4 generations with a cell size of 3 should fail
+
+    Examples:
+      | delay   | format |
+      | 0s      | GIF    |
+      | 9ms     | GIF    |
+      | 9ms     | APNG   |
+      | 65.536s | APNG   |
+      | 2m      | GIF    |
+
+  Scenario Outline: GIF delays are rounded to the nearest hundredth of a second
+    Given a 5x5 colony with a blinker
+    And frames are shown for <delay>
+    When the colony is exported as a GIF for 2 generations with a cell size of 3
+    Then every GIF frame should be shown for <hundredths> hundredths of a second
+
+    Examples:
+      | delay   | hundredths |
+      | 10ms    | 1          |
+      | 14ms    | 1          |
+      | 15ms    | 2          |
+      | 65.535s | 6554       |
+
+  Scenario: The longest delay fits in an APNG frame
+    Given a 5x5 colony with a blinker
+    And frames are shown for 65.535s
+    When the colony is exported as an APNG for 2 generations with a cell size of 3
+    Then every APNG frame should be shown for 65535 milliseconds
+
+  Scenario: A rule with more colours than a palette holds is refused
+    Given a 5x5 colony with a blinker
+    And the colony runs under a rule with 300 states
+    Then exporting it as a GIF for 4 generations with a cell size of 3 should fail
+
+  Scenario Outline: Animations too large to hold in memory are refused
+    Given a <size> colony with a blinker
+    Then exporting it as a <format> for <generations> generations with a cell size of <cell> should fail
+
+    Examples:
+      | size      | format | generations | cell |
+      | 1000x1000 | GIF    | 100         | 8    |
+      | 5x5       | APNG   | 100000000   | 3    |
+      | 5x5       | GIF    | 4           | 1025 |
@@ -0,0 +1,38 @@
+Feature: Hexagonal grids
+
+  Scenario: A hexagonal colony is drawn with offset rows
+    Given a 5x5 colony with a blinker
+    And the colony runs under the rule "B2/S34H"
+    When the colony is rendered with a cell size of 20
+    Then the image should be 140x93 pixels
+    And the centre of cell (1,2) in the rendered image should be alive
+    And the centre of cell (3,2) in the rendered image should be alive
+    And the centre of cell (2,1) in the rendered image should be dead
+    And the centre of cell (0,2) in the rendered image should be dead
+
+  Scenario: A hexagonal colony is written as SVG polygons
+    Given a 5x5 colony with a blinker
+    And the colony runs under the rule "B2/S34H"
+    When the colony is written as an SVG with a cell size of 20
+    Then the SVG should contain 3 live cell polygons
+    And the SVG should declare a size of 140x93
+
+  Scenario Outline: Points are hit-tested against hexagons
+    Given a 5x5 hexagonal layout with hexagons 20 pixels wide
+    Then the centre of cell (<x>,<y>) offset by (<dx>,<dy>) should hit cell (<hx>,<hy>)
+
+    Examples:
+      | x | y | dx | dy  | hx | hy |
+      | 1 | 1 | 0  | 0   | 1  | 1  |
+      | 1 | 1 | 0  | -9  | 1  | 1  |
+      | 1 | 1 | -6 | -11 | 0  | 0  |
+      | 1 | 1 | 6  | -11 | 1  | 0  |
+      | 1 | 1 | 9  | 0   | 1  | 1  |
+      | 1 | 1 | 11 | 0   | 2  | 1  |
+      | 1 | 1 | -6 | 11  | 1  | 2  |
+      | 1 | 1 | 6  | 11  | 2  | 2  |
+      | 4 | 4 | 0  | 0   | 4  | 4  |
+
+  Scenario: Points outside the colony miss it
+    Given a 5x5 hexagonal layout with hexagons 20 pixels wide
+    Then the point (5,5) should miss the colony
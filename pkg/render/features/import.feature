@@ -0,0 +1,34 @@
+Feature: Importing patterns from images
+
+  Scenario Outline: A rendered pattern imports back to the same cells
+    Given a 7x6 colony with a glider at (2,1)
+    And the colony is rendered with a cell size of <size> and grid lines <lines>
+    When the image is imported detecting the cell size
+    Then the imported colony should be 7x6
+    And the imported colony should match the original
+
+    Examples:
+      | size | lines |
+      | 5    | off   |
+      | 8    | off   |
+      | 8    | on    |
+      | 12   | on    |
+
+  Scenario: Dark cells on a light background are alive
+    Given a 7x6 colony with a glider at (2,1)
+    And the colony is rendered in black on white with a cell size of 10
+    When the image is imported detecting the cell size
+    Then the imported colony should match the original
+
+  Scenario: An explicit cell size overrides detection
+    Given a 7x6 colony with a glider at (2,1)
+    And the colony is rendered with a cell size of 6 and grid lines off
+    When the image is imported with a cell size of 6
+    Then the imported colony should be 7x6
+    And the imported colony should match the original
+
+  Scenario: An image without live pixels is rejected
+    Given a 4x4 colony with a glider at (9,9)
+    And the colony is rendered with a cell size of 4 and grid lines off
+    When the image is imported detecting the cell size
+    Then the import should fail with "image has no live pixels"
@@ -0,0 +1,58 @@
+Feature: Display modes
+
+  Scenario: The heat map shows the stator of an oscillator hotter than its rotor
+    Given a 5x5 colony with a blinker
+    And the colony is tracked
+    And the display mode is "heat"
+    When the colony runs for 1 generation
+    And the colony is rendered with a cell size of 4
+    Then the pixel at (9,9) of the rendered image should be coloured 255,208,0
+    And the pixel at (5,9) of the rendered image should be coloured 223,38,0
+    And the pixel at (9,5) of the rendered image should be coloured 223,38,0
+    And the pixel at (1,1) of the rendered image should be dead
+
+  Scenario: Old cells fade from the alive colour to the old colour
+    Given a 5x5 colony with a blinker
+    And the colony is tracked
+    And the display mode is "age"
+    When the colony runs for 63 generations
+    And the colony is rendered with a cell size of 4
+    Then the pixel at (9,9) of the rendered image should be coloured 30,144,255
+    And the pixel at (9,5) of the rendered image should be alive
+
+  Scenario: Recently dead cells leave a trail
+    Given a 5x5 colony with a blinker
+    And the colony is tracked
+    And the display mode is "trail"
+    When the colony runs for 1 generation
+    And the colony is rendered with a cell size of 4
+    Then the pixel at (9,9) of the rendered image should be alive
+    And the pixel at (5,9) of the rendered image should be coloured 63,93,16
+    And the pixel at (1,1) of the rendered image should be dead
+
+  Scenario: An animation tracks its run for the display mode
+    Given a 5x5 colony with a blinker
+    And the display mode is "heat"
+    When the colony is exported as a GIF for 2 generations with a cell size of 4
+    Then the last frame pixel at (9,9) should be coloured 255,208,0
+    And the colony should not be tracked
+
+  Scenario: An SVG groups cells by the colour of the display mode
+    Given a 5x5 colony with a blinker
+    And the colony is tracked
+    And the display mode is "heat"
+    When the colony runs for 1 generation
+    And the colony is written as an SVG with a cell size of 4
+    Then the SVG should have 2 colour groups
+
+  Scenario Outline: Display modes are parsed by name
+    When the display mode "<name>" is parsed
+    Then it should be the <mode> mode
+
+    Examples:
+      | name   | mode    |
+      | states | states  |
+      | Age    | age     |
+      | TRAIL  | trail   |
+      | heat   | heat    |
+      | glow   | invalid |
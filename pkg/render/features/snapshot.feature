@@ -0,0 +1,23 @@
+Feature: Static snapshot of a colony
+
+  Scenario: Render draws live cells in the alive colour
+    Given a 5x5 colony with a blinker
+    When the colony is rendered with a cell size of 4
+    Then the image should be 20x20 pixels
+    And the pixel at (9,9) of the rendered image should be alive
+    And the pixel at (9,1) of the rendered image should be dead
+
+  Scenario: An SVG has one rect per live cell
+    Given a 5x5 colony with a blinker
+    When the colony is written as an SVG with a cell size of 4
+    Then the SVG should contain 3 live cell rects
+    And the SVG should declare a size of 20x20
+
+  Scenario: Rule tables are drawn in the colours of their @COLORS section
+    Given a 5x5 colony with a blinker
+    And the colony runs under the rule "WireWorld"
+    And the cell at (0,0) is in state 3
+    When the colony is rendered with a cell size of 4
+    Then the pixel at (1,1) of the rendered image should be coloured 255,128,0
+    And the pixel at (5,9) of the rendered image should be coloured 0,128,255
+    And the pixel at (1,5) of the rendered image should be coloured 48,48,48
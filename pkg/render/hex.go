@@ -0,0 +1,108 @@
+package render
+
+import (
+	"github.com/richardwooding/gameoflife/model"
+	"image"
+	"math"
+)
+
+// HexLayout places the cells of a colony on a hexagonal grid of pointy-topped hexagons. Each row is offset half
+// a cell left of the row above, so a cell's six neighbours under model.Hexagonal are the hexagons touching it.
+type HexLayout struct {
+	Width, Height int     // Size of the colony in cells
+	Size          float64 // Distance between the centres of adjacent hexagons, and the width of a hexagon
+}
+
+// radius returns the distance from the centre of a hexagon to its corners.
+func (l HexLayout) radius() float64 {
+	return l.Size / math.Sqrt(3)
+}
+
+// rowPitch returns the vertical distance between the centres of adjacent rows.
+func (l HexLayout) rowPitch() float64 {
+	return l.radius() * 1.5
+}
+
+// Bounds returns the width and height of the drawing.
+func (l HexLayout) Bounds() (w, h float64) {
+	w = (float64(l.Width) + float64(l.Height-1)/2) * l.Size
+	h = float64(l.Height-1)*l.rowPitch() + 2*l.radius()
+	return w, h
+}
+
+// Centre returns the position of the centre of the cell at (x, y).
+func (l HexLayout) Centre(x, y int) (cx, cy float64) {
+	cx = (float64(x) + float64(l.Height-1-y)/2 + 0.5) * l.Size
+	cy = float64(y)*l.rowPitch() + l.radius()
+	return cx, cy
+}
+
+// Corners returns the six corners of the hexagon of the cell at (x, y), clockwise from the top.
+func (l HexLayout) Corners(x, y int) [6][2]float64 {
+	cx, cy := l.Centre(x, y)
+	r := l.radius()
+	var corners [6][2]float64
+	for i := range corners {
+		angle := math.Pi/3*float64(i) - math.Pi/2
+		corners[i] = [2]float64{cx + r*math.Cos(angle), cy + r*math.Sin(angle)}
+	}
+	return corners
+}
+
+// CellAt returns the cell whose hexagon contains the point (px, py), if it is in the colony.
+func (l HexLayout) CellAt(px, py float64) (x, y int, ok bool) {
+	x, y, _, _ = l.nearest(px, py)
+	return x, y, x >= 0 && y >= 0 && x < l.Width && y < l.Height
+}
+
+// nearest finds the hexagon containing a point, which is the one with the nearest centre, and returns the
+// distances to that centre and to the next nearest one.
+func (l HexLayout) nearest(px, py float64) (x, y int, d1, d2 float64) {
+	d1, d2 = math.Inf(1), math.Inf(1)
+	row := int(math.Round((py - l.radius()) / l.rowPitch()))
+	for ry := row - 1; ry <= row+1; ry++ {
+		col := int(math.Round(px/l.Size - 0.5 - float64(l.Height-1-ry)/2))
+		for rx := col - 1; rx <= col+1; rx++ {
+			cx, cy := l.Centre(rx, ry)
+			d := math.Hypot(px-cx, py-cy)
+			switch {
+			case d < d1:
+				d1, d2 = d, d1
+				x, y = rx, ry
+			case d < d2:
+				d2 = d
+			}
+		}
+	}
+	return x, y, d1, d2
+}
+
+// hexLayout returns the layout used to draw a hexagonal colony with the options.
+func (o Options) hexLayout(dx, dy int) HexLayout {
+	return HexLayout{Width: dx, Height: dy, Size: float64(o.CellSize)}
+}
+
+// hexBounds returns the image rectangle needed to draw a hexagonal colony of dx by dy cells.
+func (o Options) hexBounds(dx, dy int) image.Rectangle {
+	w, h := o.hexLayout(dx, dy).Bounds()
+	return image.Rect(0, 0, int(math.Ceil(w)), int(math.Ceil(h)))
+}
+
+// hexFrame draws a colony on a hexagonal grid, colouring each pixel by the hexagon its centre falls in.
+func hexFrame(c *model.Colony, o Options, img *image.Paletted) {
+	l := o.hexLayout(c.Width(), c.Height())
+	bounds := img.Bounds()
+	for py := 0; py < bounds.Dy(); py++ {
+		for px := 0; px < bounds.Dx(); px++ {
+			x, y, d1, d2 := l.nearest(float64(px)+0.5, float64(py)+0.5)
+			i := deadIndex
+			if x >= 0 && y >= 0 && x < c.Width() && y < c.Height() {
+				i = o.cellIndex(c, x, y)
+				if o.GridLines && d2-d1 < 1 {
+					i = gridIndex
+				}
+			}
+			img.Pix[py*img.Stride+px] = i
+		}
+	}
+}
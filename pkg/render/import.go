@@ -0,0 +1,264 @@
+package render
+
+import (
+	"errors"
+	"github.com/richardwooding/gameoflife/model"
+	"image"
+	"image/color"
+)
+
+// Polarity selects which side of the luminance threshold counts as a live cell.
+type Polarity uint8
+
+const (
+	AutoPolarity Polarity = iota // Whichever side covers fewer pixels is alive
+	LightAlive                   // Pixels brighter than the threshold are alive
+	DarkAlive                    // Pixels darker than the threshold are alive
+)
+
+// ImportOptions controls how an image is converted to a colony.
+type ImportOptions struct {
+	CellSize  int      // Distance in pixels between cell origins, 0 to detect it
+	Threshold float64  // Luminance between 0 and 1 separating live from dead, 0 to detect it
+	Polarity  Polarity // Which side of the threshold is alive
+	MaxCells  int      // Largest number of columns or rows accepted, 0 for no limit
+}
+
+var (
+	EmptyImage       = errors.New("image has no live pixels")
+	CellSizeNotFound = errors.New("could not detect the cell size")
+	TooManyCells     = errors.New("image has too many cells")
+)
+
+// maxDetectedCellSize bounds the cell sizes tried when detecting the grid.
+const maxDetectedCellSize = 64
+
+// cellSizeTolerance is the fraction of live pixels that may disagree with their cell for a detected size.
+const cellSizeTolerance = 0.1
+
+// Import converts an image of a pattern into a colony with one cell per grid square.
+// Pixel luminance is thresholded into live and dead pixels and each cell takes the majority of its pixels.
+func Import(img image.Image, o ImportOptions) (*model.Colony, error) {
+	lit := threshold(img, o)
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	live := 0
+	for _, row := range lit {
+		for _, v := range row {
+			if v {
+				live++
+			}
+		}
+	}
+	if live == 0 {
+		return nil, EmptyImage
+	}
+
+	size := o.CellSize
+	var offsetX, offsetY int
+	if size <= 0 {
+		var ok bool
+		if size, offsetX, offsetY, ok = detectGrid(lit, live); !ok {
+			return nil, CellSizeNotFound
+		}
+	} else {
+		offsetX, offsetY = gridOffset(lit, size, true), gridOffset(lit, size, false)
+	}
+
+	// Partial cells at the edges are kept when at least half of them is inside the image.
+	startX, startY := offsetX, offsetY
+	for startX-size/2 > 0 {
+		startX -= size
+	}
+	for startY-size/2 > 0 {
+		startY -= size
+	}
+	cols := (w - startX + size/2) / size
+	rows := (h - startY + size/2) / size
+	if o.MaxCells > 0 && (cols > o.MaxCells || rows > o.MaxCells) {
+		return nil, TooManyCells
+	}
+	colony := model.NewColony(cols, rows)
+	cells := colony.Cells()
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			on, total := sampleCell(lit, startX+x*size, startY+y*size, size)
+			(*cells)[y][x] = total > 0 && on*2 > total
+		}
+	}
+	return colony, nil
+}
+
+// threshold converts the image to a grid of live pixels indexed [y][x].
+func threshold(img image.Image, o ImportOptions) [][]bool {
+	bounds := img.Bounds()
+	lum := make([][]float64, bounds.Dy())
+	var histogram [256]int
+	for y := range lum {
+		lum[y] = make([]float64, bounds.Dx())
+		for x := range lum[y] {
+			pixel := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+			c := color.GrayModel.Convert(pixel).(color.Gray)
+			// Transparent pixels are treated as background, whatever their colour.
+			if _, _, _, a := pixel.RGBA(); a < 0x8000 {
+				lum[y][x] = -1
+				continue
+			}
+			lum[y][x] = float64(c.Y) / 255
+			histogram[c.Y]++
+		}
+	}
+	t := o.Threshold
+	if t <= 0 {
+		t = otsu(histogram)
+	}
+	polarity := o.Polarity
+	if polarity == AutoPolarity {
+		light, dark := 0, 0
+		for i, n := range histogram {
+			if float64(i)/255 > t {
+				light += n
+			} else {
+				dark += n
+			}
+		}
+		polarity = DarkAlive
+		if light < dark {
+			polarity = LightAlive
+		}
+	}
+	lit := make([][]bool, len(lum))
+	for y := range lum {
+		lit[y] = make([]bool, len(lum[y]))
+		for x, v := range lum[y] {
+			if v < 0 {
+				continue
+			}
+			if polarity == LightAlive {
+				lit[y][x] = v > t
+			} else {
+				lit[y][x] = v <= t
+			}
+		}
+	}
+	return lit
+}
+
+// otsu returns the luminance threshold that best separates the two classes of the histogram.
+func otsu(histogram [256]int) float64 {
+	total, sum := 0, 0.0
+	for i, n := range histogram {
+		total += n
+		sum += float64(i * n)
+	}
+	best, bestVariance := 127, -1.0
+	background, sumBackground := 0, 0.0
+	for i, n := range histogram {
+		background += n
+		if background == 0 {
+			continue
+		}
+		foreground := total - background
+		if foreground == 0 {
+			break
+		}
+		sumBackground += float64(i * n)
+		meanBackground := sumBackground / float64(background)
+		meanForeground := (sum - sumBackground) / float64(foreground)
+		variance := float64(background) * float64(foreground) * (meanBackground - meanForeground) * (meanBackground - meanForeground)
+		if variance > bestVariance {
+			best, bestVariance = i, variance
+		}
+	}
+	return float64(best) / 255
+}
+
+// detectGrid finds the largest cell size whose cells agree with almost all live pixels.
+func detectGrid(lit [][]bool, live int) (size, offsetX, offsetY int, ok bool) {
+	h, w := len(lit), len(lit[0])
+	limit := maxDetectedCellSize
+	if w < limit {
+		limit = w
+	}
+	if h < limit {
+		limit = h
+	}
+	for s := limit; s >= 1; s-- {
+		ox, oy := gridOffset(lit, s, true), gridOffset(lit, s, false)
+		if gridError(lit, s, ox, oy) <= cellSizeTolerance*float64(live) {
+			return s, ox, oy, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// gridOffset returns the most common position, modulo size, at which runs of live pixels start or end.
+func gridOffset(lit [][]bool, size int, horizontal bool) int {
+	votes := make([]int, size)
+	h, w := len(lit), len(lit[0])
+	if horizontal {
+		for y := 0; y < h; y++ {
+			for x := 1; x < w; x++ {
+				if lit[y][x] != lit[y][x-1] {
+					votes[x%size]++
+				}
+			}
+		}
+	} else {
+		for x := 0; x < w; x++ {
+			for y := 1; y < h; y++ {
+				if lit[y][x] != lit[y-1][x] {
+					votes[y%size]++
+				}
+			}
+		}
+	}
+	best := 0
+	for i, v := range votes {
+		if v > votes[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// gridError counts the pixels that disagree with the majority of their cell.
+func gridError(lit [][]bool, size, offsetX, offsetY int) float64 {
+	h, w := len(lit), len(lit[0])
+	wrong := 0
+	for y0 := offsetY - size; y0 < h; y0 += size {
+		for x0 := offsetX - size; x0 < w; x0 += size {
+			on, total := sampleCell(lit, x0, y0, size)
+			if on*2 > total {
+				wrong += total - on
+			} else {
+				wrong += on
+			}
+		}
+	}
+	return float64(wrong)
+}
+
+// sampleCell counts the live pixels in the interior of the cell at (x0, y0).
+// A one pixel margin is ignored on larger cells so grid lines and anti-aliasing don't count.
+func sampleCell(lit [][]bool, x0, y0, size int) (on, total int) {
+	margin := 0
+	if size >= 4 {
+		margin = 1
+	}
+	for y := y0 + margin; y < y0+size-margin; y++ {
+		if y < 0 || y >= len(lit) {
+			continue
+		}
+		for x := x0 + margin; x < x0+size-margin; x++ {
+			if x < 0 || x >= len(lit[y]) {
+				continue
+			}
+			total++
+			if lit[y][x] {
+				on++
+			}
+		}
+	}
+	return on, total
+}
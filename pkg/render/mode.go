@@ -0,0 +1,135 @@
+package render
+
+import (
+	"errors"
+	"github.com/richardwooding/gameoflife/model"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// Mode chooses what the colour of a cell shows.
+type Mode uint8
+
+const (
+	States Mode = iota // The state of each cell
+	Age                // How many generations each live cell has been alive for, from young to old
+	Trail              // Recently dead cells, fading out as they stay dead
+	Heat               // How often each cell has been alive, from never through rotors to always alive stators
+)
+
+// Modes lists the display modes in the order they are offered.
+var Modes = []Mode{States, Age, Trail, Heat}
+
+func (m Mode) String() string {
+	switch m {
+	case States:
+		return "states"
+	case Age:
+		return "age"
+	case Trail:
+		return "trail"
+	case Heat:
+		return "heat"
+	default:
+		return "unknown"
+	}
+}
+
+var InvalidMode = errors.New("invalid display mode")
+
+// ParseMode parses a display mode name such as "heat", ignoring case.
+func ParseMode(s string) (Mode, error) {
+	for _, m := range Modes {
+		if strings.EqualFold(s, m.String()) {
+			return m, nil
+		}
+	}
+	return States, InvalidMode
+}
+
+// Tracked reports whether the mode needs the colony to track the age and activity of its cells.
+func (m Mode) Tracked() bool {
+	return m != States
+}
+
+const (
+	rampLevels = 16 // Number of colours in the ramp of a display mode
+	oldAge     = 64 // Age from which live cells are drawn in the oldest colour
+)
+
+// heatStops are the colours heat rises through after the dead colour.
+var heatStops = []color.Color{
+	color.RGBA{R: 0xb0, A: 0xff},
+	color.RGBA{R: 0xff, G: 0x40, A: 0xff},
+	color.RGBA{R: 0xff, G: 0xd0, A: 0xff},
+}
+
+// mix returns the colour a share t of the way from a to b.
+func mix(a, b color.Color, t float64) color.RGBA {
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+	channel := func(a, b uint32) uint8 {
+		return uint8((float64(a)*(1-t) + float64(b)*t) / 0x101)
+	}
+	return color.RGBA{R: channel(ar, br), G: channel(ag, bg), B: channel(ab, bb), A: 0xff}
+}
+
+// RampColor returns the colour a share t of the way along the ramp of the display mode: from the alive colour
+// to the old colour by age, from dead to the history colour by activity, and from dead through red to yellow by
+// heat.
+func RampColor(o Options, t float64) color.Color {
+	t = math.Max(0, math.Min(1, t))
+	switch o.Mode {
+	case Age:
+		return mix(o.Alive, o.Old, t)
+	case Trail:
+		return mix(o.Dead, o.History, t)
+	case Heat:
+		stops := append([]color.Color{o.Dead}, heatStops...)
+		i := int(t * float64(len(stops)-1))
+		if i == len(stops)-1 {
+			return stops[i]
+		}
+		return mix(stops[i], stops[i+1], t*float64(len(stops)-1)-float64(i))
+	default:
+		return o.Alive
+	}
+}
+
+// shade returns how far along the ramp of the display mode the cell at (x, y) is, if its colour comes from the
+// ramp rather than its state.
+func (o Options) shade(c *model.Colony, x, y int) (float64, bool) {
+	switch {
+	case o.Mode == Age && c.State(x, y) == 1:
+		return math.Log2(float64(c.Age(x, y))) / math.Log2(oldAge), true
+	case o.Mode == Trail && c.State(x, y) == 0:
+		return c.Activity(x, y), true
+	case o.Mode == Heat:
+		return c.Heat(x, y), true
+	}
+	return 0, false
+}
+
+// level quantises a shade to a colour of the ramp. Any heat at all shows, however rarely the cell was alive.
+func (o Options) level(t float64) int {
+	level := int(math.Round(math.Max(0, math.Min(1, t)) * (rampLevels - 1)))
+	if o.Mode == Heat && level == 0 && t > 0 {
+		return 1
+	}
+	return level
+}
+
+// ramped reports whether frames under the rule draw the ramp of the display mode. Rules with so many states
+// that the ramp doesn't fit in a palette are drawn by state.
+func (o Options) ramped(r model.Rule) bool {
+	return o.Mode != States && 3+r.States()-2+rampLevels <= 256
+}
+
+// ShadeColor returns the colour of the cell at (x, y) in the display mode, as frames draw it.
+func ShadeColor(o Options, c *model.Colony, x, y int) color.Color {
+	if t, ok := o.shade(c, x, y); ok && o.ramped(c.Rule()) {
+		return RampColor(o, float64(o.level(t))/(rampLevels-1))
+	}
+	return CellColor(o, c.Rule(), c.State(x, y))
+}
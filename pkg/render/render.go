@@ -0,0 +1,181 @@
+package render
+
+import (
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Options controls how a colony is drawn to an image.
+type Options struct {
+	CellSize  int         // Width and height of a cell in pixels
+	Alive     color.Color // Colour of live cells
+	Dead      color.Color // Colour of dead cells
+	Grid      color.Color // Colour of the grid lines
+	GridLines bool        // Whether to draw a one pixel line between cells
+	Mode      Mode        // What the colour of a cell shows
+	Old       color.Color // Colour of the oldest live cells in the Age mode
+	History   color.Color // Colour recently dead cells fade from in the Trail mode
+}
+
+// DefaultOptions returns options matching the colours used by web/gameoflife.css.
+func DefaultOptions() Options {
+	return Options{
+		CellSize:  8,
+		Alive:     color.RGBA{R: 0xad, G: 0xff, B: 0x2f, A: 0xff}, // greenyellow
+		Dead:      color.RGBA{A: 0xff},                            // black
+		Grid:      color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff},
+		GridLines: false,
+		Old:       color.RGBA{R: 0x1e, G: 0x90, B: 0xff, A: 0xff}, // dodgerblue
+		History:   color.RGBA{R: 0x56, G: 0x7f, B: 0x17, A: 0xff}, // halfway from black to greenyellow
+	}
+}
+
+// pitch returns the distance in pixels between the origins of adjacent cells.
+func (o Options) pitch() int {
+	if o.GridLines {
+		return o.CellSize + 1
+	}
+	return o.CellSize
+}
+
+// bounds returns the image rectangle needed to draw a colony of dx by dy cells.
+func (o Options) bounds(dx, dy int) image.Rectangle {
+	w, h := dx*o.pitch(), dy*o.pitch()
+	if o.GridLines {
+		w, h = w+1, h+1
+	}
+	return image.Rect(0, 0, w, h)
+}
+
+// palette returns the colours used by a frame: dead, alive and grid, followed by states 2 and up of the rule
+// and then the ramp of the display mode.
+func (o Options) palette(r model.Rule) color.Palette {
+	p := color.Palette{CellColor(o, r, 0), CellColor(o, r, 1), o.Grid}
+	for state := 2; state < r.States(); state++ {
+		p = append(p, CellColor(o, r, uint8(state)))
+	}
+	if o.ramped(r) {
+		for level := 0; level < rampLevels; level++ {
+			p = append(p, RampColor(o, float64(level)/(rampLevels-1)))
+		}
+	}
+	return p
+}
+
+const (
+	deadIndex uint8 = iota
+	aliveIndex
+	gridIndex
+	stateIndex // Index of state 2, with later states following it
+)
+
+// StateColor returns the colour of a cell state. States beyond alive, such as the refractory states of
+// Generations rules, fade from the alive colour towards the dead colour.
+func StateColor(o Options, state uint8, states int) color.Color {
+	switch {
+	case state == 0:
+		return o.Dead
+	case state == 1 || states <= 2:
+		return o.Alive
+	}
+	// State 2 is a third of the way from alive to dead when there are three states.
+	return mix(o.Alive, o.Dead, float64(state-1)/float64(states))
+}
+
+// CellColor returns the colour of a cell state under a rule: the rule's own colour for the state if it has one,
+// as rule tables with a @COLORS section do, and otherwise StateColor.
+func CellColor(o Options, r model.Rule, state uint8) color.Color {
+	if colours, ok := r.(interface {
+		Colour(uint8) (color.RGBA, bool)
+	}); ok {
+		if c, ok := colours.Colour(state); ok {
+			return c
+		}
+	}
+	return StateColor(o, state, r.States())
+}
+
+// index returns the palette index of a cell state.
+func index(state uint8) uint8 {
+	switch state {
+	case 0:
+		return deadIndex
+	case 1:
+		return aliveIndex
+	default:
+		return stateIndex + state - 2
+	}
+}
+
+// cellIndex returns the palette index of the cell at (x, y), from the ramp of the display mode or its state.
+func (o Options) cellIndex(c *model.Colony, x, y int) uint8 {
+	if t, ok := o.shade(c, x, y); ok && o.ramped(c.Rule()) {
+		return stateIndex + uint8(c.Rule().States()-2+o.level(t))
+	}
+	return index(c.State(x, y))
+}
+
+// frameBounds returns the image rectangle frames of the colony are drawn in.
+func (o Options) frameBounds(c *model.Colony) image.Rectangle {
+	if model.NeighbourhoodOf(c.Rule()) == model.Hexagonal {
+		return o.hexBounds(c.Width(), c.Height())
+	}
+	return o.bounds(c.Width(), c.Height())
+}
+
+// frame draws the current generation of the colony as a paletted image, on a hexagonal grid for rules using
+// the hexagonal neighbourhood.
+func frame(c *model.Colony, o Options) *image.Paletted {
+	img := image.NewPaletted(o.frameBounds(c), o.palette(c.Rule()))
+	if model.NeighbourhoodOf(c.Rule()) == model.Hexagonal {
+		hexFrame(c, o, img)
+		return img
+	}
+	if o.GridLines {
+		for i := range img.Pix {
+			img.Pix[i] = gridIndex
+		}
+	}
+	offset, pitch := 0, o.pitch()
+	if o.GridLines {
+		offset = 1
+	}
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			i := o.cellIndex(c, x, y)
+			x0, y0 := offset+x*pitch, offset+y*pitch
+			for py := y0; py < y0+o.CellSize; py++ {
+				row := img.Pix[py*img.Stride:]
+				for px := x0; px < x0+o.CellSize; px++ {
+					row[px] = i
+				}
+			}
+		}
+	}
+	return img
+}
+
+// Render draws the current generation of the colony.
+func Render(c *model.Colony, o Options) image.Image {
+	return frame(c, o)
+}
+
+// ParseColor parses a colour in "#rgb" or "#rrggbb" notation.
+func ParseColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid colour %q", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid colour %q", s)
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
@@ -0,0 +1,484 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type renderFeature struct {
+	colony    *model.Colony
+	options   AnimationOptions
+	output    bytes.Buffer
+	decoded   image.Image
+	imported  *model.Colony
+	importErr error
+	layout    HexLayout
+	mode      Mode
+	modeErr   error
+}
+
+func (f *renderFeature) aColonyWithABlinker(dx, dy int) error {
+	f.colony = model.NewColony(dx, dy)
+	f.options = DefaultAnimationOptions()
+	f.output.Reset()
+	for x := 1; x <= 3; x++ {
+		f.colony.Toggle(x, 2)
+	}
+	return nil
+}
+
+func (f *renderFeature) framesAreShownFor(delay string) error {
+	d, err := time.ParseDuration(delay)
+	if err != nil {
+		return err
+	}
+	f.options.Delay = d
+	return nil
+}
+
+// manyStates is a rule with more states than a palette holds, which no parsed rule has.
+type manyStates struct{ model.Totalistic }
+
+func (manyStates) States() int {
+	return 300
+}
+
+func (f *renderFeature) theColonyRunsUnderARuleWithStates(states int) error {
+	f.colony.SetRule(manyStates{model.Life})
+	return nil
+}
+
+func (f *renderFeature) everyGIFFrameShouldBeShownForHundredths(delay int) error {
+	anim, err := gif.DecodeAll(bytes.NewReader(f.output.Bytes()))
+	if err != nil {
+		return err
+	}
+	for i, d := range anim.Delay {
+		if d != delay {
+			return fmt.Errorf("expected frame %d to be shown for %d hundredths, got %d", i, delay, d)
+		}
+	}
+	return nil
+}
+
+func (f *renderFeature) everyAPNGFrameShouldBeShownForMilliseconds(delay int) error {
+	chunks, err := readChunks(f.output.Bytes())
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if chunk.kind != "fcTL" {
+			continue
+		}
+		num, den := binary.BigEndian.Uint16(chunk.data[20:]), binary.BigEndian.Uint16(chunk.data[22:])
+		if int(num) != delay || den != 1000 {
+			return fmt.Errorf("expected frames to be shown for %d/1000 s, got %d/%d", delay, num, den)
+		}
+	}
+	return nil
+}
+
+func (f *renderFeature) gridLinesAreDrawn() error {
+	f.options.GridLines = true
+	return nil
+}
+
+func (f *renderFeature) theColonyIsExported(format string, generations, cellSize int) error {
+	f.options.Generations = generations
+	f.options.CellSize = cellSize
+	parsed, err := ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	return Animate(&f.output, f.colony, parsed, f.options)
+}
+
+func (f *renderFeature) exportingItShouldFail(format string, generations, cellSize int) error {
+	f.options.Generations = generations
+	f.options.CellSize = cellSize
+	parsed, err := ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	if err := Animate(&f.output, f.colony, parsed, f.options); !errors.Is(err, InvalidOptions) {
+		return fmt.Errorf("expected %v, got %v", InvalidOptions, err)
+	}
+	if f.output.Len() != 0 {
+		return fmt.Errorf("expected nothing written, got %d bytes", f.output.Len())
+	}
+	return nil
+}
+
+func (f *renderFeature) theGIFShouldHaveFrames(frames, width, height int) error {
+	anim, err := gif.DecodeAll(bytes.NewReader(f.output.Bytes()))
+	if err != nil {
+		return err
+	}
+	if len(anim.Image) != frames {
+		return fmt.Errorf("expected %d frames, got %d", frames, len(anim.Image))
+	}
+	if anim.Config.Width != width || anim.Config.Height != height {
+		return fmt.Errorf("expected %dx%d pixels, got %dx%d", width, height, anim.Config.Width, anim.Config.Height)
+	}
+	return nil
+}
+
+func (f *renderFeature) theColonyShouldStillBeAtGeneration(generation int64) error {
+	if f.colony.GetGeneration() != generation {
+		return fmt.Errorf("expected generation %d, got %d", generation, f.colony.GetGeneration())
+	}
+	return nil
+}
+
+func (f *renderFeature) theAPNGShouldDeclareFrames(frames int) error {
+	chunks, err := readChunks(f.output.Bytes())
+	if err != nil {
+		return err
+	}
+	fctl := 0
+	for _, chunk := range chunks {
+		switch chunk.kind {
+		case "acTL":
+			if n := int(binary.BigEndian.Uint32(chunk.data)); n != frames {
+				return fmt.Errorf("acTL declares %d frames, expected %d", n, frames)
+			}
+		case "fcTL":
+			fctl++
+		}
+	}
+	if fctl != frames {
+		return fmt.Errorf("expected %d fcTL chunks, got %d", frames, fctl)
+	}
+	return nil
+}
+
+func (f *renderFeature) theAPNGShouldDecodeToAnImage(width, height int) error {
+	img, err := png.Decode(bytes.NewReader(f.output.Bytes()))
+	if err != nil {
+		return err
+	}
+	f.decoded = img
+	if b := img.Bounds(); b.Dx() != width || b.Dy() != height {
+		return fmt.Errorf("expected %dx%d pixels, got %dx%d", width, height, b.Dx(), b.Dy())
+	}
+	return nil
+}
+
+func (f *renderFeature) thePixelOfTheDecodedImageShouldBeAlive(x, y int) error {
+	r, g, b, _ := f.decoded.At(x, y).RGBA()
+	er, eg, eb, _ := f.options.Alive.RGBA()
+	if r != er || g != eg || b != eb {
+		return fmt.Errorf("expected pixel (%d,%d) to be the alive colour", x, y)
+	}
+	return nil
+}
+
+func (f *renderFeature) theColonyIsRendered(cellSize int) error {
+	f.options.CellSize = cellSize
+	f.decoded = Render(f.colony, f.options.Options)
+	return nil
+}
+
+func (f *renderFeature) theImageShouldBePixels(width, height int) error {
+	if b := f.decoded.Bounds(); b.Dx() != width || b.Dy() != height {
+		return fmt.Errorf("expected %dx%d pixels, got %dx%d", width, height, b.Dx(), b.Dy())
+	}
+	return nil
+}
+
+func (f *renderFeature) thePixelOfTheRenderedImageShouldBe(x, y int, state string) error {
+	expected := f.options.Dead
+	if state == "alive" {
+		expected = f.options.Alive
+	}
+	r, g, b, _ := f.decoded.At(x, y).RGBA()
+	er, eg, eb, _ := expected.RGBA()
+	if r != er || g != eg || b != eb {
+		return fmt.Errorf("expected pixel (%d,%d) to be the %s colour", x, y, state)
+	}
+	return nil
+}
+
+func (f *renderFeature) theColonyIsWrittenAsAnSVG(cellSize int) error {
+	f.options.CellSize = cellSize
+	return WriteSVG(&f.output, f.colony, f.options.Options)
+}
+
+func (f *renderFeature) theSVGShouldContainLiveCellRects(n int) error {
+	doc := f.output.String()
+	group := regexp.MustCompile(`(?s)<g fill="` + hexColor(f.options.Alive) + `">(.*?)</g>`).FindStringSubmatch(doc)
+	if group == nil {
+		return fmt.Errorf("no group of live cells in %s", doc)
+	}
+	if rects := regexp.MustCompile(`<rect `).FindAllString(group[1], -1); len(rects) != n {
+		return fmt.Errorf("expected %d live cell rects, got %d", n, len(rects))
+	}
+	return nil
+}
+
+func (f *renderFeature) theSVGShouldDeclareASizeOf(width, height int) error {
+	header := fmt.Sprintf(`width="%d" height="%d"`, width, height)
+	if !bytes.Contains(f.output.Bytes(), []byte(header)) {
+		return fmt.Errorf("expected SVG to declare %s", header)
+	}
+	return nil
+}
+
+func (f *renderFeature) aColonyWithAGliderAt(dx, dy, x, y int) error {
+	f.colony = model.NewColony(dx, dy)
+	f.options = DefaultAnimationOptions()
+	for _, pos := range [][2]int{{1, 0}, {2, 1}, {0, 2}, {1, 2}, {2, 2}} {
+		f.colony.Toggle(x+pos[0], y+pos[1])
+	}
+	return nil
+}
+
+func (f *renderFeature) theColonyIsRenderedWithGridLines(cellSize int, lines string) error {
+	f.options.CellSize = cellSize
+	f.options.GridLines = lines == "on"
+	f.decoded = Render(f.colony, f.options.Options)
+	return nil
+}
+
+func (f *renderFeature) theColonyIsRenderedInBlackOnWhite(cellSize int) error {
+	f.options.CellSize = cellSize
+	f.options.Alive = color.Black
+	f.options.Dead = color.White
+	f.decoded = Render(f.colony, f.options.Options)
+	return nil
+}
+
+func (f *renderFeature) theImageIsImported(cellSize int) error {
+	f.imported, f.importErr = Import(f.decoded, ImportOptions{CellSize: cellSize})
+	return nil
+}
+
+func (f *renderFeature) theImageIsImportedDetectingTheCellSize() error {
+	return f.theImageIsImported(0)
+}
+
+func (f *renderFeature) theImportedColonyShouldBe(width, height int) error {
+	if f.importErr != nil {
+		return f.importErr
+	}
+	if f.imported.Width() != width || f.imported.Height() != height {
+		return fmt.Errorf("expected a %dx%d colony, got %dx%d", width, height, f.imported.Width(), f.imported.Height())
+	}
+	return nil
+}
+
+func (f *renderFeature) theImportedColonyShouldMatchTheOriginal() error {
+	if f.importErr != nil {
+		return f.importErr
+	}
+	for y := 0; y < f.colony.Height(); y++ {
+		for x := 0; x < f.colony.Width(); x++ {
+			if f.imported.IsAlive(x, y) != f.colony.IsAlive(x, y) {
+				return fmt.Errorf("cell (%d,%d) should be %v", x, y, f.colony.IsAlive(x, y))
+			}
+		}
+	}
+	return nil
+}
+
+func (f *renderFeature) theImportShouldFailWith(message string) error {
+	if f.importErr == nil || f.importErr.Error() != message {
+		return fmt.Errorf("expected error %q, got %v", message, f.importErr)
+	}
+	return nil
+}
+
+func (f *renderFeature) theColonyRunsUnderTheRule(s string) error {
+	rule, err := model.ParseRule(s)
+	if err != nil {
+		return err
+	}
+	f.colony.SetRule(rule)
+	return nil
+}
+
+func (f *renderFeature) theCentreOfCellInTheRenderedImageShouldBe(x, y int, state string) error {
+	cx, cy := f.options.hexLayout(f.colony.Width(), f.colony.Height()).Centre(x, y)
+	return f.thePixelOfTheRenderedImageShouldBe(int(cx), int(cy), state)
+}
+
+func (f *renderFeature) theSVGShouldContainLiveCellPolygons(n int) error {
+	doc := f.output.String()
+	group := regexp.MustCompile(`(?s)<g fill="` + hexColor(f.options.Alive) + `"[^>]*>(.*?)</g>`).FindStringSubmatch(doc)
+	if group == nil {
+		return fmt.Errorf("no group of live cells in %s", doc)
+	}
+	if polygons := regexp.MustCompile(`<polygon `).FindAllString(group[1], -1); len(polygons) != n {
+		return fmt.Errorf("expected %d live cell polygons, got %d", n, len(polygons))
+	}
+	return nil
+}
+
+func (f *renderFeature) aHexagonalLayout(width, height, size int) error {
+	f.layout = HexLayout{Width: width, Height: height, Size: float64(size)}
+	return nil
+}
+
+func (f *renderFeature) theCentreOfCellOffsetByShouldHitCell(x, y, dx, dy, hx, hy int) error {
+	cx, cy := f.layout.Centre(x, y)
+	gx, gy, ok := f.layout.CellAt(cx+float64(dx), cy+float64(dy))
+	if !ok || gx != hx || gy != hy {
+		return fmt.Errorf("expected cell (%d,%d), got (%d,%d) in colony %v", hx, hy, gx, gy, ok)
+	}
+	return nil
+}
+
+func (f *renderFeature) thePointShouldMissTheColony(px, py int) error {
+	if x, y, ok := f.layout.CellAt(float64(px), float64(py)); ok {
+		return fmt.Errorf("expected a miss, got cell (%d,%d)", x, y)
+	}
+	return nil
+}
+
+func (f *renderFeature) theCellAtIsInState(x, y, state int) error {
+	f.colony.SetState(x, y, uint8(state))
+	return nil
+}
+
+func (f *renderFeature) thePixelOfTheRenderedImageShouldBeColoured(x, y int, r, g, b uint32) error {
+	pr, pg, pb, _ := f.decoded.At(x, y).RGBA()
+	if pr>>8 != r || pg>>8 != g || pb>>8 != b {
+		return fmt.Errorf("expected pixel (%d,%d) to be %d,%d,%d, got %d,%d,%d", x, y, r, g, b, pr>>8, pg>>8, pb>>8)
+	}
+	return nil
+}
+
+func (f *renderFeature) theColonyIsTracked() error {
+	f.colony.Track(true)
+	return nil
+}
+
+func (f *renderFeature) theDisplayModeIs(name string) error {
+	mode, err := ParseMode(name)
+	f.options.Mode = mode
+	return err
+}
+
+func (f *renderFeature) theColonyRunsForGenerations(n int) error {
+	for i := 0; i < n; i++ {
+		f.colony.Generate()
+	}
+	return nil
+}
+
+func (f *renderFeature) theLastFramePixelShouldBeColoured(x, y int, r, g, b uint32) error {
+	anim, err := gif.DecodeAll(bytes.NewReader(f.output.Bytes()))
+	if err != nil {
+		return err
+	}
+	f.decoded = anim.Image[len(anim.Image)-1]
+	return f.thePixelOfTheRenderedImageShouldBeColoured(x, y, r, g, b)
+}
+
+func (f *renderFeature) theColonyShouldNotBeTracked() error {
+	if f.colony.Tracking() {
+		return fmt.Errorf("expected the colony not to be tracked")
+	}
+	return nil
+}
+
+func (f *renderFeature) theSVGShouldHaveColourGroups(n int) error {
+	if groups := regexp.MustCompile(`<g fill=`).FindAllString(f.output.String(), -1); len(groups) != n {
+		return fmt.Errorf("expected %d colour groups, got %d", n, len(groups))
+	}
+	return nil
+}
+
+func (f *renderFeature) theDisplayModeIsParsed(name string) error {
+	f.mode, f.modeErr = ParseMode(name)
+	return nil
+}
+
+func (f *renderFeature) itShouldBeTheMode(name string) error {
+	if name == "invalid" {
+		if f.modeErr != InvalidMode {
+			return fmt.Errorf("expected %v, got %v", InvalidMode, f.modeErr)
+		}
+		return nil
+	}
+	if f.modeErr != nil {
+		return f.modeErr
+	}
+	if f.mode.String() != name {
+		return fmt.Errorf("expected the %s mode, got %s", name, f.mode)
+	}
+	return nil
+}
+
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	f := &renderFeature{}
+	ctx.Step(`^a (\d+)x(\d+) colony with a blinker$`, f.aColonyWithABlinker)
+	ctx.Step(`^grid lines are drawn$`, f.gridLinesAreDrawn)
+	ctx.Step(`^frames are shown for (\S+)$`, f.framesAreShownFor)
+	ctx.Step(`^the colony runs under a rule with (\d+) states$`, f.theColonyRunsUnderARuleWithStates)
+	ctx.Step(`^every GIF frame should be shown for (\d+) hundredths of a second$`, f.everyGIFFrameShouldBeShownForHundredths)
+	ctx.Step(`^every APNG frame should be shown for (\d+) milliseconds$`, f.everyAPNGFrameShouldBeShownForMilliseconds)
+	ctx.Step(`^the colony is exported as an? (GIF|APNG) for (\d+) generations with a cell size of (\d+)$`, f.theColonyIsExported)
+	ctx.Step(`^exporting it as an? (GIF|APNG) for (-?\d+) generations with a cell size of (-?\d+) should fail$`, f.exportingItShouldFail)
+	ctx.Step(`^the GIF should have (\d+) frames of (\d+)x(\d+) pixels$`, f.theGIFShouldHaveFrames)
+	ctx.Step(`^the colony should still be at generation (\d+)$`, f.theColonyShouldStillBeAtGeneration)
+	ctx.Step(`^the APNG should declare (\d+) frames$`, f.theAPNGShouldDeclareFrames)
+	ctx.Step(`^the APNG should decode to a (\d+)x(\d+) image$`, f.theAPNGShouldDecodeToAnImage)
+	ctx.Step(`^the pixel at \((\d+),(\d+)\) of the decoded image should be alive$`, f.thePixelOfTheDecodedImageShouldBeAlive)
+	ctx.Step(`^the colony is rendered with a cell size of (\d+)$`, f.theColonyIsRendered)
+	ctx.Step(`^the image should be (\d+)x(\d+) pixels$`, f.theImageShouldBePixels)
+	ctx.Step(`^the pixel at \((\d+),(\d+)\) of the rendered image should be (alive|dead)$`, f.thePixelOfTheRenderedImageShouldBe)
+	ctx.Step(`^the colony is written as an SVG with a cell size of (\d+)$`, f.theColonyIsWrittenAsAnSVG)
+	ctx.Step(`^the SVG should contain (\d+) live cell rects$`, f.theSVGShouldContainLiveCellRects)
+	ctx.Step(`^a (\d+)x(\d+) colony with a glider at \((\d+),(\d+)\)$`, f.aColonyWithAGliderAt)
+	ctx.Step(`^the colony is rendered with a cell size of (\d+) and grid lines (on|off)$`, f.theColonyIsRenderedWithGridLines)
+	ctx.Step(`^the colony is rendered in black on white with a cell size of (\d+)$`, f.theColonyIsRenderedInBlackOnWhite)
+	ctx.Step(`^the image is imported detecting the cell size$`, f.theImageIsImportedDetectingTheCellSize)
+	ctx.Step(`^the image is imported with a cell size of (\d+)$`, f.theImageIsImported)
+	ctx.Step(`^the imported colony should be (\d+)x(\d+)$`, f.theImportedColonyShouldBe)
+	ctx.Step(`^the imported colony should match the original$`, f.theImportedColonyShouldMatchTheOriginal)
+	ctx.Step(`^the import should fail with "([^"]*)"$`, f.theImportShouldFailWith)
+	ctx.Step(`^the SVG should declare a size of (\d+)x(\d+)$`, f.theSVGShouldDeclareASizeOf)
+	ctx.Step(`^the colony runs under the rule "([^"]*)"$`, f.theColonyRunsUnderTheRule)
+	ctx.Step(`^the cell at \((\d+),(\d+)\) is in state (\d+)$`, f.theCellAtIsInState)
+	ctx.Step(`^the pixel at \((\d+),(\d+)\) of the rendered image should be coloured (\d+),(\d+),(\d+)$`, f.thePixelOfTheRenderedImageShouldBeColoured)
+	ctx.Step(`^the centre of cell \((\d+),(\d+)\) in the rendered image should be (alive|dead)$`, f.theCentreOfCellInTheRenderedImageShouldBe)
+	ctx.Step(`^the SVG should contain (\d+) live cell polygons$`, f.theSVGShouldContainLiveCellPolygons)
+	ctx.Step(`^a (\d+)x(\d+) hexagonal layout with hexagons (\d+) pixels wide$`, f.aHexagonalLayout)
+	ctx.Step(`^the centre of cell \((\d+),(\d+)\) offset by \((-?\d+),(-?\d+)\) should hit cell \((\d+),(\d+)\)$`, f.theCentreOfCellOffsetByShouldHitCell)
+	ctx.Step(`^the point \((\d+),(\d+)\) should miss the colony$`, f.thePointShouldMissTheColony)
+	ctx.Step(`^the colony is tracked$`, f.theColonyIsTracked)
+	ctx.Step(`^the display mode is "([^"]*)"$`, f.theDisplayModeIs)
+	ctx.Step(`^the colony runs for (\d+) generations?$`, f.theColonyRunsForGenerations)
+	ctx.Step(`^the last frame pixel at \((\d+),(\d+)\) should be coloured (\d+),(\d+),(\d+)$`, f.theLastFramePixelShouldBeColoured)
+	ctx.Step(`^the colony should not be tracked$`, f.theColonyShouldNotBeTracked)
+	ctx.Step(`^the SVG should have (\d+) colour groups$`, f.theSVGShouldHaveColourGroups)
+	ctx.Step(`^the display mode "([^"]*)" is parsed$`, f.theDisplayModeIsParsed)
+	ctx.Step(`^it should be the (\w+) mode$`, f.itShouldBeTheMode)
+}
+
+func TestRenderFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "render",
+		ScenarioInitializer: InitializeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
@@ -0,0 +1,110 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"image/color"
+	"io"
+	"math"
+)
+
+// hexColor formats a colour in "#rrggbb" notation for SVG attributes.
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// group is a set of cells drawn in the same colour.
+type group struct {
+	colour color.Color
+	cells  [][2]int
+}
+
+// groups returns the cells of the colony grouped by colour in palette order: dead, alive, the later states of
+// the rule and then the ramp of the display mode. Cells in the dead colour are left out unless all is set,
+// as the background already shows it.
+func (o Options) groups(c *model.Colony, all bool) []group {
+	p := o.palette(c.Rule())
+	cells := make([][][2]int, len(p))
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			i := o.cellIndex(c, x, y)
+			cells[i] = append(cells[i], [2]int{x, y})
+		}
+	}
+	background := hexColor(p[deadIndex])
+	var groups []group
+	for i, colour := range p {
+		if uint8(i) == gridIndex || len(cells[i]) == 0 || !all && hexColor(colour) == background {
+			continue
+		}
+		groups = append(groups, group{colour: colour, cells: cells[i]})
+	}
+	return groups
+}
+
+// WriteSVG writes the current generation of the colony as an SVG document with one rect per live cell,
+// grouped by colour.
+// Coordinates match the pixels of Render, so CellSize sets the nominal size of the drawing.
+func WriteSVG(w io.Writer, c *model.Colony, o Options) error {
+	if model.NeighbourhoodOf(c.Rule()) == model.Hexagonal {
+		return writeHexSVG(w, c, o)
+	}
+	bw := bufio.NewWriter(w)
+	bounds := o.bounds(c.Width(), c.Height())
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		bounds.Dx(), bounds.Dy(), bounds.Dx(), bounds.Dy())
+	rule := c.Rule()
+	background := CellColor(o, rule, 0)
+	if o.GridLines {
+		background = o.Grid
+	}
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="%s"/>`+"\n", bounds.Dx(), bounds.Dy(), hexColor(background))
+	offset, pitch := 0, o.pitch()
+	if o.GridLines {
+		offset = 1
+	}
+	// With grid lines, dead cells are drawn individually so the grid shows between them.
+	for _, g := range o.groups(c, o.GridLines) {
+		fmt.Fprintf(bw, `<g fill="%s">`+"\n", hexColor(g.colour))
+		for _, cell := range g.cells {
+			fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d"/>`+"\n", offset+cell[0]*pitch, offset+cell[1]*pitch, o.CellSize, o.CellSize)
+		}
+		fmt.Fprintln(bw, `</g>`)
+	}
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
+
+// writeHexSVG writes a colony on a hexagonal grid with one polygon per live cell, grouped by colour.
+// With grid lines, dead cells are drawn too and every hexagon is outlined.
+func writeHexSVG(w io.Writer, c *model.Colony, o Options) error {
+	bw := bufio.NewWriter(w)
+	l := o.hexLayout(c.Width(), c.Height())
+	width, height := l.Bounds()
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.2f %.2f">`+"\n",
+		math.Ceil(width), math.Ceil(height), width, height)
+	rule := c.Rule()
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(CellColor(o, rule, 0)))
+	stroke := ""
+	if o.GridLines {
+		stroke = fmt.Sprintf(` stroke="%s" stroke-width="1"`, hexColor(o.Grid))
+	}
+	for _, g := range o.groups(c, o.GridLines) {
+		fmt.Fprintf(bw, `<g fill="%s"%s>`+"\n", hexColor(g.colour), stroke)
+		for _, cell := range g.cells {
+			fmt.Fprint(bw, `<polygon points="`)
+			for i, corner := range l.Corners(cell[0], cell[1]) {
+				if i > 0 {
+					fmt.Fprint(bw, " ")
+				}
+				fmt.Fprintf(bw, "%.2f,%.2f", corner[0], corner[1])
+			}
+			fmt.Fprintln(bw, `"/>`)
+		}
+		fmt.Fprintln(bw, `</g>`)
+	}
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
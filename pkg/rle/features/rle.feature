@@ -0,0 +1,145 @@
+Feature: Run length encoding
+
+  Scenario: A glider is written with a header
+    Given the colony under the rule "B3/S23"
+      """
+      .O.
+      ..O
+      OOO
+      """
+    When it is written as RLE with the comment "Glider"
+    Then the RLE should be
+      """
+      #C Glider
+      x = 3, y = 3, rule = B3/S23
+      bo$2bo$3o!
+      """
+
+  Scenario: Dead cells at the end of a row are left out and blank rows are counted
+    Given the colony under the rule "B3/S23"
+      """
+      OO...
+      .....
+      .....
+      ....O
+      """
+    When it is written as RLE
+    Then the RLE should be
+      """
+      x = 5, y = 4, rule = B3/S23
+      2o3$4bo!
+      """
+
+  Scenario: Multi-state rules use letters for the states
+    Given the colony under the rule "B2/S/C3"
+      """
+      O2.
+      ..1
+      """
+    When it is written as RLE
+    Then the RLE should be
+      """
+      x = 3, y = 2, rule = B2/S/C3
+      AB$2.A!
+      """
+
+  Scenario: Long rows are broken into lines of at most 70 characters
+    Given a 200x1 colony with every other cell alive under the rule "B3/S23"
+    When it is written as RLE
+    Then no line of the RLE should be longer than 70 characters
+
+  Scenario: Reading a pattern with a header
+    When I parse the pattern
+      """
+      #N Glider
+      #C A small spaceship
+      x = 4, y = 3, rule = B36/S23
+      bo$2bo$3o!
+      """
+    Then the colony should be under the rule "B36/S23"
+      """
+      .O..
+      ..O.
+      OOO.
+      """
+
+  Scenario: Reading a pattern without a header
+    When I parse the pattern
+      """
+      2o$
+      2$obo!
+      """
+    Then the colony should be under the rule "B3/S23"
+      """
+      OO.
+      ...
+      ...
+      O.O
+      """
+
+  Scenario: Reading multi-state letters
+    When I parse the pattern
+      """
+      x = 3, y = 2, rule = B2/S/C3
+      AB$2.A!
+      """
+    Then the colony should be under the rule "B2/S/C3"
+      """
+      O2.
+      ..O
+      """
+
+  Scenario Outline: What is written can be read back
+    Given the colony under the rule "<rule>"
+      """
+      <row 1>
+      <row 2>
+      <row 3>
+      """
+    When it is written as RLE
+    And I parse the RLE
+    Then the colony should be the same as the one written
+
+    Examples:
+      | rule        | row 1    | row 2    | row 3    |
+      | B3/S23      | .O..O.   | ......   | OOO...   |
+      | B3/S23      | ......   | ......   | .....O   |
+      | B2/S/C3     | O2.2O.   | ..1...   | 22....   |
+      | WireWorld   | 1233321. | ........ | .3.3.3.3 |
+
+  Scenario: Reading plaintext
+    When I parse the pattern
+      """
+      !Name: Glider
+      !
+      .O
+      ..O
+      OOO
+      """
+    Then the colony should be under the rule "B3/S23"
+      """
+      .O.
+      ..O
+      OOO
+      """
+
+  Scenario Outline: Patterns that can't be read
+    When I parse the pattern
+      """
+      <header>
+      <body>
+      """
+    Then parsing should fail with "<error>"
+
+    Examples:
+      | header                       | body                      | error                           |
+      | x = 2, y = 1, rule = B3/S23  | o$o?!                     | unexpected '?'                  |
+      | x = 2, y = 1, rule = B2/S/C3 | AC!                       | state 3 isn't one of the 3      |
+      | x = 2, y = 1, rule = B3/S23x | bo!                       | rule in header                  |
+      | x = 2, y = 1, rule = B3/S23  | 2pA!                      | unexpected 'A'                  |
+      | !Name: broken                | .O.#                      | unexpected '#' on line 2        |
+      | x = 3, y = 3                 | 3o$100000000o!            | run of more than 1024 cells     |
+      | x = 3, y = 3                 | 3o$99999999999999999999o! | run of more than 1024 cells     |
+      | x = 3, y = 3                 | 1000o25o!                 | 1025x1 is larger than 1024x1024 |
+      | x = 3, y = 3                 | o1000$24$o!               | 1x1025 is larger than 1024x1024 |
+      | x = 2000, y = 3              | o!                        | 2000x3 is larger than 1024x1024 |
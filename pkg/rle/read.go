@@ -0,0 +1,221 @@
+package rle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MaxSize is the largest width or height of a pattern that is read. Patterns come from pasted text, so larger
+// ones are refused rather than allocated.
+const MaxSize = 1024
+
+var InvalidPattern = errors.New("invalid pattern")
+
+// tooLarge returns the error for a pattern that would be w×h cells.
+func tooLarge(w, h int) error {
+	return fmt.Errorf("%w: %dx%d is larger than %dx%d", InvalidPattern, w, h, MaxSize, MaxSize)
+}
+
+// cell is a cell read from a pattern that isn't dead.
+type cell struct {
+	x, y  int
+	state uint8
+}
+
+// Read reads a pattern in run length encoded format. The header line is optional; without it the pattern runs
+// under Conway's Life and is as big as its cells. Comment lines starting with # are skipped.
+func Read(r io.Reader) (*model.Colony, error) {
+	scanner := bufio.NewScanner(r)
+	var rule model.Rule = model.Life
+	width, height := 0, 0
+	var body strings.Builder
+	header := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case header && strings.HasPrefix(line, "x"):
+			var err error
+			if width, height, rule, err = readHeader(line); err != nil {
+				return nil, err
+			}
+		default:
+			body.WriteString(line)
+		}
+		header = false
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var cells []cell
+	x, y, n := 0, 0, 0
+	prefix := 0
+	w, h := 0, 1
+body:
+	for _, ch := range body.String() {
+		if ch >= '0' && ch <= '9' {
+			if n = n*10 + int(ch-'0'); n > MaxSize {
+				return nil, fmt.Errorf("%w: run of more than %d cells", InvalidPattern, MaxSize)
+			}
+			continue
+		}
+		run := max(n, 1)
+		n = 0
+		switch {
+		case ch == '!':
+			break body
+		case ch == '$':
+			x, y = 0, y+run
+			h = max(h, y+1)
+			if h > MaxSize {
+				return nil, tooLarge(w, h)
+			}
+		case ch == ' ' || ch == '\t':
+		case ch >= 'p' && ch <= 'y' && rule.States() > 2:
+			prefix = int(ch-'o') * 24
+			n = run
+		default:
+			state, err := readState(ch, prefix, rule)
+			if err != nil {
+				return nil, err
+			}
+			prefix = 0
+			if state != 0 {
+				for i := 0; i < run; i++ {
+					cells = append(cells, cell{x: x + i, y: y, state: state})
+				}
+			}
+			x += run
+			w = max(w, x)
+			if w > MaxSize {
+				return nil, tooLarge(w, h)
+			}
+		}
+	}
+	return colony(max(width, w, 1), max(height, h), rule, cells), nil
+}
+
+// readHeader reads the size and rule from a header line such as "x = 3, y = 3, rule = B3/S23".
+func readHeader(line string) (int, int, model.Rule, error) {
+	var rule model.Rule = model.Life
+	width, height := 0, 0
+	for _, item := range strings.Split(line, ",") {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return 0, 0, nil, fmt.Errorf("%w: header item %q has no value", InvalidPattern, item)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		var err error
+		switch key {
+		case "x":
+			width, err = strconv.Atoi(value)
+		case "y":
+			height, err = strconv.Atoi(value)
+		case "rule":
+			rule, err = model.ParseRule(value)
+		}
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("%w: %s in header: %w", InvalidPattern, key, err)
+		}
+	}
+	if width < 0 || height < 0 {
+		return 0, 0, nil, fmt.Errorf("%w: size %dx%d", InvalidPattern, width, height)
+	}
+	if width > MaxSize || height > MaxSize {
+		return 0, 0, nil, tooLarge(width, height)
+	}
+	return width, height, rule, nil
+}
+
+// readState returns the state a letter stands for under the rule, after any p to y prefix.
+func readState(ch rune, prefix int, rule model.Rule) (uint8, error) {
+	var state int
+	switch {
+	case ch == 'b' || ch == '.':
+		state = 0
+	case ch == 'o' && prefix == 0:
+		state = 1
+	case ch >= 'A' && ch <= 'X' && rule.States() > 2:
+		state = prefix + int(ch-'A') + 1
+	case ch >= 'a' && ch <= 'z' && rule.States() <= 2:
+		state = 1 // Two-state patterns may use any letter for live cells
+	default:
+		return 0, fmt.Errorf("%w: unexpected %q", InvalidPattern, ch)
+	}
+	if state >= rule.States() {
+		return 0, fmt.Errorf("%w: state %d isn't one of the %d states of %s", InvalidPattern, state, rule.States(), rule)
+	}
+	return uint8(state), nil
+}
+
+// ReadPlaintext reads a pattern in the plaintext format, with . for dead cells and O or * for live ones on each
+// line. Lines starting with ! are comments.
+func ReadPlaintext(r io.Reader) (*model.Colony, error) {
+	scanner := bufio.NewScanner(r)
+	var cells []cell
+	w, h := 1, 0
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.HasPrefix(line, "!") {
+			continue
+		}
+		for x, ch := range []rune(line) {
+			switch ch {
+			case '.':
+			case 'O', '*':
+				cells = append(cells, cell{x: x, y: h, state: 1})
+				w = max(w, x+1)
+			default:
+				return nil, fmt.Errorf("%w: unexpected %q on line %d", InvalidPattern, ch, n)
+			}
+		}
+		h++
+		if w > MaxSize || h > MaxSize {
+			return nil, tooLarge(w, h)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return colony(w, max(h, 1), model.Life, cells), nil
+}
+
+// Parse reads a pattern in either format: run length encoded when it has a header or ends with !, and
+// plaintext otherwise.
+func Parse(text string) (*model.Colony, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: no cells", InvalidPattern)
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "x") && strings.Contains(line, "=") {
+			return Read(strings.NewReader(text))
+		}
+		break
+	}
+	if strings.HasSuffix(text, "!") && !strings.HasPrefix(text, "!") {
+		return Read(strings.NewReader(text))
+	}
+	return ReadPlaintext(strings.NewReader(text))
+}
+
+// colony puts the cells in a w×h colony under the rule.
+func colony(w, h int, rule model.Rule, cells []cell) *model.Colony {
+	c := model.NewColony(w, h)
+	c.SetRule(rule)
+	for _, cell := range cells {
+		c.SetState(cell.x, cell.y, cell.state)
+	}
+	return c
+}
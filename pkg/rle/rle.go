@@ -0,0 +1,95 @@
+package rle
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"io"
+	"strings"
+)
+
+// lineLength is the longest line written, as recommended for the format.
+const lineLength = 70
+
+// Write writes the colony in the run length encoded format used by Golly and LifeWiki, with a header giving its
+// size and rule and each comment on a #C line. Two-state rules use b and o for dead and live cells; rules with more
+// states use . for state 0 and A to X, then pA to pX and so on, for states 1 and up.
+func Write(w io.Writer, c *model.Colony, comments ...string) error {
+	bw := bufio.NewWriter(w)
+	for _, comment := range comments {
+		fmt.Fprintf(bw, "#C %s\n", comment)
+	}
+	fmt.Fprintf(bw, "x = %d, y = %d, rule = %s\n", c.Width(), c.Height(), c.Rule())
+	l := line{w: bw}
+	states := c.Rule().States()
+	row := 0 // Row the runs written so far have reached
+	for y := 0; y < c.Height(); y++ {
+		end := c.Width()
+		for end > 0 && c.State(end-1, y) == 0 {
+			end--
+		}
+		if end == 0 {
+			continue
+		}
+		if y > row {
+			l.run(y-row, "$")
+			row = y
+		}
+		for x := 0; x < end; {
+			state := c.State(x, y)
+			n := 1
+			for x+n < end && c.State(x+n, y) == state {
+				n++
+			}
+			l.run(n, symbol(state, states))
+			x += n
+		}
+	}
+	l.run(1, "!")
+	fmt.Fprintln(bw)
+	return bw.Flush()
+}
+
+// symbol returns the letters for a cell state.
+func symbol(state uint8, states int) string {
+	if states <= 2 {
+		if state == 0 {
+			return "b"
+		}
+		return "o"
+	}
+	if state == 0 {
+		return "."
+	}
+	letter := string(rune('A' + (int(state)-1)%24))
+	if state > 24 {
+		return string(rune('o'+(int(state)-1)/24)) + letter
+	}
+	return letter
+}
+
+// line writes runs, breaking lines before they grow too long.
+type line struct {
+	w      *bufio.Writer
+	length int
+}
+
+func (l *line) run(n int, symbol string) {
+	item := symbol
+	if n > 1 {
+		item = fmt.Sprint(n) + symbol
+	}
+	if l.length+len(item) > lineLength {
+		l.w.WriteString("\n")
+		l.length = 0
+	}
+	l.w.WriteString(item)
+	l.length += len(item)
+}
+
+// String returns the colony in run length encoded format.
+func String(c *model.Colony, comments ...string) string {
+	var b strings.Builder
+	Write(&b, c, comments...)
+	return b.String()
+}
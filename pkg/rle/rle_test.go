@@ -0,0 +1,171 @@
+package rle
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"strings"
+	"testing"
+)
+
+type rleFeature struct {
+	colony *model.Colony
+	rle    string
+	parsed *model.Colony
+	err    error
+}
+
+// theColonyUnderTheRule reads a colony drawn with O for live cells, . for dead ones and digits for other states.
+func (f *rleFeature) theColonyUnderTheRule(rulestring string, doc *godog.DocString) error {
+	rule, err := model.ParseRule(rulestring)
+	if err != nil {
+		return err
+	}
+	rows := strings.Split(strings.TrimSpace(doc.Content), "\n")
+	f.colony = model.NewColony(len(rows[0]), len(rows))
+	f.colony.SetRule(rule)
+	for y, row := range rows {
+		for x, c := range row {
+			switch {
+			case c == 'O':
+				f.colony.SetState(x, y, 1)
+			case c >= '0' && c <= '9':
+				f.colony.SetState(x, y, uint8(c-'0'))
+			}
+		}
+	}
+	return nil
+}
+
+func (f *rleFeature) aColonyWithEveryOtherCellAlive(w, h int, rulestring string) error {
+	rule, err := model.ParseRule(rulestring)
+	if err != nil {
+		return err
+	}
+	f.colony = model.NewColony(w, h)
+	f.colony.SetRule(rule)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x += 2 {
+			f.colony.SetState(x, y, 1)
+		}
+	}
+	return nil
+}
+
+func (f *rleFeature) itIsWrittenAsRLE() error {
+	f.rle = String(f.colony)
+	return nil
+}
+
+func (f *rleFeature) itIsWrittenAsRLEWithTheComment(comment string) error {
+	f.rle = String(f.colony, comment)
+	return nil
+}
+
+func (f *rleFeature) theRLEShouldBe(doc *godog.DocString) error {
+	if strings.TrimSpace(f.rle) != strings.TrimSpace(doc.Content) {
+		return fmt.Errorf("expected\n%s\ngot\n%s", doc.Content, f.rle)
+	}
+	return nil
+}
+
+func (f *rleFeature) noLineShouldBeLongerThan(n int) error {
+	for _, line := range strings.Split(f.rle, "\n") {
+		if len(line) > n {
+			return fmt.Errorf("line %q is longer than %d characters", line, n)
+		}
+	}
+	return nil
+}
+
+func (f *rleFeature) iParseThePattern(doc *godog.DocString) error {
+	f.parsed, f.err = Parse(doc.Content)
+	return nil
+}
+
+func (f *rleFeature) iParseTheRLE() error {
+	f.parsed, f.err = Parse(f.rle)
+	return nil
+}
+
+// cells draws a colony with O for live cells, . for dead ones and digits for other states.
+func cells(c *model.Colony) string {
+	var b strings.Builder
+	for y := 0; y < c.Height(); y++ {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+		for x := 0; x < c.Width(); x++ {
+			switch s := c.State(x, y); s {
+			case 0:
+				b.WriteByte('.')
+			case 1:
+				b.WriteByte('O')
+			default:
+				b.WriteByte('0' + s)
+			}
+		}
+	}
+	return b.String()
+}
+
+func (f *rleFeature) theColonyShouldBeUnderTheRule(rulestring string, doc *godog.DocString) error {
+	if f.err != nil {
+		return f.err
+	}
+	if got := f.parsed.Rule().String(); got != rulestring {
+		return fmt.Errorf("expected rule %s, got %s", rulestring, got)
+	}
+	if got, want := cells(f.parsed), strings.TrimSpace(doc.Content); got != want {
+		return fmt.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+	return nil
+}
+
+func (f *rleFeature) theColonyShouldBeTheSameAsTheOneWritten() error {
+	if f.err != nil {
+		return f.err
+	}
+	if got, want := cells(f.parsed), cells(f.colony); got != want || f.parsed.Rule().String() != f.colony.Rule().String() {
+		return fmt.Errorf("wrote\n%s\nread back\n%s", want, got)
+	}
+	return nil
+}
+
+func (f *rleFeature) parsingShouldFailWith(message string) error {
+	if !errors.Is(f.err, InvalidPattern) || !strings.Contains(f.err.Error(), message) {
+		return fmt.Errorf("expected an invalid pattern with %q, got %v", message, f.err)
+	}
+	return nil
+}
+
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	f := &rleFeature{}
+	ctx.Step(`^the colony under the rule "([^"]*)"$`, f.theColonyUnderTheRule)
+	ctx.Step(`^a (\d+)x(\d+) colony with every other cell alive under the rule "([^"]*)"$`, f.aColonyWithEveryOtherCellAlive)
+	ctx.Step(`^it is written as RLE$`, f.itIsWrittenAsRLE)
+	ctx.Step(`^it is written as RLE with the comment "([^"]*)"$`, f.itIsWrittenAsRLEWithTheComment)
+	ctx.Step(`^the RLE should be$`, f.theRLEShouldBe)
+	ctx.Step(`^I parse the pattern$`, f.iParseThePattern)
+	ctx.Step(`^I parse the RLE$`, f.iParseTheRLE)
+	ctx.Step(`^the colony should be under the rule "([^"]*)"$`, f.theColonyShouldBeUnderTheRule)
+	ctx.Step(`^the colony should be the same as the one written$`, f.theColonyShouldBeTheSameAsTheOneWritten)
+	ctx.Step(`^parsing should fail with "([^"]*)"$`, f.parsingShouldFailWith)
+	ctx.Step(`^no line of the RLE should be longer than (\d+) characters$`, f.noLineShouldBeLongerThan)
+}
+
+func TestRLEFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "rle",
+		ScenarioInitializer: InitializeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/rle.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
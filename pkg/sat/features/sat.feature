@@ -0,0 +1,91 @@
+Feature: SAT solving
+
+  Scenario: A satisfiable formula is solved
+    Given the formula
+      """
+      p cnf 4 5
+      1 2 0
+      -1 3 0
+      -2 -3 0
+      3 4 0
+      -4 -1 0
+      """
+    When the formula is solved
+    Then it should be satisfiable
+    And the solution should satisfy every clause
+
+  Scenario: An unsatisfiable formula is refuted
+    Given the formula
+      """
+      p cnf 2 4
+      1 2 0
+      1 -2 0
+      -1 2 0
+      -1 -2 0
+      """
+    When the formula is solved
+    Then it should be unsatisfiable
+
+  Scenario: An empty clause can't be satisfied
+    Given the formula
+      """
+      p cnf 1 2
+      1 0
+      0
+      """
+    When the formula is solved
+    Then it should be unsatisfiable
+
+  Scenario Outline: Pigeons only fit when there are enough holes
+    Given the formula putting <pigeons> pigeons in <holes> holes
+    When the formula is solved
+    Then it should be <answer>
+
+    Examples:
+      | pigeons | holes | answer        |
+      | 4       | 4     | satisfiable   |
+      | 5       | 4     | unsatisfiable |
+      | 7       | 7     | satisfiable   |
+      | 7       | 6     | unsatisfiable |
+
+  Scenario Outline: Random formulas with a planted solution are solved
+    Given a random formula of <clauses> clauses over <variables> variables satisfied by a hidden assignment from seed <seed>
+    When the formula is solved
+    Then it should be satisfiable
+    And the solution should satisfy every clause
+
+    Examples:
+      | variables | clauses | seed |
+      | 50        | 200     | 1    |
+      | 100       | 420     | 2    |
+      | 200       | 850     | 3    |
+
+  Scenario: Solutions can be excluded to find the others
+    Given the formula
+      """
+      p cnf 3 4
+      1 2 3 0
+      -1 -2 0
+      -1 -3 0
+      -2 -3 0
+      """
+    When every solution is found by excluding the ones before
+    Then there should be 3 solutions
+
+  Scenario: A cancelled search gives up
+    Given the formula putting 9 pigeons in 8 holes
+    When the formula is solved with a cancelled context
+    Then the search should have been cancelled
+
+  Scenario Outline: Clauses encode a boolean function
+    When the clauses for <function> of <inputs> inputs are made
+    Then the clauses should agree with <function> on every input
+    And there should be at most <most> clauses
+
+    Examples:
+      | function      | inputs | most |
+      | majority      | 3      | 3    |
+      | parity        | 4      | 8    |
+      | exactly three | 8      | 200  |
+      | false         | 2      | 1    |
+      | true          | 2      | 0    |
@@ -0,0 +1,113 @@
+package sat
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// cube is a conjunction of input values: the bits of value set where mask is clear, with masked bits free.
+type cube struct {
+	value uint32
+	mask  uint32
+}
+
+// Function returns clauses over n inputs, numbered from 1, that are all satisfied exactly when f is true of the
+// inputs, with input i in bit i-1 of the argument to f. The clauses are kept short by merging the inputs f is false
+// on into prime implicants, so a rule lookup table becomes a compact encoding. n should be small, at most about 12.
+func Function(n int, f func(uint32) bool) [][]int {
+	var level []cube
+	for v := uint32(0); v < 1<<n; v++ {
+		if !f(v) {
+			level = append(level, cube{value: v})
+		}
+	}
+	var primes []cube
+	for len(level) > 0 {
+		present := make(map[cube]bool, len(level))
+		for _, c := range level {
+			present[c] = true
+		}
+		merged := make(map[cube]bool)
+		used := make(map[cube]bool)
+		for _, c := range level {
+			for i := 0; i < n; i++ {
+				bit := uint32(1) << i
+				if c.mask&bit != 0 || c.value&bit != 0 {
+					continue
+				}
+				partner := cube{value: c.value | bit, mask: c.mask}
+				if present[partner] {
+					merged[cube{value: c.value, mask: c.mask | bit}] = true
+					used[c], used[partner] = true, true
+				}
+			}
+		}
+		var next []cube
+		for _, c := range level {
+			if !used[c] {
+				primes = append(primes, c)
+			}
+		}
+		for c := range merged {
+			next = append(next, c)
+		}
+		sort.Slice(next, func(i, j int) bool {
+			return next[i].mask < next[j].mask || (next[i].mask == next[j].mask && next[i].value < next[j].value)
+		})
+		level = next
+	}
+	return cover(n, primes, f)
+}
+
+// cover greedily picks prime implicants until every input f is false on is covered, and turns each one into the
+// clause that excludes it.
+func cover(n int, primes []cube, f func(uint32) bool) [][]int {
+	covered := make([]bool, 1<<n)
+	for v := range covered {
+		covered[v] = f(uint32(v))
+	}
+	var clauses [][]int
+	for {
+		best, bestCount := -1, 0
+		for i, p := range primes {
+			count := 0
+			p.each(func(v uint32) {
+				if !covered[v] {
+					count++
+				}
+			})
+			if count > bestCount || (count == bestCount && count > 0 && bits.OnesCount32(p.mask) > bits.OnesCount32(primes[best].mask)) {
+				best, bestCount = i, count
+			}
+		}
+		if best < 0 {
+			return clauses
+		}
+		p := primes[best]
+		p.each(func(v uint32) { covered[v] = true })
+		var clause []int
+		for i := 0; i < n; i++ {
+			bit := uint32(1) << i
+			switch {
+			case p.mask&bit != 0:
+			case p.value&bit != 0:
+				clause = append(clause, -(i + 1))
+			default:
+				clause = append(clause, i+1)
+			}
+		}
+		clauses = append(clauses, clause)
+	}
+}
+
+// each calls visit with every input the cube contains.
+func (c cube) each(visit func(uint32)) {
+	sub := uint32(0)
+	for {
+		visit(c.value | sub)
+		if sub == c.mask {
+			return
+		}
+		sub = (sub - c.mask) & c.mask
+	}
+}
@@ -0,0 +1,86 @@
+package sat
+
+// heap orders variables by activity, most active first.
+type heap struct {
+	activity *[]float64
+	items    []int
+	index    []int // Position of each variable in items, or -1 when it isn't in the heap
+}
+
+func (h *heap) empty() bool {
+	return len(h.items) == 0
+}
+
+func (h *heap) less(a, b int) bool {
+	return (*h.activity)[a] > (*h.activity)[b]
+}
+
+// insert adds a variable if it isn't in the heap already.
+func (h *heap) insert(v int) {
+	for len(h.index) <= v {
+		h.index = append(h.index, -1)
+	}
+	if h.index[v] >= 0 {
+		return
+	}
+	h.index[v] = len(h.items)
+	h.items = append(h.items, v)
+	h.up(h.index[v])
+}
+
+// update restores the order after the activity of a variable has increased.
+func (h *heap) update(v int) {
+	if v < len(h.index) && h.index[v] >= 0 {
+		h.up(h.index[v])
+	}
+}
+
+// pop removes and returns the most active variable.
+func (h *heap) pop() int {
+	v := h.items[0]
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	h.index[v] = -1
+	if len(h.items) > 0 {
+		h.items[0] = last
+		h.index[last] = 0
+		h.down(0)
+	}
+	return v
+}
+
+func (h *heap) up(i int) {
+	v := h.items[i]
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(v, h.items[parent]) {
+			break
+		}
+		h.items[i] = h.items[parent]
+		h.index[h.items[i]] = i
+		i = parent
+	}
+	h.items[i] = v
+	h.index[v] = i
+}
+
+func (h *heap) down(i int) {
+	v := h.items[i]
+	for {
+		child := 2*i + 1
+		if child >= len(h.items) {
+			break
+		}
+		if child+1 < len(h.items) && h.less(h.items[child+1], h.items[child]) {
+			child++
+		}
+		if !h.less(h.items[child], v) {
+			break
+		}
+		h.items[i] = h.items[child]
+		h.index[h.items[i]] = i
+		i = child
+	}
+	h.items[i] = v
+	h.index[v] = i
+}
@@ -0,0 +1,384 @@
+package sat
+
+import (
+	"context"
+	"sort"
+)
+
+// Solver decides whether a formula in conjunctive normal form can be satisfied, using conflict-driven clause
+// learning. Variables are numbered from 1 and literals are written as in DIMACS: v for variable v being true and
+// -v for it being false.
+//
+// Clauses may be added between calls to Solve, so a caller can exclude a solution and ask for another.
+type Solver struct {
+	clauses  [][]literal
+	watches  [][]int   // Clauses watching each literal, by index into clauses
+	assigns  []int8    // Value of each variable: 0 unassigned, 1 true or -1 false
+	level    []int     // Decision level at which each variable was assigned
+	reason   []int     // Clause that implied each variable, or -1 for decisions
+	phase    []bool    // Last value of each variable, tried first when deciding on it
+	activity []float64 // How often each variable took part in recent conflicts
+	seen     []bool
+	order    heap
+	trail    []literal // Assigned literals in the order they were assigned
+	limits   []int     // Length of the trail at the start of each decision level
+	head     int       // Next trail entry to propagate
+	bump     float64
+	unsat    bool
+	model    []bool
+}
+
+// literal is a literal numbered from 0: twice the variable index, plus one when negated.
+type literal int
+
+func (l literal) variable() int {
+	return int(l >> 1)
+}
+
+func (l literal) not() literal {
+	return l ^ 1
+}
+
+// activityDecay is how much the activity of variables fades with each conflict.
+const activityDecay = 0.95
+
+// restartUnit is the number of conflicts in the shortest run between restarts.
+const restartUnit = 100
+
+// checkInterval is the number of conflicts between checks for a cancelled context.
+const checkInterval = 256
+
+// New returns a solver with no variables or clauses.
+func New() *Solver {
+	s := &Solver{bump: 1}
+	s.order.activity = &s.activity
+	return s
+}
+
+// NewVariable adds a variable and returns its number.
+func (s *Solver) NewVariable() int {
+	v := len(s.assigns)
+	s.assigns = append(s.assigns, 0)
+	s.level = append(s.level, 0)
+	s.reason = append(s.reason, -1)
+	s.phase = append(s.phase, false)
+	s.activity = append(s.activity, 0)
+	s.seen = append(s.seen, false)
+	s.watches = append(s.watches, nil, nil)
+	s.order.insert(v)
+	return v + 1
+}
+
+// Variables returns the number of variables.
+func (s *Solver) Variables() int {
+	return len(s.assigns)
+}
+
+// AddClause adds a clause satisfied when any of its literals is true. Variables are created as needed.
+// An empty clause makes the formula unsatisfiable.
+func (s *Solver) AddClause(literals ...int) {
+	if s.unsat {
+		return
+	}
+	c := make([]literal, 0, len(literals))
+	for _, l := range literals {
+		v := l
+		if v < 0 {
+			v = -v
+		}
+		for s.Variables() < v {
+			s.NewVariable()
+		}
+		if l > 0 {
+			c = append(c, literal(2*(v-1)))
+		} else {
+			c = append(c, literal(2*(v-1)+1))
+		}
+	}
+	sort.Slice(c, func(i, j int) bool { return c[i] < c[j] })
+	kept := c[:0]
+	for i, l := range c {
+		switch {
+		case s.value(l) == 1 || (i > 0 && l == c[i-1].not()):
+			return // Already satisfied, or a tautology
+		case s.value(l) == -1 || (i > 0 && l == c[i-1]):
+			continue
+		}
+		kept = append(kept, l)
+	}
+	switch len(kept) {
+	case 0:
+		s.unsat = true
+	case 1:
+		s.enqueue(kept[0], -1)
+		if s.propagate() != -1 {
+			s.unsat = true
+		}
+	default:
+		s.attach(kept)
+	}
+}
+
+// Solve reports whether the clauses can all be satisfied. If they can, Value returns the solution.
+// It gives up with the context's error if the context is done first.
+func (s *Solver) Solve(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if s.unsat {
+		return false, nil
+	}
+	for run := 0; ; run++ {
+		status, err := s.search(ctx, luby(run)*restartUnit)
+		if err != nil {
+			s.cancelUntil(0)
+			return false, err
+		}
+		switch status {
+		case 1:
+			s.model = make([]bool, len(s.assigns))
+			for v, a := range s.assigns {
+				s.model[v] = a == 1
+			}
+			s.cancelUntil(0)
+			return true, nil
+		case -1:
+			s.unsat = true
+			return false, nil
+		}
+	}
+}
+
+// Value returns the value of a variable in the solution found by the last successful call to Solve.
+func (s *Solver) Value(v int) bool {
+	return v >= 1 && v <= len(s.model) && s.model[v-1]
+}
+
+// search decides and propagates until it finds a solution (1), proves there is none (-1) or reaches the conflict
+// limit (0), after which it backtracks so the search can be restarted.
+func (s *Solver) search(ctx context.Context, limit int) (int, error) {
+	for conflicts := 0; ; {
+		if conflict := s.propagate(); conflict != -1 {
+			conflicts++
+			if len(s.limits) == 0 {
+				return -1, nil
+			}
+			learnt, back := s.analyse(conflict)
+			s.cancelUntil(back)
+			s.learn(learnt)
+			s.bump /= activityDecay
+			if conflicts%checkInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return 0, err
+				}
+			}
+			continue
+		}
+		if conflicts >= limit {
+			s.cancelUntil(0)
+			return 0, ctx.Err()
+		}
+		v := s.decision()
+		if v < 0 {
+			return 1, nil
+		}
+		s.limits = append(s.limits, len(s.trail))
+		l := literal(2 * v)
+		if !s.phase[v] {
+			l = l.not()
+		}
+		s.enqueue(l, -1)
+	}
+}
+
+// value returns 1 if the literal is true, -1 if it is false and 0 if its variable is unassigned.
+func (s *Solver) value(l literal) int8 {
+	a := s.assigns[l.variable()]
+	if l&1 == 1 {
+		return -a
+	}
+	return a
+}
+
+// attach adds a clause of at least two literals, watching the first two.
+func (s *Solver) attach(c []literal) int {
+	i := len(s.clauses)
+	s.clauses = append(s.clauses, c)
+	s.watches[c[0]] = append(s.watches[c[0]], i)
+	s.watches[c[1]] = append(s.watches[c[1]], i)
+	return i
+}
+
+// enqueue makes a literal true, implied by the given clause or -1 for a decision.
+func (s *Solver) enqueue(l literal, reason int) {
+	v := l.variable()
+	s.assigns[v] = 1
+	if l&1 == 1 {
+		s.assigns[v] = -1
+	}
+	s.level[v] = len(s.limits)
+	s.reason[v] = reason
+	s.trail = append(s.trail, l)
+}
+
+// propagate assigns the literals implied by unit clauses, returning a clause that has become false or -1.
+// A clause implying a literal always has that literal first.
+func (s *Solver) propagate() int {
+	for s.head < len(s.trail) {
+		falsified := s.trail[s.head].not()
+		s.head++
+		watching := s.watches[falsified]
+		kept := 0
+		for i := 0; i < len(watching); i++ {
+			ci := watching[i]
+			c := s.clauses[ci]
+			if c[0] == falsified {
+				c[0], c[1] = c[1], c[0]
+			}
+			if s.value(c[0]) == 1 {
+				watching[kept] = ci
+				kept++
+				continue
+			}
+			moved := false
+			for k := 2; k < len(c); k++ {
+				if s.value(c[k]) != -1 {
+					c[1], c[k] = c[k], c[1]
+					s.watches[c[1]] = append(s.watches[c[1]], ci)
+					moved = true
+					break
+				}
+			}
+			if moved {
+				continue
+			}
+			watching[kept] = ci
+			kept++
+			if s.value(c[0]) == -1 {
+				kept += copy(watching[kept:], watching[i+1:])
+				s.watches[falsified] = watching[:kept]
+				s.head = len(s.trail)
+				return ci
+			}
+			s.enqueue(c[0], ci)
+		}
+		s.watches[falsified] = watching[:kept]
+	}
+	return -1
+}
+
+// analyse derives a learnt clause from a conflict by resolving back to the first unique implication point.
+// It returns the clause, with the literal it asserts first, and the level to backtrack to.
+func (s *Solver) analyse(conflict int) ([]literal, int) {
+	learnt := []literal{0}
+	current := len(s.limits)
+	pending := 0
+	var p literal
+	first := true
+	i := len(s.trail) - 1
+	for {
+		c := s.clauses[conflict]
+		if !first {
+			c = c[1:]
+		}
+		first = false
+		for _, q := range c {
+			v := q.variable()
+			if s.seen[v] || s.level[v] == 0 {
+				continue
+			}
+			s.seen[v] = true
+			s.raise(v)
+			if s.level[v] == current {
+				pending++
+			} else {
+				learnt = append(learnt, q)
+			}
+		}
+		for !s.seen[s.trail[i].variable()] {
+			i--
+		}
+		p = s.trail[i]
+		i--
+		s.seen[p.variable()] = false
+		conflict = s.reason[p.variable()]
+		pending--
+		if pending == 0 {
+			break
+		}
+	}
+	learnt[0] = p.not()
+	back := 0
+	for j := 1; j < len(learnt); j++ {
+		v := learnt[j].variable()
+		s.seen[v] = false
+		if s.level[v] > back {
+			back = s.level[v]
+			learnt[1], learnt[j] = learnt[j], learnt[1]
+		}
+	}
+	return learnt, back
+}
+
+// learn adds a learnt clause after backtracking and asserts its first literal.
+func (s *Solver) learn(learnt []literal) {
+	if len(learnt) == 1 {
+		s.enqueue(learnt[0], -1)
+		return
+	}
+	s.enqueue(learnt[0], s.attach(learnt))
+}
+
+// raise bumps the activity of a variable that took part in a conflict.
+func (s *Solver) raise(v int) {
+	s.activity[v] += s.bump
+	if s.activity[v] > 1e100 {
+		for i := range s.activity {
+			s.activity[i] *= 1e-100
+		}
+		s.bump *= 1e-100
+	}
+	s.order.update(v)
+}
+
+// cancelUntil undoes the assignments made after the given decision level.
+func (s *Solver) cancelUntil(level int) {
+	if len(s.limits) <= level {
+		return
+	}
+	for i := len(s.trail) - 1; i >= s.limits[level]; i-- {
+		v := s.trail[i].variable()
+		s.phase[v] = s.assigns[v] == 1
+		s.assigns[v] = 0
+		s.reason[v] = -1
+		s.order.insert(v)
+	}
+	s.trail = s.trail[:s.limits[level]]
+	s.limits = s.limits[:level]
+	s.head = len(s.trail)
+}
+
+// decision returns the most active unassigned variable, or -1 if every variable is assigned.
+func (s *Solver) decision() int {
+	for !s.order.empty() {
+		if v := s.order.pop(); s.assigns[v] == 0 {
+			return v
+		}
+	}
+	return -1
+}
+
+// luby returns the i-th term of the Luby sequence 1, 1, 2, 1, 1, 2, 4, ..., which spaces out restarts.
+func luby(i int) int {
+	size, exponent := 1, 0
+	for size < i+1 {
+		exponent++
+		size = 2*size + 1
+	}
+	for size-1 != i {
+		size = (size - 1) >> 1
+		exponent--
+		i %= size
+	}
+	return 1 << exponent
+}
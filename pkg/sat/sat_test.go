@@ -0,0 +1,250 @@
+package sat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/cucumber/godog"
+	"math/bits"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type satFeature struct {
+	clauses   [][]int
+	solver    *Solver
+	satisfied bool
+	err       error
+	solutions int
+	function  func(uint32) bool
+	inputs    int
+}
+
+var functions = map[string]func(uint32) bool{
+	"majority":      func(v uint32) bool { return bits.OnesCount32(v) >= 2 },
+	"parity":        func(v uint32) bool { return bits.OnesCount32(v)%2 == 1 },
+	"exactly three": func(v uint32) bool { return bits.OnesCount32(v) == 3 },
+	"false":         func(uint32) bool { return false },
+	"true":          func(uint32) bool { return true },
+}
+
+func (f *satFeature) theFormula(doc *godog.DocString) error {
+	f.clauses = nil
+	var clause []int
+	for _, line := range strings.Split(doc.Content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "c") || strings.HasPrefix(line, "p") {
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			l, err := strconv.Atoi(field)
+			if err != nil {
+				return err
+			}
+			if l == 0 {
+				f.clauses = append(f.clauses, clause)
+				clause = nil
+				continue
+			}
+			clause = append(clause, l)
+		}
+	}
+	return nil
+}
+
+// pigeonhole builds the formula that each pigeon is in a hole and no hole holds two pigeons.
+func (f *satFeature) theFormulaPuttingPigeonsInHoles(pigeons, holes int) error {
+	f.clauses = nil
+	variable := func(p, h int) int { return p*holes + h + 1 }
+	for p := 0; p < pigeons; p++ {
+		var clause []int
+		for h := 0; h < holes; h++ {
+			clause = append(clause, variable(p, h))
+		}
+		f.clauses = append(f.clauses, clause)
+	}
+	for h := 0; h < holes; h++ {
+		for p := 0; p < pigeons; p++ {
+			for q := p + 1; q < pigeons; q++ {
+				f.clauses = append(f.clauses, []int{-variable(p, h), -variable(q, h)})
+			}
+		}
+	}
+	return nil
+}
+
+func (f *satFeature) aRandomFormulaWithAHiddenAssignment(clauses, variables int, seed uint64) error {
+	rng := rand.New(rand.NewPCG(seed, 0))
+	hidden := make([]bool, variables+1)
+	for v := range hidden {
+		hidden[v] = rng.IntN(2) == 1
+	}
+	f.clauses = nil
+	for len(f.clauses) < clauses {
+		clause := make([]int, 3)
+		satisfied := false
+		for i := range clause {
+			v := rng.IntN(variables) + 1
+			clause[i] = v
+			if rng.IntN(2) == 1 {
+				clause[i] = -v
+			}
+			satisfied = satisfied || (clause[i] > 0) == hidden[v]
+		}
+		if satisfied {
+			f.clauses = append(f.clauses, clause)
+		}
+	}
+	return nil
+}
+
+func (f *satFeature) load() {
+	f.solver = New()
+	for _, c := range f.clauses {
+		f.solver.AddClause(c...)
+	}
+}
+
+func (f *satFeature) theFormulaIsSolved() error {
+	f.load()
+	f.satisfied, f.err = f.solver.Solve(context.Background())
+	return f.err
+}
+
+func (f *satFeature) theFormulaIsSolvedWithACancelledContext() error {
+	f.load()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f.satisfied, f.err = f.solver.Solve(ctx)
+	return nil
+}
+
+func (f *satFeature) everySolutionIsFound() error {
+	f.load()
+	for {
+		ok, err := f.solver.Solve(context.Background())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		f.solutions++
+		if f.solutions > 1<<f.solver.Variables() {
+			return fmt.Errorf("found more solutions than there are assignments")
+		}
+		blocking := make([]int, f.solver.Variables())
+		for v := 1; v <= f.solver.Variables(); v++ {
+			blocking[v-1] = v
+			if f.solver.Value(v) {
+				blocking[v-1] = -v
+			}
+		}
+		f.solver.AddClause(blocking...)
+	}
+}
+
+func (f *satFeature) itShouldBe(answer string) error {
+	if f.satisfied != (answer == "satisfiable") {
+		return fmt.Errorf("expected the formula to be %s", answer)
+	}
+	return nil
+}
+
+func (f *satFeature) theSolutionShouldSatisfyEveryClause() error {
+	for _, c := range f.clauses {
+		satisfied := false
+		for _, l := range c {
+			if l > 0 == f.solver.Value(max(l, -l)) {
+				satisfied = true
+			}
+		}
+		if !satisfied {
+			return fmt.Errorf("clause %v is not satisfied", c)
+		}
+	}
+	return nil
+}
+
+func (f *satFeature) thereShouldBeSolutions(n int) error {
+	if f.solutions != n {
+		return fmt.Errorf("expected %d solutions, found %d", n, f.solutions)
+	}
+	return nil
+}
+
+func (f *satFeature) theSearchShouldHaveBeenCancelled() error {
+	if !errors.Is(f.err, context.Canceled) {
+		return fmt.Errorf("expected the search to be cancelled, got %v", f.err)
+	}
+	return nil
+}
+
+func (f *satFeature) theClausesForAreMade(name string, inputs int) error {
+	f.function, f.inputs = functions[name], inputs
+	if f.function == nil {
+		return fmt.Errorf("unknown function %q", name)
+	}
+	f.clauses = Function(inputs, f.function)
+	return nil
+}
+
+func (f *satFeature) theClausesShouldAgreeOnEveryInput(name string) error {
+	for v := uint32(0); v < 1<<f.inputs; v++ {
+		satisfied := true
+		for _, c := range f.clauses {
+			any := false
+			for _, l := range c {
+				if l > 0 == (v>>(max(l, -l)-1)&1 == 1) {
+					any = true
+				}
+			}
+			satisfied = satisfied && any
+		}
+		if satisfied != f.function(v) {
+			return fmt.Errorf("clauses give %v for input %b, %s gives %v", satisfied, v, name, !satisfied)
+		}
+	}
+	return nil
+}
+
+func (f *satFeature) thereShouldBeAtMostClauses(n int) error {
+	if len(f.clauses) > n {
+		return fmt.Errorf("expected at most %d clauses, got %d", n, len(f.clauses))
+	}
+	return nil
+}
+
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	f := &satFeature{}
+	ctx.Step(`^the formula$`, f.theFormula)
+	ctx.Step(`^the formula putting (\d+) pigeons in (\d+) holes$`, f.theFormulaPuttingPigeonsInHoles)
+	ctx.Step(`^a random formula of (\d+) clauses over (\d+) variables satisfied by a hidden assignment from seed (\d+)$`, f.aRandomFormulaWithAHiddenAssignment)
+	ctx.Step(`^the formula is solved$`, f.theFormulaIsSolved)
+	ctx.Step(`^the formula is solved with a cancelled context$`, f.theFormulaIsSolvedWithACancelledContext)
+	ctx.Step(`^every solution is found by excluding the ones before$`, f.everySolutionIsFound)
+	ctx.Step(`^it should be (satisfiable|unsatisfiable)$`, f.itShouldBe)
+	ctx.Step(`^the solution should satisfy every clause$`, f.theSolutionShouldSatisfyEveryClause)
+	ctx.Step(`^there should be (\d+) solutions$`, f.thereShouldBeSolutions)
+	ctx.Step(`^the search should have been cancelled$`, f.theSearchShouldHaveBeenCancelled)
+	ctx.Step(`^the clauses for (.+) of (\d+) inputs are made$`, f.theClausesForAreMade)
+	ctx.Step(`^the clauses should agree with (.+) on every input$`, f.theClausesShouldAgreeOnEveryInput)
+	ctx.Step(`^there should be at most (\d+) clauses$`, f.thereShouldBeAtMostClauses)
+}
+
+func TestSatFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "sat",
+		ScenarioInitializer: InitializeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/sat.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
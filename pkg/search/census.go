@@ -0,0 +1,144 @@
+package search
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"github.com/richardwooding/gameoflife/pkg/analysis"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tally is the number of times an object was seen across all soups.
+type Tally struct {
+	Code    string        `json:"apgcode"`
+	Kind    analysis.Kind `json:"-"`
+	Count   int           `json:"count"`
+	Samples []Sample      `json:"samples,omitempty"`
+}
+
+// Census is the combined result of a soup search.
+type Census struct {
+	options      Options
+	Soups        int
+	Objects      map[string]*Tally
+	Pathological Tally
+}
+
+func newCensus(o Options) *Census {
+	return &Census{
+		options:      o,
+		Objects:      make(map[string]*Tally),
+		Pathological: Tally{Code: "PATHOLOGICAL"},
+	}
+}
+
+// add merges the census of one soup.
+func (c *Census) add(r soupResult) {
+	c.Soups++
+	if r.pathological {
+		c.Pathological.Count++
+		c.Pathological.addSample(r.sample, c.options.Samples)
+		return
+	}
+	for code, n := range r.codes {
+		t, ok := c.Objects[code]
+		if !ok {
+			t = &Tally{Code: code, Kind: r.kinds[code]}
+			c.Objects[code] = t
+		}
+		t.Count += n
+		t.addSample(r.sample, c.options.Samples)
+	}
+}
+
+// addSample keeps the soups with the lowest indices, so the samples don't depend on the order soups finish.
+func (t *Tally) addSample(s Sample, limit int) {
+	t.Samples = append(t.Samples, s)
+	sort.Slice(t.Samples, func(i, j int) bool { return t.Samples[i].Soup < t.Samples[j].Soup })
+	if len(t.Samples) > limit {
+		t.Samples = t.Samples[:limit]
+	}
+}
+
+// Sorted returns the objects from most to least common.
+func (c *Census) Sorted() []Tally {
+	tallies := make([]Tally, 0, len(c.Objects))
+	for _, t := range c.Objects {
+		tallies = append(tallies, *t)
+	}
+	sort.Slice(tallies, func(i, j int) bool {
+		if tallies[i].Count != tallies[j].Count {
+			return tallies[i].Count > tallies[j].Count
+		}
+		return tallies[i].Code < tallies[j].Code
+	})
+	return tallies
+}
+
+// reported returns the objects with samples removed from those that aren't rare.
+func (c *Census) reported() []Tally {
+	tallies := c.Sorted()
+	for i := range tallies {
+		if tallies[i].Count >= c.options.Rare {
+			tallies[i].Samples = nil
+		}
+	}
+	return tallies
+}
+
+type jsonTally struct {
+	Tally
+	Kind string `json:"kind"`
+}
+
+type jsonReport struct {
+	Rule         string      `json:"rule"`
+	Symmetry     string      `json:"symmetry"`
+	Seed         uint64      `json:"seed"`
+	Size         int         `json:"size"`
+	Density      float64     `json:"density"`
+	Soups        int         `json:"soups"`
+	Objects      []jsonTally `json:"objects"`
+	Pathological Tally       `json:"pathological"`
+}
+
+// WriteJSON writes the census as a JSON report, including sample soups for rare objects.
+func (c *Census) WriteJSON(w io.Writer) error {
+	report := jsonReport{
+		Rule:         "b3s23",
+		Symmetry:     c.options.Symmetry.String(),
+		Seed:         c.options.Seed,
+		Size:         c.options.Size,
+		Density:      c.options.Density,
+		Soups:        c.Soups,
+		Objects:      []jsonTally{},
+		Pathological: c.Pathological,
+	}
+	for _, t := range c.reported() {
+		report.Objects = append(report.Objects, jsonTally{Tally: t, Kind: t.Kind.String()})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteCSV writes the census as CSV with one row per object, including sample soups for rare objects.
+func (c *Census) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"apgcode", "kind", "count", "samples"}}
+	tallies := append(c.reported(), c.Pathological)
+	for _, t := range tallies {
+		states := make([]string, len(t.Samples))
+		for i, s := range t.Samples {
+			states[i] = s.State
+		}
+		kind := t.Kind.String()
+		if t.Code == c.Pathological.Code {
+			kind = "pathological"
+		}
+		rows = append(rows, []string{t.Code, kind, strconv.Itoa(t.Count), strings.Join(states, " ")})
+	}
+	return cw.WriteAll(rows)
+}
@@ -0,0 +1,39 @@
+Feature: Soup search and census
+
+  Scenario Outline: Soups have the requested symmetry
+    Given soups of size 12 with <symmetry> symmetry
+    When soup 3 is generated
+    Then the soup should be invariant under <transform>
+
+    Examples:
+      | symmetry | transform            |
+      | C2       | rotation by 180      |
+      | C4       | rotation by 90       |
+      | D2       | a vertical mirror    |
+      | D4       | a horizontal mirror  |
+      | D8       | a diagonal mirror    |
+
+  Scenario: The same seed always produces the same soup
+    Given soups of size 16 with C1 symmetry
+    When soup 7 is generated
+    Then generating soup 7 again should give the same soup
+    And soup 8 should be different
+
+  Scenario Outline: Soups that can't be made are refused
+    Given soups of size <size> with C1 symmetry
+    And the soup density is <density>
+    When I try to generate soup 0
+    Then the soup should be refused
+
+    Examples:
+      | size | density |
+      | 0    | 0.5     |
+      | 12   | 2       |
+      | 12   | -0.5    |
+
+  Scenario: A search tallies every soup
+    Given soups of size 8 with C1 symmetry
+    When 6 soups are searched
+    Then the census should cover 6 soups
+    And every object in the census should have an apgcode
+    And the JSON report should list the rule "b3s23"
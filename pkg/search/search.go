@@ -0,0 +1,273 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/richardwooding/gameoflife/model"
+	"github.com/richardwooding/gameoflife/pkg/analysis"
+	"github.com/richardwooding/gameoflife/pkg/game"
+	"hash/fnv"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+)
+
+// Options configures a soup search.
+type Options struct {
+	Soups          int            // Number of soups to run
+	Seed           uint64         // Seed from which every soup is derived
+	Size           int            // Width and height of each soup
+	Density        float64        // Probability that a soup cell is alive
+	Symmetry       model.Symmetry // Symmetry imposed on each soup
+	Padding        int            // Dead cells around the soup for the ash to spread into
+	MaxGenerations int            // Generations after which a soup that hasn't settled is pathological
+	MaxPeriod      int            // Longest period looked for when classifying an object
+	Workers        int            // Number of soups run in parallel
+	Samples        int            // Sample soups kept for each object
+	Rare           int            // Objects seen fewer times than this are reported with their samples
+}
+
+// DefaultOptions returns options for a search of 16×16 soups at 50% density on all CPU cores.
+func DefaultOptions() Options {
+	return Options{
+		Soups:          1000,
+		Seed:           1,
+		Size:           16,
+		Density:        0.5,
+		Symmetry:       model.C1,
+		Padding:        48,
+		MaxGenerations: 6000,
+		MaxPeriod:      64,
+		Workers:        runtime.NumCPU(),
+		Samples:        3,
+		Rare:           10,
+	}
+}
+
+var InvalidOptions = errors.New("invalid search options")
+
+// Validate checks the options describe a search that can be run, with soups small enough for their state strings
+// to open in the web app.
+func (o Options) Validate() error {
+	switch {
+	case o.Soups < 0:
+		return fmt.Errorf("%w: soups %d is negative", InvalidOptions, o.Soups)
+	case o.Padding < 0:
+		return fmt.Errorf("%w: padding %d is negative", InvalidOptions, o.Padding)
+	case o.Size < 1 || o.Size+2*o.Padding > game.MaxStateSize:
+		return fmt.Errorf("%w: soups of size %d padded by %d are not between 1 and %d cells across", InvalidOptions, o.Size, o.Padding, game.MaxStateSize)
+	case !(o.Density >= 0 && o.Density <= 1):
+		return fmt.Errorf("%w: density %g is not between 0 and 1", InvalidOptions, o.Density)
+	case o.Symmetry > model.D8:
+		return fmt.Errorf("%w: %w", InvalidOptions, model.InvalidSymmetry)
+	case o.MaxGenerations < 1:
+		return fmt.Errorf("%w: max generations %d is less than 1", InvalidOptions, o.MaxGenerations)
+	case o.MaxPeriod < 1:
+		return fmt.Errorf("%w: max period %d is less than 1", InvalidOptions, o.MaxPeriod)
+	case o.Workers < 1:
+		return fmt.Errorf("%w: workers %d is less than 1", InvalidOptions, o.Workers)
+	case o.Samples < 0:
+		return fmt.Errorf("%w: samples %d is negative", InvalidOptions, o.Samples)
+	case o.Rare < 0:
+		return fmt.Errorf("%w: rare %d is negative", InvalidOptions, o.Rare)
+	}
+	return nil
+}
+
+// Unclassified is the code tallied for objects that don't repeat within the maximum period.
+const Unclassified = "zz_UNKNOWN"
+
+// escapeBand is the width of the border in which spaceships are removed before they hit the edge.
+const escapeBand = 6
+
+// escapeInterval is how often, in generations, the border is checked for escaping spaceships.
+const escapeInterval = 12
+
+// Sample identifies a soup that produced an object.
+type Sample struct {
+	Soup  int    `json:"soup"`
+	State string `json:"state"` // State string that opens the soup in the web app
+}
+
+// soupResult is the census of a single soup.
+type soupResult struct {
+	sample       Sample
+	codes        map[string]int
+	kinds        map[string]analysis.Kind
+	pathological bool
+	err          error
+}
+
+// Soup returns the colony for the i-th soup of a search, with its padding.
+// Each soup has a seed of its own, recorded in the colony so its state string recreates it.
+func (o Options) Soup(i int) (*model.Colony, error) {
+	// A region of size 0 would reach the edge of the colony, randomising the padding as well.
+	if o.Size < 1 {
+		return nil, fmt.Errorf("%w: soups of size %d", InvalidOptions, o.Size)
+	}
+	colony := model.NewColony(o.Size+2*o.Padding, o.Size+2*o.Padding)
+	err := colony.Randomize(model.RandomOptions{
+		Seed:     rand.New(rand.NewPCG(o.Seed, uint64(i))).Uint64(),
+		Density:  o.Density,
+		Symmetry: o.Symmetry,
+		X:        o.Padding,
+		Y:        o.Padding,
+		Width:    o.Size,
+		Height:   o.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return colony, nil
+}
+
+// Run searches the soups in parallel and returns their combined census.
+// It stops early, returning the census so far, if the context is cancelled or a soup can't be made.
+func Run(ctx context.Context, o Options) (*Census, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	workers := o.Workers
+	jobs := make(chan int)
+	results := make(chan soupResult)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- o.runSoup(i)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := 0; i < o.Soups; i++ {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	census := newCensus(o)
+	var err error
+	for r := range results {
+		if r.err != nil {
+			if err == nil {
+				err = r.err
+				cancel()
+			}
+			continue
+		}
+		census.add(r)
+	}
+	if err != nil {
+		return census, err
+	}
+	return census, ctx.Err()
+}
+
+// runSoup runs the i-th soup until it settles and tallies the objects in its ash.
+func (o Options) runSoup(i int) soupResult {
+	colony, err := o.Soup(i)
+	if err != nil {
+		return soupResult{err: err}
+	}
+	result := soupResult{
+		sample: Sample{Soup: i, State: game.EncodeState(colony)},
+		codes:  make(map[string]int),
+		kinds:  make(map[string]analysis.Kind),
+	}
+	seen := make(map[uint64]bool)
+	settled := false
+	for gen := 0; gen < o.MaxGenerations; gen++ {
+		if gen%escapeInterval == 0 && o.removeEscapes(colony, &result) {
+			clear(seen)
+		}
+		h := hash(colony)
+		if seen[h] {
+			settled = true
+			break
+		}
+		seen[h] = true
+		colony.Generate()
+	}
+	if !settled {
+		result.pathological = true
+		return result
+	}
+	for _, object := range analysis.Separate(colony, o.separation()) {
+		result.tally(analysis.Classify(object, model.Life, o.MaxPeriod))
+	}
+	return result
+}
+
+// removeEscapes tallies and erases spaceships near the edge of the colony, reporting whether any were found.
+func (o Options) removeEscapes(colony *model.Colony, result *soupResult) bool {
+	removed := false
+	cells := colony.Cells()
+	for _, object := range analysis.Separate(colony, o.separation()) {
+		x, y, w, h := object.Bounds()
+		if x >= escapeBand && y >= escapeBand && x+w <= colony.Width()-escapeBand && y+h <= colony.Height()-escapeBand {
+			continue
+		}
+		class := analysis.Classify(object, model.Life, o.MaxPeriod)
+		if class.Kind != analysis.Spaceship {
+			continue
+		}
+		result.tally(class)
+		for _, cell := range object.Cells {
+			(*cells)[cell[1]][cell[0]] = false
+		}
+		removed = true
+	}
+	return removed
+}
+
+// separation returns the analysis options used to split ash into objects.
+func (o Options) separation() analysis.Options {
+	separation := analysis.DefaultOptions()
+	separation.MaxPeriod = o.MaxPeriod
+	return separation
+}
+
+// tally counts one classified object.
+func (r *soupResult) tally(class analysis.Classification) {
+	code := class.Code
+	if class.Kind == analysis.Unknown {
+		code = Unclassified
+	}
+	r.codes[code]++
+	r.kinds[code] = class.Kind
+}
+
+// hash returns a fingerprint of the live cells of the colony.
+func hash(colony *model.Colony) uint64 {
+	h := fnv.New64a()
+	var b [8]byte
+	n := 0
+	for _, row := range *colony.Cells() {
+		for _, alive := range row {
+			b[n/8] <<= 1
+			if alive {
+				b[n/8] |= 1
+			}
+			n++
+			if n == 64 {
+				h.Write(b[:])
+				b, n = [8]byte{}, 0
+			}
+		}
+	}
+	h.Write(b[:])
+	return h.Sum64()
+}
@@ -0,0 +1,186 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/richardwooding/gameoflife/model"
+	"strings"
+	"testing"
+)
+
+type searchFeature struct {
+	options Options
+	soup    *model.Colony
+	census  *Census
+	err     error
+}
+
+func (f *searchFeature) soupsOfSizeWithSymmetry(size int, symmetry string) error {
+	f.options = DefaultOptions()
+	f.options.Size = size
+	f.options.Padding = 8
+	f.options.MaxGenerations = 2000
+	var err error
+	f.options.Symmetry, err = model.ParseSymmetry(symmetry)
+	return err
+}
+
+func (f *searchFeature) soupIsGenerated(i int) error {
+	var err error
+	f.soup, err = f.options.Soup(i)
+	return err
+}
+
+func (f *searchFeature) theSoupDensityIs(density float64) error {
+	f.options.Density = density
+	return nil
+}
+
+func (f *searchFeature) iTryToGenerateSoup(i int) error {
+	f.soup, f.err = f.options.Soup(i)
+	return nil
+}
+
+func (f *searchFeature) theSoupShouldBeRefused() error {
+	if f.err == nil {
+		return fmt.Errorf("expected the soup to be refused")
+	}
+	return nil
+}
+
+// at returns the soup cell at (x, y) relative to the soup's corner.
+func (f *searchFeature) at(x, y int) bool {
+	return f.soup.IsAlive(x+f.options.Padding, y+f.options.Padding)
+}
+
+func (f *searchFeature) theSoupShouldBeInvariantUnder(transform string) error {
+	m := f.options.Size - 1
+	image := map[string]func(x, y int) (int, int){
+		"rotation by 180":     func(x, y int) (int, int) { return m - x, m - y },
+		"rotation by 90":      func(x, y int) (int, int) { return m - y, x },
+		"a vertical mirror":   func(x, y int) (int, int) { return m - x, y },
+		"a horizontal mirror": func(x, y int) (int, int) { return x, m - y },
+		"a diagonal mirror":   func(x, y int) (int, int) { return y, x },
+	}[transform]
+	for y := 0; y <= m; y++ {
+		for x := 0; x <= m; x++ {
+			ix, iy := image(x, y)
+			if f.at(x, y) != f.at(ix, iy) {
+				return fmt.Errorf("cell (%d,%d) differs from its image (%d,%d)", x, y, ix, iy)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *searchFeature) generatingSoupAgainShouldGiveTheSameSoup(i int) error {
+	soup, err := f.options.Soup(i)
+	if err != nil {
+		return err
+	}
+	if encodeCells(soup) != encodeCells(f.soup) {
+		return fmt.Errorf("soup %d changed between runs", i)
+	}
+	return nil
+}
+
+func (f *searchFeature) soupShouldBeDifferent(i int) error {
+	soup, err := f.options.Soup(i)
+	if err != nil {
+		return err
+	}
+	if encodeCells(soup) == encodeCells(f.soup) {
+		return fmt.Errorf("soup %d is the same as the previous soup", i)
+	}
+	return nil
+}
+
+func (f *searchFeature) soupsAreSearched(n int) error {
+	f.options.Soups = n
+	var err error
+	f.census, err = Run(context.Background(), f.options)
+	return err
+}
+
+func (f *searchFeature) theCensusShouldCoverSoups(n int) error {
+	if f.census.Soups != n {
+		return fmt.Errorf("expected %d soups, got %d", n, f.census.Soups)
+	}
+	return nil
+}
+
+func (f *searchFeature) everyObjectInTheCensusShouldHaveAnApgcode() error {
+	for _, t := range f.census.Sorted() {
+		if !strings.HasPrefix(t.Code, "x") && t.Code != Unclassified {
+			return fmt.Errorf("unexpected code %q", t.Code)
+		}
+	}
+	return nil
+}
+
+func (f *searchFeature) theJSONReportShouldListTheRule(rule string) error {
+	var buff bytes.Buffer
+	if err := f.census.WriteJSON(&buff); err != nil {
+		return err
+	}
+	var report struct {
+		Rule string `json:"rule"`
+	}
+	if err := json.Unmarshal(buff.Bytes(), &report); err != nil {
+		return err
+	}
+	if report.Rule != rule {
+		return fmt.Errorf("expected rule %q, got %q", rule, report.Rule)
+	}
+	return nil
+}
+
+// encodeCells renders the live cells of a colony as text for comparison.
+func encodeCells(c *model.Colony) string {
+	var b strings.Builder
+	for _, row := range *c.Cells() {
+		for _, alive := range row {
+			if alive {
+				b.WriteByte('o')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	f := &searchFeature{}
+	ctx.Step(`^soups of size (\d+) with (\w+) symmetry$`, f.soupsOfSizeWithSymmetry)
+	ctx.Step(`^soup (\d+) is generated$`, f.soupIsGenerated)
+	ctx.Step(`^the soup density is (-?[\d.]+)$`, f.theSoupDensityIs)
+	ctx.Step(`^I try to generate soup (\d+)$`, f.iTryToGenerateSoup)
+	ctx.Step(`^the soup should be refused$`, f.theSoupShouldBeRefused)
+	ctx.Step(`^the soup should be invariant under (.+)$`, f.theSoupShouldBeInvariantUnder)
+	ctx.Step(`^generating soup (\d+) again should give the same soup$`, f.generatingSoupAgainShouldGiveTheSameSoup)
+	ctx.Step(`^soup (\d+) should be different$`, f.soupShouldBeDifferent)
+	ctx.Step(`^(\d+) soups are searched$`, f.soupsAreSearched)
+	ctx.Step(`^the census should cover (\d+) soups$`, f.theCensusShouldCoverSoups)
+	ctx.Step(`^every object in the census should have an apgcode$`, f.everyObjectInTheCensusShouldHaveAnApgcode)
+	ctx.Step(`^the JSON report should list the rule "([^"]*)"$`, f.theJSONReportShouldListTheRule)
+}
+
+func TestSearchFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "search",
+		ScenarioInitializer: InitializeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features/search.feature"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
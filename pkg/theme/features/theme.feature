@@ -0,0 +1,83 @@
+Feature: Themes
+
+  Scenario Outline: Built-in themes are found by name
+    When I look up the theme "<name>"
+    Then it should be the "<label>" theme
+
+    Examples:
+      | name          | label         |
+      | dark          | Dark          |
+      | Light         | Light         |
+      | high-contrast | High contrast |
+      | GOLLY         | Golly classic |
+
+  Scenario: Unknown themes are not found
+    When I look up the theme "solarized"
+    Then no theme should be found
+
+  Scenario Outline: The automatic theme follows the system's preference
+    Given the settings choose the "<choice>" theme
+    When the system prefers <scheme> colours
+    Then the settings should resolve to the "<label>" theme
+
+    Examples:
+      | choice        | scheme | label         |
+      | auto          | dark   | Dark          |
+      | auto          | light  | Light         |
+      |               | light  | Light         |
+      | solarized     | dark   | Dark          |
+      | high-contrast | light  | High contrast |
+      | golly         | dark   | Golly classic |
+
+  Scenario: Editing a colour starts a custom theme from the current one
+    Given the settings choose the "golly" theme
+    When I set the custom "alive" colour to "#ff8000"
+    Then the settings should resolve to the "Custom" theme
+    And the resolved "alive" colour should be "#ff8000"
+    And the resolved "dead" colour should be "#303030"
+
+  Scenario: Edits to the custom theme add up
+    Given the settings choose the "light" theme
+    When I set the custom "dead" colour to "#000"
+    And I set the custom "history" colour to "#0000ff"
+    Then the resolved "dead" colour should be "#000000"
+    And the resolved "history" colour should be "#0000ff"
+    And the resolved "alive" colour should be "#202020"
+
+  Scenario Outline: Invalid edits leave the settings alone
+    Given the settings choose the "dark" theme
+    When I set the custom "<field>" colour to "<value>"
+    Then the edit should fail
+    And the settings should resolve to the "Dark" theme
+
+    Examples:
+      | field  | value   |
+      | alive  | green   |
+      | border | #ffffff |
+
+  Scenario: Settings survive being saved as JSON
+    Given the settings choose the "high-contrast" theme
+    And I set the custom "grid" colour to "#123456"
+    When the settings are saved and loaded again
+    Then the settings should resolve to the "Custom" theme
+    And the resolved "grid" colour should be "#123456"
+
+  Scenario: Exports are drawn in the theme's colours
+    Given the settings choose the "light" theme
+    Then the render options should draw live cells "#202020" on "#ffffff"
+
+  Scenario: The style sheet gets the theme's colours
+    Given the settings choose the "high-contrast" theme
+    Then the CSS variable "--alive" should be "#ffff00"
+    And the CSS variable "--background" should be "#000000"
+
+  Scenario Outline: Live cells stand out from dead cells in every theme
+    When I look up the theme "<name>"
+    Then its live and dead cells should have a contrast ratio of at least <ratio>
+
+    Examples:
+      | name          | ratio |
+      | dark          | 7     |
+      | light         | 7     |
+      | high-contrast | 15    |
+      | golly         | 7     |
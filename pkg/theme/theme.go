@@ -0,0 +1,206 @@
+// Package theme provides the colour schemes the board, the page around it and image downloads are drawn in.
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/richardwooding/gameoflife/pkg/render"
+	"image/color"
+	"strings"
+)
+
+// Colour is a colour that is written to JSON in "#rrggbb" notation.
+type Colour color.RGBA
+
+// ParseColour parses a colour in "#rgb" or "#rrggbb" notation.
+func ParseColour(s string) (Colour, error) {
+	c, err := render.ParseColor(s)
+	return Colour(c), err
+}
+
+func (c Colour) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// RGBA returns the colour for drawing.
+func (c Colour) RGBA() color.RGBA {
+	return color.RGBA(c)
+}
+
+func (c Colour) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *Colour) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseColour(s)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Theme is a set of colours for cells and for the page.
+type Theme struct {
+	Name       string `json:"name"`       // Identifier used in settings and on the command line
+	Label      string `json:"label"`      // Name shown to people
+	Alive      Colour `json:"alive"`      // Live cells
+	Dead       Colour `json:"dead"`       // Dead cells
+	Grid       Colour `json:"grid"`       // Lines between cells
+	History    Colour `json:"history"`    // Recently dead cells, which fade from it to dead in the trail display
+	Old        Colour `json:"old"`        // The oldest live cells in the age display
+	Background Colour `json:"background"` // The page
+	Text       Colour `json:"text"`       // Text on the page
+}
+
+// Dark is the original look: green cells on black.
+var Dark = Theme{
+	Name:       "dark",
+	Label:      "Dark",
+	Alive:      Colour{R: 0xad, G: 0xff, B: 0x2f, A: 0xff},
+	Dead:       Colour{A: 0xff},
+	Grid:       Colour{R: 0x40, G: 0x40, B: 0x40, A: 0xff},
+	History:    Colour{R: 0x56, G: 0x7f, B: 0x17, A: 0xff},
+	Old:        Colour{R: 0x1e, G: 0x90, B: 0xff, A: 0xff},
+	Background: Colour{R: 0x1e, G: 0x1e, B: 0x1e, A: 0xff},
+	Text:       Colour{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff},
+}
+
+// Light draws dark cells on white.
+var Light = Theme{
+	Name:       "light",
+	Label:      "Light",
+	Alive:      Colour{R: 0x20, G: 0x20, B: 0x20, A: 0xff},
+	Dead:       Colour{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	Grid:       Colour{R: 0xd0, G: 0xd0, B: 0xd0, A: 0xff},
+	History:    Colour{R: 0x8c, G: 0xa8, B: 0xd8, A: 0xff},
+	Old:        Colour{R: 0xd2, G: 0x69, B: 0x1e, A: 0xff},
+	Background: Colour{R: 0xfa, G: 0xfa, B: 0xfa, A: 0xff},
+	Text:       Colour{R: 0x10, G: 0x10, B: 0x10, A: 0xff},
+}
+
+// HighContrast uses pure colours far apart in brightness.
+var HighContrast = Theme{
+	Name:       "high-contrast",
+	Label:      "High contrast",
+	Alive:      Colour{R: 0xff, G: 0xff, A: 0xff},
+	Dead:       Colour{A: 0xff},
+	Grid:       Colour{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	History:    Colour{G: 0xff, B: 0xff, A: 0xff},
+	Old:        Colour{R: 0xff, B: 0xff, A: 0xff},
+	Background: Colour{A: 0xff},
+	Text:       Colour{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+}
+
+// GollyClassic follows the default colours of Golly: white cells on dark grey.
+var GollyClassic = Theme{
+	Name:       "golly",
+	Label:      "Golly classic",
+	Alive:      Colour{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	Dead:       Colour{R: 0x30, G: 0x30, B: 0x30, A: 0xff},
+	Grid:       Colour{R: 0x50, G: 0x50, B: 0x50, A: 0xff},
+	History:    Colour{R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+	Old:        Colour{R: 0xff, G: 0xd7, A: 0xff},
+	Background: Colour{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff},
+	Text:       Colour{A: 0xff},
+}
+
+// Themes lists the built-in themes in the order they are offered.
+var Themes = []Theme{Dark, Light, HighContrast, GollyClassic}
+
+// Named returns the built-in theme with the name, ignoring case.
+func Named(name string) (Theme, bool) {
+	for _, t := range Themes {
+		if strings.EqualFold(name, t.Name) {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// Options returns the render options that draw cells in the theme's colours.
+func (t Theme) Options() render.Options {
+	o := render.DefaultOptions()
+	o.Alive, o.Dead, o.Grid = t.Alive.RGBA(), t.Dead.RGBA(), t.Grid.RGBA()
+	o.History, o.Old = t.History.RGBA(), t.Old.RGBA()
+	return o
+}
+
+// Variables returns the CSS custom properties web/gameoflife.css takes its colours from.
+func (t Theme) Variables() map[string]string {
+	return map[string]string{
+		"--alive":      t.Alive.String(),
+		"--dead":       t.Dead.String(),
+		"--grid":       t.Grid.String(),
+		"--history":    t.History.String(),
+		"--background": t.Background.String(),
+		"--text":       t.Text.String(),
+	}
+}
+
+const (
+	Auto   = "auto"   // Follows the light or dark preference of the system
+	Custom = "custom" // The colours picked in the editor
+)
+
+// Settings is the choice of theme kept in local storage.
+type Settings struct {
+	Theme  string `json:"theme"`            // Name of a built-in theme, Auto or Custom
+	Custom *Theme `json:"custom,omitempty"` // Colours of the custom theme, once it has been edited
+}
+
+// Resolve returns the theme the settings choose. Auto, and names that aren't known, choose Dark or Light by
+// the system's preference, and Custom chooses the edited colours, starting from those of the preference.
+func (s Settings) Resolve(prefersDark bool) Theme {
+	preferred := Light
+	if prefersDark {
+		preferred = Dark
+	}
+	if s.Theme == Custom {
+		if s.Custom != nil {
+			return *s.Custom
+		}
+		return customFrom(preferred)
+	}
+	if t, ok := Named(s.Theme); ok {
+		return t
+	}
+	return preferred
+}
+
+// Edit changes one of the colours of the custom theme, by the name of its JSON field, starting the custom
+// theme from base if it hasn't been edited before.
+func (s *Settings) Edit(base Theme, field string, value string) error {
+	c, err := ParseColour(value)
+	if err != nil {
+		return err
+	}
+	custom := customFrom(base)
+	if s.Custom != nil {
+		custom = *s.Custom
+	}
+	switch field {
+	case "alive":
+		custom.Alive = c
+	case "dead":
+		custom.Dead = c
+	case "grid":
+		custom.Grid = c
+	case "history":
+		custom.History = c
+	default:
+		return fmt.Errorf("unknown theme colour %q", field)
+	}
+	s.Theme, s.Custom = Custom, &custom
+	return nil
+}
+
+// customFrom returns a custom theme with the colours of t.
+func customFrom(t Theme) Theme {
+	t.Name, t.Label = Custom, "Custom"
+	return t
+}
@@ -0,0 +1,159 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cucumber/godog"
+	"math"
+	"testing"
+)
+
+type themeFeature struct {
+	theme       Theme
+	found       bool
+	settings    Settings
+	prefersDark bool
+	err         error
+}
+
+func (f *themeFeature) iLookUpTheTheme(name string) error {
+	f.theme, f.found = Named(name)
+	return nil
+}
+
+func (f *themeFeature) itShouldBeTheTheme(label string) error {
+	if !f.found {
+		return fmt.Errorf("expected the %q theme, got none", label)
+	}
+	if f.theme.Label != label {
+		return fmt.Errorf("expected the %q theme, got %q", label, f.theme.Label)
+	}
+	return nil
+}
+
+func (f *themeFeature) noThemeShouldBeFound() error {
+	if f.found {
+		return fmt.Errorf("expected no theme, got %q", f.theme.Label)
+	}
+	return nil
+}
+
+func (f *themeFeature) theSettingsChooseTheTheme(name string) error {
+	f.settings = Settings{Theme: name}
+	f.prefersDark = true
+	return nil
+}
+
+func (f *themeFeature) theSystemPrefersColours(scheme string) error {
+	f.prefersDark = scheme == "dark"
+	return nil
+}
+
+func (f *themeFeature) theSettingsShouldResolveToTheTheme(label string) error {
+	if got := f.settings.Resolve(f.prefersDark).Label; got != label {
+		return fmt.Errorf("expected the %q theme, got %q", label, got)
+	}
+	return nil
+}
+
+func (f *themeFeature) iSetTheCustomColourTo(field, value string) error {
+	f.err = f.settings.Edit(f.settings.Resolve(f.prefersDark), field, value)
+	return nil
+}
+
+func (f *themeFeature) theEditShouldFail() error {
+	if f.err == nil {
+		return fmt.Errorf("expected the edit to fail")
+	}
+	return nil
+}
+
+func (f *themeFeature) theResolvedColourShouldBe(field, value string) error {
+	t := f.settings.Resolve(f.prefersDark)
+	colours := map[string]Colour{"alive": t.Alive, "dead": t.Dead, "grid": t.Grid, "history": t.History}
+	if got := colours[field].String(); got != value {
+		return fmt.Errorf("expected the %s colour to be %s, got %s", field, value, got)
+	}
+	return nil
+}
+
+func (f *themeFeature) theSettingsAreSavedAndLoadedAgain() error {
+	b, err := json.Marshal(f.settings)
+	if err != nil {
+		return err
+	}
+	f.settings = Settings{}
+	return json.Unmarshal(b, &f.settings)
+}
+
+func (f *themeFeature) theRenderOptionsShouldDrawLiveCellsOn(alive, dead string) error {
+	o := f.settings.Resolve(f.prefersDark).Options()
+	hex := func(c interface{ RGBA() (r, g, b, a uint32) }) string {
+		r, g, b, _ := c.RGBA()
+		return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+	}
+	if hex(o.Alive) != alive || hex(o.Dead) != dead {
+		return fmt.Errorf("expected %s on %s, got %s on %s", alive, dead, hex(o.Alive), hex(o.Dead))
+	}
+	return nil
+}
+
+func (f *themeFeature) theCSSVariableShouldBe(name, value string) error {
+	if got := f.settings.Resolve(f.prefersDark).Variables()[name]; got != value {
+		return fmt.Errorf("expected %s to be %s, got %q", name, value, got)
+	}
+	return nil
+}
+
+// luminance returns the relative luminance of a colour as defined by WCAG.
+func luminance(c Colour) float64 {
+	channel := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+func (f *themeFeature) itsLiveAndDeadCellsShouldHaveAContrastRatioOfAtLeast(ratio float64) error {
+	a, d := luminance(f.theme.Alive), luminance(f.theme.Dead)
+	got := (math.Max(a, d) + 0.05) / (math.Min(a, d) + 0.05)
+	if got < ratio {
+		return fmt.Errorf("expected a contrast ratio of at least %v, got %.2f", ratio, got)
+	}
+	return nil
+}
+
+func InitializeThemeScenario(ctx *godog.ScenarioContext) {
+	f := &themeFeature{}
+	ctx.Step(`^I look up the theme "([^"]*)"$`, f.iLookUpTheTheme)
+	ctx.Step(`^it should be the "([^"]*)" theme$`, f.itShouldBeTheTheme)
+	ctx.Step(`^no theme should be found$`, f.noThemeShouldBeFound)
+	ctx.Step(`^the settings choose the "([^"]*)" theme$`, f.theSettingsChooseTheTheme)
+	ctx.Step(`^the system prefers (dark|light) colours$`, f.theSystemPrefersColours)
+	ctx.Step(`^the settings should resolve to the "([^"]*)" theme$`, f.theSettingsShouldResolveToTheTheme)
+	ctx.Step(`^I set the custom "([^"]*)" colour to "([^"]*)"$`, f.iSetTheCustomColourTo)
+	ctx.Step(`^the edit should fail$`, f.theEditShouldFail)
+	ctx.Step(`^the resolved "([^"]*)" colour should be "([^"]*)"$`, f.theResolvedColourShouldBe)
+	ctx.Step(`^the settings are saved and loaded again$`, f.theSettingsAreSavedAndLoadedAgain)
+	ctx.Step(`^the render options should draw live cells "([^"]*)" on "([^"]*)"$`, f.theRenderOptionsShouldDrawLiveCellsOn)
+	ctx.Step(`^the CSS variable "([^"]*)" should be "([^"]*)"$`, f.theCSSVariableShouldBe)
+	ctx.Step(`^its live and dead cells should have a contrast ratio of at least (\d+)$`, f.itsLiveAndDeadCellsShouldHaveAContrastRatioOfAtLeast)
+}
+
+func TestThemeFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "theme",
+		ScenarioInitializer: InitializeThemeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}
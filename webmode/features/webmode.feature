@@ -0,0 +1,24 @@
+Feature: Web modes
+
+  Scenario Outline: Web modes are parsed from their names
+    When I parse the web mode "<name>"
+    Then it should be the <mode> web mode
+    And it should be valid
+
+    Examples:
+      | name   | mode   |
+      | live   | live   |
+      | static | static |
+      | dev    | dev    |
+
+  Scenario Outline: Unknown web modes are invalid
+    When I parse the web mode "<name>"
+    Then parsing should fail
+    And it should be the unknown web mode
+    And it should not be valid
+
+    Examples:
+      | name       |
+      | production |
+      | DEV        |
+      |            |
@@ -10,6 +10,7 @@ const (
 	Unknown WebMode = iota
 	Live
 	Static
+	Dev
 )
 
 func (m WebMode) String() string {
@@ -18,6 +19,8 @@ func (m WebMode) String() string {
 		return "live"
 	case Static:
 		return "static"
+	case Dev:
+		return "dev"
 	default:
 		return "unknown"
 	}
@@ -32,11 +35,15 @@ func (m WebMode) IsLive() bool {
 func (m WebMode) IsStatic() bool {
 	return m == Static
 }
+
+func (m WebMode) IsDev() bool {
+	return m == Dev
+}
 func (m WebMode) IsUnknown() bool {
 	return m == Unknown
 }
 func (m WebMode) IsValid() bool {
-	return m == Live || m == Static
+	return m == Live || m == Static || m == Dev
 }
 
 func ParseWebMode(s string) (WebMode, error) {
@@ -45,6 +52,8 @@ func ParseWebMode(s string) (WebMode, error) {
 		return Live, nil
 	case "static":
 		return Static, nil
+	case "dev":
+		return Dev, nil
 	default:
 		return Unknown, InvalidWebMode
 	}
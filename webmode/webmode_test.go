@@ -0,0 +1,69 @@
+package webmode
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"testing"
+)
+
+type webModeFeature struct {
+	mode WebMode
+	err  error
+}
+
+func (f *webModeFeature) iParseTheWebMode(name string) error {
+	f.mode, f.err = ParseWebMode(name)
+	return nil
+}
+
+func (f *webModeFeature) itShouldBeTheWebMode(name string) error {
+	if f.mode.String() != name {
+		return fmt.Errorf("expected the %s web mode, got %s", name, f.mode)
+	}
+	return nil
+}
+
+func (f *webModeFeature) itShouldBeValid() error {
+	if f.err != nil || !f.mode.IsValid() {
+		return fmt.Errorf("expected a valid web mode, got %s (%v)", f.mode, f.err)
+	}
+	return nil
+}
+
+func (f *webModeFeature) parsingShouldFail() error {
+	if f.err != InvalidWebMode {
+		return fmt.Errorf("expected %v, got %v", InvalidWebMode, f.err)
+	}
+	return nil
+}
+
+func (f *webModeFeature) itShouldNotBeValid() error {
+	if f.mode.IsValid() {
+		return fmt.Errorf("expected %s not to be valid", f.mode)
+	}
+	return nil
+}
+
+func InitializeWebModeScenario(ctx *godog.ScenarioContext) {
+	f := &webModeFeature{}
+	ctx.Step(`^I parse the web mode "([^"]*)"$`, f.iParseTheWebMode)
+	ctx.Step(`^it should be the (\w+) web mode$`, f.itShouldBeTheWebMode)
+	ctx.Step(`^it should be valid$`, f.itShouldBeValid)
+	ctx.Step(`^parsing should fail$`, f.parsingShouldFail)
+	ctx.Step(`^it should not be valid$`, f.itShouldNotBeValid)
+}
+
+func TestWebModeFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "webmode",
+		ScenarioInitializer: InitializeWebModeScenario,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("there were test failures")
+	}
+}